@@ -4,11 +4,14 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	// Embeds the IANA time zones used by silence windows: the release image
+	// ships without tzdata
+	_ "time/tzdata"
 
 	"k8s-monitoring-app/internal/core"
 	"k8s-monitoring-app/internal/env"
-	"k8s-monitoring-app/internal/monitoring"
 	"k8s-monitoring-app/internal/server"
+	serverModel "k8s-monitoring-app/internal/server/model"
 
 	"github.com/rs/zerolog/log"
 )
@@ -24,15 +27,10 @@ func main() {
 		log.Error().Msgf("Erro ao criar servidor: %s", err.Error())
 		os.Exit(1)
 	}
-	defer httpServer.SQLite.Close()
-
-	// Initialize and start monitoring service
-	monitoringSvc, err := monitoring.NewMonitoringService(httpServer.SQLite)
-	if err != nil {
-		log.Error().Msgf("Erro ao criar serviço de monitoramento: %s", err.Error())
-		os.Exit(1)
-	}
+	defer httpServer.DB.Close()
 
+	// Start monitoring service
+	monitoringSvc := serverModel.ServerSvc.Monitoring
 	if err := monitoringSvc.Start(); err != nil {
 		log.Error().Msgf("Erro ao iniciar serviço de monitoramento: %s", err.Error())
 		os.Exit(1)
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS alerts_sent_daily;
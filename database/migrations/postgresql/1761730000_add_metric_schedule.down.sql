@@ -0,0 +1,3 @@
+ALTER TABLE application_metrics DROP COLUMN IF EXISTS jitter_seconds;
+ALTER TABLE application_metrics DROP COLUMN IF EXISTS cron_expression;
+ALTER TABLE application_metrics DROP COLUMN IF EXISTS interval_seconds;
@@ -0,0 +1,5 @@
+-- Per-metric collection schedule
+-- interval_seconds = 0 and an empty cron_expression fall back to METRICS_COLLECTION_INTERVAL
+ALTER TABLE application_metrics ADD COLUMN IF NOT EXISTS interval_seconds integer NOT NULL DEFAULT 0;
+ALTER TABLE application_metrics ADD COLUMN IF NOT EXISTS cron_expression text NOT NULL DEFAULT '';
+ALTER TABLE application_metrics ADD COLUMN IF NOT EXISTS jitter_seconds integer NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_application_metric_values_created_at;
+DROP INDEX IF EXISTS idx_application_metric_values_metric_id;
+DROP INDEX IF EXISTS idx_application_metrics_type_id;
+DROP INDEX IF EXISTS idx_application_metrics_application_id;
+DROP INDEX IF EXISTS idx_applications_project_id;
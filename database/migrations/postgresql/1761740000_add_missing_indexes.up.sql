@@ -0,0 +1,6 @@
+-- Indexes matching the SQLite schema, used by the latest value, history and cleanup queries
+CREATE INDEX IF NOT EXISTS idx_applications_project_id ON applications(project_id);
+CREATE INDEX IF NOT EXISTS idx_application_metrics_application_id ON application_metrics(application_id);
+CREATE INDEX IF NOT EXISTS idx_application_metrics_type_id ON application_metrics(type_id);
+CREATE INDEX IF NOT EXISTS idx_application_metric_values_metric_id ON application_metric_values(application_metric_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_application_metric_values_created_at ON application_metric_values(created_at);
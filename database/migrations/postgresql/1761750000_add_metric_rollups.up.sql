@@ -0,0 +1,17 @@
+-- Hourly and daily aggregates of application_metric_values, kept longer than the raw values
+-- fields holds {"<field>": {"min", "max", "sum", "p95"}} for the fields present in the bucket
+CREATE TABLE IF NOT EXISTS application_metric_rollups (
+	id uuid NOT NULL DEFAULT uuid_generate_v4(),
+	application_metric_id uuid NOT NULL,
+	resolution varchar(10) NOT NULL,
+	bucket_start timestamp NOT NULL,
+	samples integer NOT NULL,
+	up_samples integer NOT NULL,
+	"fields" jsonb NOT NULL,
+	"created_at" timestamp NOT NULL DEFAULT now(),
+	CONSTRAINT application_metric_rollups_pk PRIMARY KEY (id),
+	CONSTRAINT application_metric_rollups_bucket_uk UNIQUE (application_metric_id, resolution, bucket_start),
+	CONSTRAINT application_metric_rollups_application_metric_fk FOREIGN KEY (application_metric_id) REFERENCES application_metrics(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_application_metric_rollups_resolution_bucket ON application_metric_rollups(resolution, bucket_start);
@@ -0,0 +1,2 @@
+ALTER TABLE projects DROP COLUMN IF EXISTS retention_days;
+ALTER TABLE application_metrics DROP COLUMN IF EXISTS retention_days;
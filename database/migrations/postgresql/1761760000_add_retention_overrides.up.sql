@@ -0,0 +1,4 @@
+-- Per-metric and per-project retention of the raw metric values
+-- retention_days = 0 falls back to the project's retention, then to METRICS_RETENTION_DAYS
+ALTER TABLE application_metrics ADD COLUMN IF NOT EXISTS retention_days integer NOT NULL DEFAULT 0;
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS retention_days integer NOT NULL DEFAULT 0;
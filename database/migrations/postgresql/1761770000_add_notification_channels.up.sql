@@ -0,0 +1,18 @@
+-- Alert destinations of a project, optionally overridden per application
+-- settings holds the channel JSON (webhook URL, bot token, SMTP server...)
+CREATE TABLE IF NOT EXISTS notification_channels (
+	id uuid NOT NULL DEFAULT uuid_generate_v4(),
+	project_id uuid NOT NULL,
+	application_id uuid NULL,
+	"name" varchar(100) NOT NULL,
+	kind varchar(20) NOT NULL,
+	settings jsonb NOT NULL,
+	enabled boolean NOT NULL DEFAULT true,
+	"created_at" timestamp NOT NULL DEFAULT now(),
+	"updated_at" timestamp NOT NULL DEFAULT now(),
+	CONSTRAINT notification_channels_pk PRIMARY KEY (id),
+	CONSTRAINT notification_channels_project_fk FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+	CONSTRAINT notification_channels_application_fk FOREIGN KEY (application_id) REFERENCES applications(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_channels_project ON notification_channels(project_id);
@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS alert_states;
+
+-- Daily alert deduplication table to avoid repeated Slack notifications
+CREATE TABLE IF NOT EXISTS alerts_sent_daily (
+	id uuid NOT NULL DEFAULT uuid_generate_v4(),
+	application_metric_id uuid NOT NULL,
+	alert_date date NOT NULL,
+	alert_reason text,
+	"created_at" timestamp NOT NULL DEFAULT now(),
+	CONSTRAINT alerts_sent_daily_pk PRIMARY KEY (id),
+	CONSTRAINT alerts_sent_daily_metric_date_uk UNIQUE (application_metric_id, alert_date),
+	CONSTRAINT alerts_sent_daily_application_metric_fk FOREIGN KEY (application_metric_id) REFERENCES application_metrics(id)
+);
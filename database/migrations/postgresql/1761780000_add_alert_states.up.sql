@@ -0,0 +1,17 @@
+-- Alert state of each application metric: ok -> pending -> firing -> resolved
+-- Replaces the daily alert deduplication table
+CREATE TABLE IF NOT EXISTS alert_states (
+	application_metric_id uuid NOT NULL,
+	state varchar(20) NOT NULL DEFAULT 'ok',
+	reason text,
+	consecutive_failures integer NOT NULL DEFAULT 0,
+	started_at timestamp, -- First failure of the current problem
+	fired_at timestamp,
+	resolved_at timestamp,
+	notified_at timestamp, -- When the firing alert was delivered
+	"updated_at" timestamp NOT NULL DEFAULT now(),
+	CONSTRAINT alert_states_pk PRIMARY KEY (application_metric_id),
+	CONSTRAINT alert_states_application_metric_fk FOREIGN KEY (application_metric_id) REFERENCES application_metrics(id) ON DELETE CASCADE
+);
+
+DROP TABLE IF EXISTS alerts_sent_daily;
@@ -0,0 +1,2 @@
+ALTER TABLE alert_states DROP COLUMN IF EXISTS severity;
+DROP TABLE IF EXISTS alert_rules;
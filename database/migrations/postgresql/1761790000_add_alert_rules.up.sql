@@ -0,0 +1,21 @@
+-- Threshold rules of an application metric, e.g. pvc_percent > 85 for 5 minutes
+-- comparator is one of >, >=, <, <=, ==, !=
+CREATE TABLE IF NOT EXISTS alert_rules (
+	id uuid NOT NULL DEFAULT uuid_generate_v4(),
+	application_metric_id uuid NOT NULL,
+	field varchar(50) NOT NULL,
+	comparator varchar(2) NOT NULL,
+	threshold double precision NOT NULL,
+	for_seconds integer NOT NULL DEFAULT 0, -- How long the condition must hold before the rule fires
+	severity varchar(20) NOT NULL DEFAULT 'warning',
+	enabled boolean NOT NULL DEFAULT true,
+	"created_at" timestamp NOT NULL DEFAULT now(),
+	"updated_at" timestamp NOT NULL DEFAULT now(),
+	CONSTRAINT alert_rules_pk PRIMARY KEY (id),
+	CONSTRAINT alert_rules_application_metric_fk FOREIGN KEY (application_metric_id) REFERENCES application_metrics(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_rules_application_metric ON alert_rules(application_metric_id);
+
+-- Severity of the current or last problem of each metric
+ALTER TABLE alert_states ADD COLUMN IF NOT EXISTS severity varchar(20) NOT NULL DEFAULT 'critical';
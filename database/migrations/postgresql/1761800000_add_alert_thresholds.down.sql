@@ -0,0 +1,3 @@
+ALTER TABLE alert_states DROP COLUMN IF EXISTS consecutive_successes;
+ALTER TABLE application_metrics DROP COLUMN IF EXISTS recover_after;
+ALTER TABLE application_metrics DROP COLUMN IF EXISTS fail_after;
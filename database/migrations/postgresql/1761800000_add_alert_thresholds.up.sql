@@ -0,0 +1,5 @@
+-- Per-metric consecutive failure and recovery thresholds
+-- fail_after = 0 uses the default (3 for HealthCheck, 1 otherwise), recover_after = 0 uses 1
+ALTER TABLE application_metrics ADD COLUMN IF NOT EXISTS fail_after integer NOT NULL DEFAULT 0;
+ALTER TABLE application_metrics ADD COLUMN IF NOT EXISTS recover_after integer NOT NULL DEFAULT 0;
+ALTER TABLE alert_states ADD COLUMN IF NOT EXISTS consecutive_successes integer NOT NULL DEFAULT 0;
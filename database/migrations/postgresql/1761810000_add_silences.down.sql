@@ -0,0 +1,3 @@
+ALTER TABLE alert_states DROP COLUMN IF EXISTS silence_id;
+DROP INDEX IF EXISTS idx_silences_ends_at;
+DROP TABLE IF EXISTS silences;
@@ -0,0 +1,30 @@
+-- Alert silences scoped to a project, application, metric and/or metric type
+-- A silence with a window (weekdays, window_start, window_end) is a recurring
+-- maintenance window, active only inside the window between starts_at and ends_at
+CREATE TABLE IF NOT EXISTS silences (
+	id uuid NOT NULL DEFAULT uuid_generate_v4(),
+	project_id uuid NULL,
+	application_id uuid NULL,
+	application_metric_id uuid NULL,
+	metric_type_id uuid NULL,
+	starts_at timestamp NOT NULL,
+	ends_at timestamp NULL, -- NULL for maintenance windows that never end
+	weekdays varchar(100) NULL, -- Comma separated, e.g. "sunday,saturday"; NULL for every day
+	window_start varchar(5) NULL, -- HH:MM, NULL for one-off silences
+	window_end varchar(5) NULL,
+	timezone varchar(64) NULL,
+	created_by varchar(255) NULL,
+	reason text NOT NULL,
+	"created_at" timestamp NOT NULL DEFAULT now(),
+	"updated_at" timestamp NOT NULL DEFAULT now(),
+	CONSTRAINT silences_pk PRIMARY KEY (id),
+	CONSTRAINT silences_project_fk FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+	CONSTRAINT silences_application_fk FOREIGN KEY (application_id) REFERENCES applications(id) ON DELETE CASCADE,
+	CONSTRAINT silences_application_metric_fk FOREIGN KEY (application_metric_id) REFERENCES application_metrics(id) ON DELETE CASCADE,
+	CONSTRAINT silences_metric_type_fk FOREIGN KEY (metric_type_id) REFERENCES metric_types(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_silences_ends_at ON silences(ends_at);
+
+-- Silence that held back the firing alert of each metric
+ALTER TABLE alert_states ADD COLUMN IF NOT EXISTS silence_id uuid NULL;
@@ -0,0 +1,6 @@
+ALTER TABLE alert_states DROP COLUMN IF EXISTS alert_id;
+DROP INDEX IF EXISTS idx_alert_deliveries_alert;
+DROP TABLE IF EXISTS alert_deliveries;
+DROP INDEX IF EXISTS idx_alert_history_application_metric;
+DROP INDEX IF EXISTS idx_alert_history_fired_at;
+DROP TABLE IF EXISTS alert_history;
@@ -0,0 +1,42 @@
+-- Alert history: one row per problem of an application metric, from the
+-- moment its alert fired until it resolved
+CREATE TABLE IF NOT EXISTS alert_history (
+	id uuid NOT NULL DEFAULT uuid_generate_v4(),
+	application_metric_id uuid NOT NULL,
+	application_id uuid NOT NULL,
+	project_id uuid NOT NULL,
+	metric_type varchar(100) NOT NULL,
+	status varchar(20) NOT NULL DEFAULT 'firing', -- firing or resolved
+	severity varchar(20) NOT NULL,
+	title varchar(255) NOT NULL,
+	reason text,
+	started_at timestamp NOT NULL, -- First failure of the problem
+	fired_at timestamp NOT NULL,
+	resolved_at timestamp,
+	silence_id uuid, -- Last silence that held back the alert
+	"created_at" timestamp NOT NULL DEFAULT now(),
+	"updated_at" timestamp NOT NULL DEFAULT now(),
+	CONSTRAINT alert_history_pk PRIMARY KEY (id),
+	CONSTRAINT alert_history_application_metric_fk FOREIGN KEY (application_metric_id) REFERENCES application_metrics(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_history_fired_at ON alert_history(fired_at);
+CREATE INDEX IF NOT EXISTS idx_alert_history_application_metric ON alert_history(application_metric_id);
+
+-- Every attempt to send the firing or resolved notification of an alert
+CREATE TABLE IF NOT EXISTS alert_deliveries (
+	id uuid NOT NULL DEFAULT uuid_generate_v4(),
+	alert_id uuid NOT NULL,
+	status varchar(20) NOT NULL, -- Notification sent: firing or resolved
+	channel varchar(50) NOT NULL,
+	success boolean NOT NULL,
+	error text,
+	sent_at timestamp NOT NULL DEFAULT now(),
+	CONSTRAINT alert_deliveries_pk PRIMARY KEY (id),
+	CONSTRAINT alert_deliveries_alert_fk FOREIGN KEY (alert_id) REFERENCES alert_history(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_deliveries_alert ON alert_deliveries(alert_id);
+
+-- Alert history entry of the current or last problem of each metric
+ALTER TABLE alert_states ADD COLUMN IF NOT EXISTS alert_id uuid NULL;
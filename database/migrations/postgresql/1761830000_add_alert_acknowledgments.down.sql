@@ -0,0 +1,2 @@
+ALTER TABLE alert_history DROP COLUMN IF EXISTS acknowledged_by;
+ALTER TABLE alert_history DROP COLUMN IF EXISTS acknowledged_at;
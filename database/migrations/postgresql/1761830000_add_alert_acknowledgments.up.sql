@@ -0,0 +1,3 @@
+-- Acknowledgment of firing alerts: who is on it, and since when
+ALTER TABLE alert_history ADD COLUMN IF NOT EXISTS acknowledged_at timestamp NULL;
+ALTER TABLE alert_history ADD COLUMN IF NOT EXISTS acknowledged_by varchar(255) NULL;
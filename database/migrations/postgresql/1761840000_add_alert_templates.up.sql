@@ -0,0 +1,13 @@
+-- Go text/template alert messages, per metric type and/or channel kind
+-- A NULL metric_type_id or channel applies to every metric type or channel
+CREATE TABLE IF NOT EXISTS alert_templates (
+	id uuid NOT NULL DEFAULT uuid_generate_v4(),
+	metric_type_id uuid NULL,
+	channel varchar(20) NULL, -- Notification channel kind, e.g. slack
+	title text NULL, -- NULL keeps the default title
+	body text NOT NULL,
+	"created_at" timestamp NOT NULL DEFAULT now(),
+	"updated_at" timestamp NOT NULL DEFAULT now(),
+	CONSTRAINT alert_templates_pk PRIMARY KEY (id),
+	CONSTRAINT alert_templates_metric_type_fk FOREIGN KEY (metric_type_id) REFERENCES metric_types(id) ON DELETE CASCADE
+);
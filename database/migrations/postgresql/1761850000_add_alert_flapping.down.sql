@@ -0,0 +1,4 @@
+ALTER TABLE alert_states DROP COLUMN IF EXISTS flap_alert_id;
+ALTER TABLE alert_states DROP COLUMN IF EXISTS flapping_since;
+ALTER TABLE alert_states DROP COLUMN IF EXISTS flap_score;
+ALTER TABLE alert_states DROP COLUMN IF EXISTS flapping;
@@ -0,0 +1,6 @@
+-- Flapping detection: metrics whose health keeps changing get one flapping
+-- alert instead of an alert per change
+ALTER TABLE alert_states ADD COLUMN IF NOT EXISTS flapping boolean NOT NULL DEFAULT false;
+ALTER TABLE alert_states ADD COLUMN IF NOT EXISTS flap_score double precision NOT NULL DEFAULT 0; -- Weighted share of health changes, in percent
+ALTER TABLE alert_states ADD COLUMN IF NOT EXISTS flapping_since timestamp NULL;
+ALTER TABLE alert_states ADD COLUMN IF NOT EXISTS flap_alert_id uuid NULL; -- Alert history entry of the flapping alert
@@ -0,0 +1,6 @@
+ALTER TABLE alert_deliveries DROP COLUMN IF EXISTS escalation_step;
+ALTER TABLE alert_history DROP COLUMN IF EXISTS escalated_at;
+ALTER TABLE alert_history DROP COLUMN IF EXISTS escalation_step;
+ALTER TABLE projects DROP COLUMN IF EXISTS escalation_policy_id;
+
+DROP TABLE IF EXISTS escalation_policies;
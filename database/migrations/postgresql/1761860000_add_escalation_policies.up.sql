@@ -0,0 +1,18 @@
+-- Escalation policies: alerts nobody acknowledges are sent to more channels,
+-- step after step. steps holds [{"delay_minutes", "notification_channel_ids"}]
+CREATE TABLE IF NOT EXISTS escalation_policies (
+	id uuid NOT NULL DEFAULT uuid_generate_v4(),
+	"name" varchar(100) NOT NULL,
+	description text NULL,
+	steps jsonb NOT NULL,
+	"created_at" timestamp NOT NULL DEFAULT now(),
+	"updated_at" timestamp NOT NULL DEFAULT now(),
+	CONSTRAINT escalation_policies_pk PRIMARY KEY (id)
+);
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS escalation_policy_id uuid NULL; -- NULL when the alerts of the project don't escalate
+
+-- Last escalation step reached by an alert, 0 before the first one
+ALTER TABLE alert_history ADD COLUMN IF NOT EXISTS escalation_step integer NOT NULL DEFAULT 0;
+ALTER TABLE alert_history ADD COLUMN IF NOT EXISTS escalated_at timestamp NULL;
+ALTER TABLE alert_deliveries ADD COLUMN IF NOT EXISTS escalation_step integer NOT NULL DEFAULT 0; -- 0 for the regular notifications
@@ -0,0 +1 @@
+ALTER TABLE application_metrics DROP COLUMN IF EXISTS failure_alerts;
@@ -0,0 +1,3 @@
+-- Per-metric switch for the alerts on failed values (health check down,
+-- connection failed); NULL uses the default of the metric type
+ALTER TABLE application_metrics ADD COLUMN IF NOT EXISTS failure_alerts boolean;
@@ -0,0 +1,5 @@
+-- Remove per-metric collection schedule columns
+
+ALTER TABLE application_metrics DROP COLUMN jitter_seconds;
+ALTER TABLE application_metrics DROP COLUMN cron_expression;
+ALTER TABLE application_metrics DROP COLUMN interval_seconds;
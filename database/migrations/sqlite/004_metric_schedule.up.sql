@@ -0,0 +1,6 @@
+-- Per-metric collection schedule
+-- interval_seconds = 0 and an empty cron_expression fall back to METRICS_COLLECTION_INTERVAL
+
+ALTER TABLE application_metrics ADD COLUMN interval_seconds INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE application_metrics ADD COLUMN cron_expression TEXT NOT NULL DEFAULT '';
+ALTER TABLE application_metrics ADD COLUMN jitter_seconds INTEGER NOT NULL DEFAULT 0;
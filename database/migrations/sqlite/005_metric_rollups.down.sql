@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_application_metric_rollups_resolution_bucket;
+DROP TABLE IF EXISTS application_metric_rollups;
@@ -0,0 +1,22 @@
+-- Hourly and daily aggregates of application_metric_values, kept longer than the raw values
+-- fields holds JSON {"<field>": {"min", "max", "sum", "p95"}} for the fields present in the bucket
+CREATE TABLE IF NOT EXISTS application_metric_rollups (
+    id TEXT PRIMARY KEY DEFAULT (
+        lower(hex(randomblob(4))) || '-' ||
+        lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))),2) || '-' ||
+        substr('89ab',abs(random()) % 4 + 1, 1) || substr(lower(hex(randomblob(2))),2) || '-' ||
+        lower(hex(randomblob(6)))
+    ),
+    application_metric_id TEXT NOT NULL,
+    resolution TEXT NOT NULL, -- 'hour' or 'day'
+    bucket_start DATETIME NOT NULL,
+    samples INTEGER NOT NULL,
+    up_samples INTEGER NOT NULL,
+    fields TEXT NOT NULL, -- JSON stored as TEXT in SQLite
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (application_metric_id) REFERENCES application_metrics(id),
+    UNIQUE(application_metric_id, resolution, bucket_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_application_metric_rollups_resolution_bucket
+  ON application_metric_rollups(resolution, bucket_start);
@@ -0,0 +1,4 @@
+-- Remove the retention overrides
+
+ALTER TABLE projects DROP COLUMN retention_days;
+ALTER TABLE application_metrics DROP COLUMN retention_days;
@@ -0,0 +1,4 @@
+-- Remove the notification channels
+
+DROP INDEX IF EXISTS idx_notification_channels_project;
+DROP TABLE IF EXISTS notification_channels;
@@ -0,0 +1,23 @@
+-- Alert destinations of a project, optionally overridden per application
+-- settings holds the channel JSON (webhook URL, bot token, SMTP server...)
+CREATE TABLE IF NOT EXISTS notification_channels (
+    id TEXT PRIMARY KEY DEFAULT (
+        lower(hex(randomblob(4))) || '-' ||
+        lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))),2) || '-' ||
+        substr('89ab',abs(random()) % 4 + 1, 1) || substr(lower(hex(randomblob(2))),2) || '-' ||
+        lower(hex(randomblob(6)))
+    ),
+    project_id TEXT NOT NULL,
+    application_id TEXT, -- NULL for the project channels
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    settings TEXT NOT NULL, -- JSON stored as TEXT in SQLite
+    enabled INTEGER NOT NULL DEFAULT 1,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+    FOREIGN KEY (application_id) REFERENCES applications(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_channels_project
+  ON notification_channels(project_id);
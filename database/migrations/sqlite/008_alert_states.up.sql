@@ -0,0 +1,17 @@
+-- Alert state of each application metric: ok -> pending -> firing -> resolved
+-- Replaces the daily alert deduplication table
+CREATE TABLE IF NOT EXISTS alert_states (
+    application_metric_id TEXT PRIMARY KEY,
+    state VARCHAR(20) NOT NULL DEFAULT 'ok',
+    reason TEXT,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    started_at DATETIME, -- First failure of the current problem
+    fired_at DATETIME,
+    resolved_at DATETIME,
+    notified_at DATETIME, -- When the firing alert was delivered
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (application_metric_id) REFERENCES application_metrics(id) ON DELETE CASCADE
+);
+
+DROP INDEX IF EXISTS idx_alerts_sent_daily_metric_date;
+DROP TABLE IF EXISTS alerts_sent_daily;
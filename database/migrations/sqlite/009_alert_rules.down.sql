@@ -0,0 +1,5 @@
+-- Remove the alert rules
+
+ALTER TABLE alert_states DROP COLUMN severity;
+DROP INDEX IF EXISTS idx_alert_rules_application_metric;
+DROP TABLE IF EXISTS alert_rules;
@@ -0,0 +1,26 @@
+-- Threshold rules of an application metric, e.g. pvc_percent > 85 for 5 minutes
+-- comparator is one of >, >=, <, <=, ==, !=
+CREATE TABLE IF NOT EXISTS alert_rules (
+    id TEXT PRIMARY KEY DEFAULT (
+        lower(hex(randomblob(4))) || '-' ||
+        lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))),2) || '-' ||
+        substr('89ab',abs(random()) % 4 + 1, 1) || substr(lower(hex(randomblob(2))),2) || '-' ||
+        lower(hex(randomblob(6)))
+    ),
+    application_metric_id TEXT NOT NULL,
+    field VARCHAR(50) NOT NULL,
+    comparator VARCHAR(2) NOT NULL,
+    threshold REAL NOT NULL,
+    for_seconds INTEGER NOT NULL DEFAULT 0, -- How long the condition must hold before the rule fires
+    severity VARCHAR(20) NOT NULL DEFAULT 'warning',
+    enabled INTEGER NOT NULL DEFAULT 1,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (application_metric_id) REFERENCES application_metrics(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_rules_application_metric
+  ON alert_rules(application_metric_id);
+
+-- Severity of the current or last problem of each metric
+ALTER TABLE alert_states ADD COLUMN severity VARCHAR(20) NOT NULL DEFAULT 'critical';
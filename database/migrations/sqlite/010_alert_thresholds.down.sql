@@ -0,0 +1,5 @@
+-- Remove the alert thresholds
+
+ALTER TABLE alert_states DROP COLUMN consecutive_successes;
+ALTER TABLE application_metrics DROP COLUMN recover_after;
+ALTER TABLE application_metrics DROP COLUMN fail_after;
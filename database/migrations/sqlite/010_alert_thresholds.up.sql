@@ -0,0 +1,6 @@
+-- Per-metric consecutive failure and recovery thresholds
+-- fail_after = 0 uses the default (3 for HealthCheck, 1 otherwise), recover_after = 0 uses 1
+
+ALTER TABLE application_metrics ADD COLUMN fail_after INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE application_metrics ADD COLUMN recover_after INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE alert_states ADD COLUMN consecutive_successes INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+-- Remove the silences
+
+ALTER TABLE alert_states DROP COLUMN silence_id;
+DROP INDEX IF EXISTS idx_silences_ends_at;
+DROP TABLE IF EXISTS silences;
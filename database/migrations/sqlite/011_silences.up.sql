@@ -0,0 +1,35 @@
+-- Alert silences scoped to a project, application, metric and/or metric type
+-- A silence with a window (weekdays, window_start, window_end) is a recurring
+-- maintenance window, active only inside the window between starts_at and ends_at
+CREATE TABLE IF NOT EXISTS silences (
+    id TEXT PRIMARY KEY DEFAULT (
+        lower(hex(randomblob(4))) || '-' ||
+        lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))),2) || '-' ||
+        substr('89ab',abs(random()) % 4 + 1, 1) || substr(lower(hex(randomblob(2))),2) || '-' ||
+        lower(hex(randomblob(6)))
+    ),
+    project_id TEXT,
+    application_id TEXT,
+    application_metric_id TEXT,
+    metric_type_id TEXT,
+    starts_at DATETIME NOT NULL,
+    ends_at DATETIME, -- NULL for maintenance windows that never end
+    weekdays VARCHAR(100), -- Comma separated, e.g. "sunday,saturday"; NULL for every day
+    window_start VARCHAR(5), -- HH:MM, NULL for one-off silences
+    window_end VARCHAR(5),
+    timezone VARCHAR(64),
+    created_by VARCHAR(255),
+    reason TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE,
+    FOREIGN KEY (application_id) REFERENCES applications(id) ON DELETE CASCADE,
+    FOREIGN KEY (application_metric_id) REFERENCES application_metrics(id) ON DELETE CASCADE,
+    FOREIGN KEY (metric_type_id) REFERENCES metric_types(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_silences_ends_at
+  ON silences(ends_at);
+
+-- Silence that held back the firing alert of each metric
+ALTER TABLE alert_states ADD COLUMN silence_id TEXT;
@@ -0,0 +1,8 @@
+-- Remove the alert history
+
+ALTER TABLE alert_states DROP COLUMN alert_id;
+DROP INDEX IF EXISTS idx_alert_deliveries_alert;
+DROP TABLE IF EXISTS alert_deliveries;
+DROP INDEX IF EXISTS idx_alert_history_application_metric;
+DROP INDEX IF EXISTS idx_alert_history_fired_at;
+DROP TABLE IF EXISTS alert_history;
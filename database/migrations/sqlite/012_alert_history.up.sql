@@ -0,0 +1,53 @@
+-- Alert history: one row per problem of an application metric, from the
+-- moment its alert fired until it resolved
+CREATE TABLE IF NOT EXISTS alert_history (
+    id TEXT PRIMARY KEY DEFAULT (
+        lower(hex(randomblob(4))) || '-' ||
+        lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))),2) || '-' ||
+        substr('89ab',abs(random()) % 4 + 1, 1) || substr(lower(hex(randomblob(2))),2) || '-' ||
+        lower(hex(randomblob(6)))
+    ),
+    application_metric_id TEXT NOT NULL,
+    application_id TEXT NOT NULL,
+    project_id TEXT NOT NULL,
+    metric_type VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'firing', -- firing or resolved
+    severity VARCHAR(20) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    reason TEXT,
+    started_at DATETIME NOT NULL, -- First failure of the problem
+    fired_at DATETIME NOT NULL,
+    resolved_at DATETIME,
+    silence_id TEXT, -- Last silence that held back the alert
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (application_metric_id) REFERENCES application_metrics(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_history_fired_at
+  ON alert_history(fired_at);
+CREATE INDEX IF NOT EXISTS idx_alert_history_application_metric
+  ON alert_history(application_metric_id);
+
+-- Every attempt to send the firing or resolved notification of an alert
+CREATE TABLE IF NOT EXISTS alert_deliveries (
+    id TEXT PRIMARY KEY DEFAULT (
+        lower(hex(randomblob(4))) || '-' ||
+        lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))),2) || '-' ||
+        substr('89ab',abs(random()) % 4 + 1, 1) || substr(lower(hex(randomblob(2))),2) || '-' ||
+        lower(hex(randomblob(6)))
+    ),
+    alert_id TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL, -- Notification sent: firing or resolved
+    channel VARCHAR(50) NOT NULL,
+    success INTEGER NOT NULL,
+    error TEXT,
+    sent_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (alert_id) REFERENCES alert_history(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_alert_deliveries_alert
+  ON alert_deliveries(alert_id);
+
+-- Alert history entry of the current or last problem of each metric
+ALTER TABLE alert_states ADD COLUMN alert_id TEXT;
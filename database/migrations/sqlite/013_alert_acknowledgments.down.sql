@@ -0,0 +1,4 @@
+-- Remove the alert acknowledgments
+
+ALTER TABLE alert_history DROP COLUMN acknowledged_by;
+ALTER TABLE alert_history DROP COLUMN acknowledged_at;
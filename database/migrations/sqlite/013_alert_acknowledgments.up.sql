@@ -0,0 +1,4 @@
+-- Acknowledgment of firing alerts: who is on it, and since when
+
+ALTER TABLE alert_history ADD COLUMN acknowledged_at DATETIME;
+ALTER TABLE alert_history ADD COLUMN acknowledged_by VARCHAR(255);
@@ -0,0 +1,3 @@
+-- Remove the alert templates
+
+DROP TABLE IF EXISTS alert_templates;
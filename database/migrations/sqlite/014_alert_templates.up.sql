@@ -0,0 +1,17 @@
+-- Go text/template alert messages, per metric type and/or channel kind
+-- A NULL metric_type_id or channel applies to every metric type or channel
+CREATE TABLE IF NOT EXISTS alert_templates (
+    id TEXT PRIMARY KEY DEFAULT (
+        lower(hex(randomblob(4))) || '-' ||
+        lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))),2) || '-' ||
+        substr('89ab',abs(random()) % 4 + 1, 1) || substr(lower(hex(randomblob(2))),2) || '-' ||
+        lower(hex(randomblob(6)))
+    ),
+    metric_type_id TEXT,
+    channel VARCHAR(20), -- Notification channel kind, e.g. slack
+    title TEXT, -- NULL keeps the default title
+    body TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (metric_type_id) REFERENCES metric_types(id) ON DELETE CASCADE
+);
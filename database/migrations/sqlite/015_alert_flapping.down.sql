@@ -0,0 +1,6 @@
+-- Remove the flapping detection
+
+ALTER TABLE alert_states DROP COLUMN flap_alert_id;
+ALTER TABLE alert_states DROP COLUMN flapping_since;
+ALTER TABLE alert_states DROP COLUMN flap_score;
+ALTER TABLE alert_states DROP COLUMN flapping;
@@ -0,0 +1,7 @@
+-- Flapping detection: metrics whose health keeps changing get one flapping
+-- alert instead of an alert per change
+
+ALTER TABLE alert_states ADD COLUMN flapping INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE alert_states ADD COLUMN flap_score REAL NOT NULL DEFAULT 0; -- Weighted share of health changes, in percent
+ALTER TABLE alert_states ADD COLUMN flapping_since DATETIME;
+ALTER TABLE alert_states ADD COLUMN flap_alert_id TEXT; -- Alert history entry of the flapping alert
@@ -0,0 +1,8 @@
+-- Remove the escalation policies
+
+ALTER TABLE alert_deliveries DROP COLUMN escalation_step;
+ALTER TABLE alert_history DROP COLUMN escalated_at;
+ALTER TABLE alert_history DROP COLUMN escalation_step;
+ALTER TABLE projects DROP COLUMN escalation_policy_id;
+
+DROP TABLE IF EXISTS escalation_policies;
@@ -0,0 +1,22 @@
+-- Escalation policies: alerts nobody acknowledges are sent to more channels,
+-- step after step. steps holds JSON [{"delay_minutes", "notification_channel_ids"}]
+CREATE TABLE IF NOT EXISTS escalation_policies (
+    id TEXT PRIMARY KEY DEFAULT (
+        lower(hex(randomblob(4))) || '-' ||
+        lower(hex(randomblob(2))) || '-4' || substr(lower(hex(randomblob(2))),2) || '-' ||
+        substr('89ab',abs(random()) % 4 + 1, 1) || substr(lower(hex(randomblob(2))),2) || '-' ||
+        lower(hex(randomblob(6)))
+    ),
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    steps TEXT NOT NULL, -- JSON stored as TEXT in SQLite
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE projects ADD COLUMN escalation_policy_id TEXT; -- NULL when the alerts of the project don't escalate
+
+-- Last escalation step reached by an alert, 0 before the first one
+ALTER TABLE alert_history ADD COLUMN escalation_step INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE alert_history ADD COLUMN escalated_at DATETIME;
+ALTER TABLE alert_deliveries ADD COLUMN escalation_step INTEGER NOT NULL DEFAULT 0; -- 0 for the regular notifications
@@ -0,0 +1,3 @@
+-- Remove the failure alerts switch
+
+ALTER TABLE application_metrics DROP COLUMN failure_alerts;
@@ -0,0 +1,4 @@
+-- Per-metric switch for the alerts on failed values (health check down,
+-- connection failed); NULL uses the default of the metric type
+
+ALTER TABLE application_metrics ADD COLUMN failure_alerts BOOLEAN;
@@ -0,0 +1,330 @@
+package alert_history
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s-monitoring-app/internal/core"
+	alertHistoryModel "k8s-monitoring-app/pkg/alert_history/model"
+)
+
+// generateUUID generates a simple UUID v4
+func generateUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+type Repository interface {
+	// Get returns an alert with its deliveries
+	Get(ctx context.Context, id string) (alertHistoryModel.Alert, error)
+	// List returns the alerts matching the filter with their deliveries, the
+	// most recently fired first
+	List(ctx context.Context, filter alertHistoryModel.Filter) ([]alertHistoryModel.Alert, error)
+	Add(ctx context.Context, alert *alertHistoryModel.Alert) error
+	// Resolve marks a firing alert resolved, replacing its severity and reason
+	// unless they are empty. It returns sql.ErrNoRows when the alert is missing
+	// or already resolved.
+	Resolve(ctx context.Context, id string, resolvedAt time.Time, severity, reason string) error
+	// SetSilence records the silence holding back a firing alert
+	SetSilence(ctx context.Context, id, silenceID string) error
+	// SetEscalation records the escalation step a firing alert reached. It
+	// returns sql.ErrNoRows when the alert is missing, resolved, acknowledged or
+	// already past that step.
+	SetEscalation(ctx context.Context, id string, step int, at time.Time) error
+	// Acknowledge records who acknowledged a firing alert. It returns
+	// sql.ErrNoRows when the alert is missing, resolved or already acknowledged.
+	Acknowledge(ctx context.Context, id, by string, at time.Time) error
+	AddDelivery(ctx context.Context, delivery *alertHistoryModel.Delivery) error
+	GetDB() *sql.DB
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepo(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (repo *repository) GetDB() *sql.DB {
+	return repo.db
+}
+
+const selectAlerts = `
+	SELECT
+		id, application_metric_id, application_id, project_id, metric_type, status, severity, title, reason,
+		started_at, fired_at, resolved_at, silence_id, acknowledged_at, acknowledged_by,
+		escalation_step, escalated_at, created_at, updated_at
+	FROM
+		alert_history`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAlert(row scanner) (alertHistoryModel.Alert, error) {
+	alert := alertHistoryModel.Alert{}
+	var reason, silenceID, acknowledgedBy sql.NullString
+	var resolvedAt, acknowledgedAt, escalatedAt sql.NullTime
+	err := row.Scan(
+		&alert.ID, &alert.ApplicationMetricID, &alert.ApplicationID, &alert.ProjectID, &alert.MetricType,
+		&alert.Status, &alert.Severity, &alert.Title, &reason,
+		&alert.StartedAt, &alert.FiredAt, &resolvedAt, &silenceID, &acknowledgedAt, &acknowledgedBy,
+		&alert.EscalationStep, &escalatedAt, &alert.CreatedAt, &alert.UpdatedAt)
+	if err != nil {
+		return alert, err
+	}
+	alert.Reason = reason.String
+	alert.SilenceID = silenceID.String
+	alert.AcknowledgedBy = acknowledgedBy.String
+	if resolvedAt.Valid {
+		alert.ResolvedAt = &resolvedAt.Time
+	}
+	if acknowledgedAt.Valid {
+		alert.AcknowledgedAt = &acknowledgedAt.Time
+	}
+	if escalatedAt.Valid {
+		alert.EscalatedAt = &escalatedAt.Time
+	}
+	alert.Deliveries = []alertHistoryModel.Delivery{}
+
+	return alert, nil
+}
+
+func (repo *repository) Get(ctx context.Context, id string) (alertHistoryModel.Alert, error) {
+	sqlString := fmt.Sprintf("%s WHERE id = ?", selectAlerts)
+
+	alert, err := scanAlert(repo.db.QueryRowContext(ctx, core.Rebind(sqlString), id))
+	if err != nil {
+		return alert, err
+	}
+
+	alerts := []alertHistoryModel.Alert{alert}
+	if err := repo.loadDeliveries(ctx, alerts); err != nil {
+		return alert, err
+	}
+
+	return alerts[0], nil
+}
+
+func (repo *repository) List(ctx context.Context, filter alertHistoryModel.Filter) ([]alertHistoryModel.Alert, error) {
+	alerts := []alertHistoryModel.Alert{}
+
+	var conditions []string
+	var args []interface{}
+	where := func(condition string, arg interface{}) {
+		conditions = append(conditions, condition)
+		args = append(args, arg)
+	}
+	if filter.ProjectID != "" {
+		where("project_id = ?", filter.ProjectID)
+	}
+	if filter.ApplicationID != "" {
+		where("application_id = ?", filter.ApplicationID)
+	}
+	if filter.ApplicationMetricID != "" {
+		where("application_metric_id = ?", filter.ApplicationMetricID)
+	}
+	if filter.Status != "" {
+		where("status = ?", filter.Status)
+	}
+	if filter.Severity != "" {
+		where("severity = ?", filter.Severity)
+	}
+	if filter.From != nil {
+		where("fired_at >= ?", filter.From.UTC())
+	}
+	if filter.To != nil {
+		where("fired_at < ?", filter.To.UTC())
+	}
+
+	sqlString := selectAlerts
+	if len(conditions) > 0 {
+		sqlString += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	sqlString += " ORDER BY fired_at DESC"
+	if filter.Limit > 0 {
+		sqlString += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	rows, err := repo.db.QueryContext(ctx, core.Rebind(sqlString), args...)
+	if err != nil {
+		return alerts, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		alert, err := scanAlert(rows)
+		if err != nil {
+			return alerts, err
+		}
+
+		alerts = append(alerts, alert)
+	}
+	if err := rows.Err(); err != nil {
+		return alerts, err
+	}
+
+	return alerts, repo.loadDeliveries(ctx, alerts)
+}
+
+// loadDeliveries fills in the deliveries of the given alerts, oldest first
+func (repo *repository) loadDeliveries(ctx context.Context, alerts []alertHistoryModel.Alert) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	index := make(map[string]int, len(alerts))
+	placeholders := make([]string, 0, len(alerts))
+	args := make([]interface{}, 0, len(alerts))
+	for i, alert := range alerts {
+		index[alert.ID] = i
+		placeholders = append(placeholders, "?")
+		args = append(args, alert.ID)
+	}
+
+	sqlString := fmt.Sprintf(`
+		SELECT
+			id, alert_id, status, channel, success, error, escalation_step, sent_at
+		FROM
+			alert_deliveries
+		WHERE alert_id IN (%s)
+		ORDER BY sent_at`, strings.Join(placeholders, ", "))
+
+	rows, err := repo.db.QueryContext(ctx, core.Rebind(sqlString), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		delivery := alertHistoryModel.Delivery{}
+		var deliveryError sql.NullString
+		err := rows.Scan(&delivery.ID, &delivery.AlertID, &delivery.Status, &delivery.Channel,
+			&delivery.Success, &deliveryError, &delivery.EscalationStep, &delivery.SentAt)
+		if err != nil {
+			return err
+		}
+		delivery.Error = deliveryError.String
+
+		i := index[delivery.AlertID]
+		alerts[i].Deliveries = append(alerts[i].Deliveries, delivery)
+	}
+
+	return rows.Err()
+}
+
+func (repo *repository) Add(ctx context.Context, alert *alertHistoryModel.Alert) error {
+	alert.ID = generateUUID()
+	now := time.Now()
+	alert.CreatedAt = now
+	alert.UpdatedAt = now
+	if alert.Status == "" {
+		alert.Status = alertHistoryModel.StatusFiring
+	}
+	alert.Deliveries = []alertHistoryModel.Delivery{}
+
+	sqlString := `INSERT INTO alert_history(
+		id, application_metric_id, application_id, project_id, metric_type, status, severity, title, reason,
+		started_at, fired_at, resolved_at, silence_id, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := repo.db.ExecContext(ctx, core.Rebind(sqlString),
+		alert.ID, alert.ApplicationMetricID, alert.ApplicationID, alert.ProjectID, alert.MetricType,
+		alert.Status, alert.Severity, alert.Title, nullString(alert.Reason),
+		alert.StartedAt.UTC(), alert.FiredAt.UTC(), nullTime(alert.ResolvedAt), nullString(alert.SilenceID),
+		alert.CreatedAt.UTC(), alert.UpdatedAt.UTC(),
+	)
+	return err
+}
+
+// The setters below each write only their own columns, guarded by the state
+// the change applies to, so that the collector, the escalation job and the
+// acknowledgments of any replica never undo each other's changes
+
+func (repo *repository) Resolve(ctx context.Context, id string, resolvedAt time.Time, severity, reason string) error {
+	sqlString := `UPDATE alert_history SET
+		status = ?, resolved_at = ?, severity = COALESCE(?, severity), reason = COALESCE(?, reason), updated_at = ?
+		WHERE id = ? AND status = ?`
+
+	return repo.exec(ctx, sqlString,
+		alertHistoryModel.StatusResolved, resolvedAt.UTC(), nullString(severity), nullString(reason), time.Now().UTC(),
+		id, alertHistoryModel.StatusFiring,
+	)
+}
+
+func (repo *repository) SetSilence(ctx context.Context, id, silenceID string) error {
+	sqlString := `UPDATE alert_history SET silence_id = ?, updated_at = ? WHERE id = ? AND status = ?`
+
+	return repo.exec(ctx, sqlString, nullString(silenceID), time.Now().UTC(), id, alertHistoryModel.StatusFiring)
+}
+
+func (repo *repository) SetEscalation(ctx context.Context, id string, step int, at time.Time) error {
+	sqlString := `UPDATE alert_history SET escalation_step = ?, escalated_at = ?, updated_at = ?
+		WHERE id = ? AND acknowledged_at IS NULL AND status = ? AND escalation_step < ?`
+
+	return repo.exec(ctx, sqlString,
+		step, at.UTC(), time.Now().UTC(), id, alertHistoryModel.StatusFiring, step,
+	)
+}
+
+func (repo *repository) Acknowledge(ctx context.Context, id, by string, at time.Time) error {
+	sqlString := `UPDATE alert_history SET acknowledged_at = ?, acknowledged_by = ?, updated_at = ?
+		WHERE id = ? AND acknowledged_at IS NULL AND status = ?`
+
+	return repo.exec(ctx, sqlString, at.UTC(), by, time.Now().UTC(), id, alertHistoryModel.StatusFiring)
+}
+
+// exec runs an update, returning sql.ErrNoRows when it matched no row
+func (repo *repository) exec(ctx context.Context, sqlString string, args ...interface{}) error {
+	result, err := repo.db.ExecContext(ctx, core.Rebind(sqlString), args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (repo *repository) AddDelivery(ctx context.Context, delivery *alertHistoryModel.Delivery) error {
+	delivery.ID = generateUUID()
+	if delivery.SentAt.IsZero() {
+		delivery.SentAt = time.Now()
+	}
+
+	sqlString := `INSERT INTO alert_deliveries(
+		id, alert_id, status, channel, success, error, escalation_step, sent_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := repo.db.ExecContext(ctx, core.Rebind(sqlString),
+		delivery.ID, delivery.AlertID, delivery.Status, delivery.Channel, delivery.Success,
+		nullString(delivery.Error), delivery.EscalationStep, delivery.SentAt.UTC(),
+	)
+	return err
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
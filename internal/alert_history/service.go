@@ -0,0 +1,315 @@
+package alert_history
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s-monitoring-app/internal/alerts"
+	"k8s-monitoring-app/internal/auth"
+	"k8s-monitoring-app/internal/core"
+	"k8s-monitoring-app/internal/env"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	model "k8s-monitoring-app/pkg/alert_history/model"
+	silenceModel "k8s-monitoring-app/pkg/silence/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultListLimit = 100
+	maxListLimit     = 1000
+
+	// slackSilenceDuration is how long the Silence 1h button silences a metric
+	slackSilenceDuration = time.Hour
+)
+
+type service struct{}
+
+func NewService() model.Service {
+	return &service{}
+}
+
+func (s *service) Get(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	id := sc.Param("id")
+
+	if len(id) == 0 {
+		log.Error().Err(errors.New("id is empty")).Msg("error getting alert")
+		return sc.String(http.StatusBadRequest, "invalid request")
+	}
+
+	alert, err := serverModel.ServerRepos.AlertHistory.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sc.String(http.StatusNotFound, "alert not found")
+		}
+		log.Error().Err(err).Str("id", id).Msg("error getting alert")
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	}
+	alert.DurationSeconds = int64(alert.Duration(time.Now()).Seconds())
+
+	return sc.JSON(http.StatusOK, alert)
+}
+
+// List returns the alert history, the most recently fired first, filtered by
+// project_id, application_id, application_metric_id, status, severity and a
+// from/to range on when the alerts fired
+func (s *service) List(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	filter, err := ParseFilter(sc)
+	if err != nil {
+		return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid filter",
+			"message": err.Error(),
+		})
+	}
+
+	history, err := serverModel.ServerRepos.AlertHistory.List(ctx, filter)
+	if err != nil {
+		log.Error().Err(err).Msg("error listing alerts")
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	}
+
+	now := time.Now()
+	for i := range history {
+		history[i].DurationSeconds = int64(history[i].Duration(now).Seconds())
+	}
+
+	return sc.JSON(http.StatusOK, history)
+}
+
+// Acknowledge records that someone is on a firing alert. It is sent again only
+// if it resolves and fires anew.
+func (s *service) Acknowledge(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+	id := sc.Param("id")
+
+	body := struct {
+		AcknowledgedBy string `json:"acknowledged_by"`
+	}{}
+	if err := sc.Bind(&body); err != nil {
+		log.Error().Msg("error binding acknowledgment")
+		return sc.String(http.StatusBadRequest, "invalid request body")
+	}
+	// The author is the signed-in user; without authentication the given one is kept
+	by := strings.TrimSpace(body.AcknowledgedBy)
+	if email, _, _, ok := auth.GetUserFromContext(sc); ok && email != "" {
+		by = email
+	}
+	if by == "" {
+		by = "unknown"
+	}
+
+	alert, status, msg := acknowledge(ctx, id, by)
+	if status != http.StatusOK {
+		return sc.String(status, msg)
+	}
+	alert.DurationSeconds = int64(alert.Duration(time.Now()).Seconds())
+
+	return sc.JSON(http.StatusOK, alert)
+}
+
+// SlackInteraction handles the buttons of Slack alerts: Acknowledge and
+// Silence 1h. Requests must be signed with SLACK_SIGNING_SECRET.
+func (s *service) SlackInteraction(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	if env.SLACK_SIGNING_SECRET == "" {
+		return sc.String(http.StatusNotFound, "slack interactions are not enabled")
+	}
+
+	body, err := io.ReadAll(sc.Request().Body)
+	if err != nil {
+		return sc.String(http.StatusBadRequest, "invalid request body")
+	}
+	err = alerts.VerifySlackSignature(
+		env.SLACK_SIGNING_SECRET,
+		sc.Request().Header.Get(alerts.SlackTimestampHeader),
+		sc.Request().Header.Get(alerts.SlackSignatureHeader),
+		body,
+		time.Now(),
+	)
+	if err != nil {
+		log.Warn().Err(err).Msg("rejected slack interaction")
+		return sc.String(http.StatusUnauthorized, "invalid signature")
+	}
+
+	interaction, err := alerts.ParseSlackInteraction(body)
+	if err != nil {
+		log.Warn().Err(err).Msg("invalid slack interaction")
+		return sc.String(http.StatusBadRequest, "invalid payload")
+	}
+
+	user := "@" + interaction.UserName()
+	for _, action := range interaction.Actions {
+		var reply string
+		switch action.ActionID {
+		case alerts.SlackActionAcknowledge:
+			alert, status, msg := acknowledge(ctx, action.Value, "slack:"+user)
+			reply = fmt.Sprintf("%s acknowledged *%s*", user, alert.Title)
+			if status != http.StatusOK {
+				reply = fmt.Sprintf("Could not acknowledge the alert: %s", msg)
+			}
+		case alerts.SlackActionSilence:
+			reply = silenceFromSlack(ctx, action.Value, user)
+		default:
+			// Link buttons, like Open dashboard, need no answer
+			continue
+		}
+
+		if interaction.ResponseURL != "" {
+			go func(text string) {
+				if err := alerts.RespondSlack(context.Background(), interaction.ResponseURL, text); err != nil {
+					log.Warn().Err(err).Msg("failed to reply to slack interaction")
+				}
+			}(reply)
+		}
+	}
+
+	// Slack expects an answer within 3 seconds; replies go to response_url
+	return sc.NoContent(http.StatusOK)
+}
+
+// acknowledge records an acknowledgment on a firing alert and returns it with
+// an HTTP status and, on failure, a message. Only the acknowledgment columns
+// are written, and only while the alert is firing and unacknowledged, so it
+// can't undo a resolution or an escalation stored meanwhile by the collector.
+// Acknowledging twice keeps the first acknowledgment and returns 409.
+func acknowledge(ctx context.Context, id, by string) (model.Alert, int, string) {
+	err := serverModel.ServerRepos.AlertHistory.Acknowledge(ctx, id, by, time.Now())
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Error().Err(err).Str("id", id).Msg("error acknowledging alert")
+		return model.Alert{}, http.StatusInternalServerError, "internal server error"
+	}
+
+	alert, getErr := serverModel.ServerRepos.AlertHistory.Get(ctx, id)
+	if getErr != nil {
+		if errors.Is(getErr, sql.ErrNoRows) {
+			return alert, http.StatusNotFound, "alert not found"
+		}
+		log.Error().Err(getErr).Str("id", id).Msg("error getting alert")
+		return alert, http.StatusInternalServerError, "internal server error"
+	}
+	if err != nil {
+		if alert.Acknowledged() {
+			return alert, http.StatusConflict, fmt.Sprintf("alert already acknowledged by %s", alert.AcknowledgedBy)
+		}
+		return alert, http.StatusConflict, "alert already resolved"
+	}
+
+	log.Info().
+		Str("alert_id", alert.ID).
+		Str("acknowledged_by", by).
+		Msg("alert acknowledged")
+
+	return alert, http.StatusOK, ""
+}
+
+// silenceFromSlack silences the metric of an alert for an hour and returns the
+// reply for the channel
+func silenceFromSlack(ctx context.Context, alertID, user string) string {
+	alert, err := serverModel.ServerRepos.AlertHistory.Get(ctx, alertID)
+	if err != nil {
+		log.Error().Err(err).Str("id", alertID).Msg("error getting alert")
+		return "Could not silence the alert: alert not found"
+	}
+
+	now := time.Now()
+	endsAt := now.Add(slackSilenceDuration)
+	silence := silenceModel.Silence{
+		ApplicationMetricID: alert.ApplicationMetricID,
+		StartsAt:            now,
+		EndsAt:              &endsAt,
+		CreatedBy:           "slack:" + user,
+		Reason:              fmt.Sprintf("Silenced from Slack by %s", user),
+	}
+	if err := serverModel.ServerRepos.Silence.Add(ctx, &silence); err != nil {
+		log.Error().Err(err).Msg("error add silence")
+		return "Could not silence the alert: internal server error"
+	}
+
+	log.Info().
+		Str("silence_id", silence.ID).
+		Str("created_by", silence.CreatedBy).
+		Str("reason", silence.Reason).
+		Msg("silence created")
+
+	return fmt.Sprintf("%s silenced *%s* for 1 hour", user, alert.Title)
+}
+
+// ParseFilter reads the alert history filters of a request
+func ParseFilter(sc *core.HTTPServerContext) (model.Filter, error) {
+	filter := model.Filter{
+		ProjectID:           sc.QueryParam("project_id"),
+		ApplicationID:       sc.QueryParam("application_id"),
+		ApplicationMetricID: sc.QueryParam("application_metric_id"),
+		Status:              sc.QueryParam("status"),
+		Severity:            sc.QueryParam("severity"),
+		Limit:               defaultListLimit,
+	}
+
+	switch filter.Status {
+	case "", model.StatusFiring, model.StatusResolved:
+	default:
+		return filter, fmt.Errorf("unknown status %q, use firing or resolved", filter.Status)
+	}
+	if filter.Severity != "" && alerts.SeverityRank(filter.Severity) < 0 {
+		return filter, fmt.Errorf("unknown severity %q, use info, warning or critical", filter.Severity)
+	}
+
+	for param, dest := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		v := sc.QueryParam(param)
+		if v == "" {
+			continue
+		}
+		t, err := parseTime(v)
+		if err != nil {
+			return filter, fmt.Errorf("invalid %s: %w", param, err)
+		}
+		// A date as the end of the range includes the whole day
+		if _, err := time.Parse(time.DateOnly, v); err == nil && param == "to" {
+			t = t.AddDate(0, 0, 1)
+		}
+		*dest = &t
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return filter, errors.New("from must be before to")
+	}
+
+	if v := sc.QueryParam("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			return filter, fmt.Errorf("invalid limit %q", v)
+		}
+		if limit > maxListLimit {
+			limit = maxListLimit
+		}
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
+
+// parseTime accepts RFC 3339 timestamps, dates (midnight UTC) and Unix seconds
+func parseTime(v string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	if t, err := time.Parse(time.DateOnly, v); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, errors.New("use RFC 3339 (2024-01-15T10:30:00Z), a date (2024-01-15) or Unix seconds")
+	}
+	return t.UTC(), nil
+}
@@ -0,0 +1,218 @@
+package alert_rule
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"k8s-monitoring-app/internal/core"
+	alertRuleModel "k8s-monitoring-app/pkg/alert_rule/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+// generateUUID generates a simple UUID v4
+func generateUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+type Repository interface {
+	Get(ctx context.Context, id string) (alertRuleModel.AlertRule, error)
+	List(ctx context.Context) ([]alertRuleModel.AlertRule, error)
+	ListByApplicationMetric(ctx context.Context, applicationMetricID string) ([]alertRuleModel.AlertRule, error)
+	Add(ctx context.Context, rule *alertRuleModel.AlertRule) error
+	Update(ctx context.Context, rule *alertRuleModel.AlertRule) error
+	Delete(ctx context.Context, id string) error
+	GetDB() *sql.DB
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepo(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (repo *repository) GetDB() *sql.DB {
+	return repo.db
+}
+
+const selectRules = `
+	SELECT
+		id, application_metric_id, field, comparator, threshold, for_seconds, severity, enabled, created_at, updated_at
+	FROM
+		alert_rules`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRule(row scanner) (alertRuleModel.AlertRule, error) {
+	rule := alertRuleModel.AlertRule{}
+	var threshold float64
+	var forSeconds int
+	var enabled bool
+	err := row.Scan(
+		&rule.ID, &rule.ApplicationMetricID, &rule.Field, &rule.Comparator, &threshold,
+		&forSeconds, &rule.Severity, &enabled, &rule.CreatedAt, &rule.UpdatedAt)
+	if err != nil {
+		return rule, err
+	}
+	rule.Threshold = &threshold
+	rule.ForSeconds = &forSeconds
+	rule.Enabled = &enabled
+
+	return rule, nil
+}
+
+func (repo *repository) Get(ctx context.Context, id string) (alertRuleModel.AlertRule, error) {
+	sqlString := fmt.Sprintf("%s WHERE id = ?", selectRules)
+
+	return scanRule(repo.db.QueryRowContext(ctx, core.Rebind(sqlString), id))
+}
+
+func (repo *repository) List(ctx context.Context) ([]alertRuleModel.AlertRule, error) {
+	sqlString := fmt.Sprintf("%s ORDER BY created_at", selectRules)
+
+	return repo.list(ctx, sqlString)
+}
+
+func (repo *repository) ListByApplicationMetric(ctx context.Context, applicationMetricID string) ([]alertRuleModel.AlertRule, error) {
+	sqlString := fmt.Sprintf("%s WHERE application_metric_id = ? ORDER BY created_at", selectRules)
+
+	return repo.list(ctx, sqlString, applicationMetricID)
+}
+
+func (repo *repository) list(ctx context.Context, sqlString string, args ...interface{}) ([]alertRuleModel.AlertRule, error) {
+	rules := []alertRuleModel.AlertRule{}
+
+	rows, err := repo.db.QueryContext(ctx, core.Rebind(sqlString), args...)
+	if err != nil {
+		return rules, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return rules, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
+
+func (repo *repository) Add(ctx context.Context, rule *alertRuleModel.AlertRule) error {
+	rule.ID = generateUUID()
+	now := time.Now()
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+
+	if rule.ForSeconds == nil {
+		forSeconds := 0
+		rule.ForSeconds = &forSeconds
+	}
+	if rule.Enabled == nil {
+		enabled := true
+		rule.Enabled = &enabled
+	}
+
+	sqlString := `INSERT INTO alert_rules(
+		id, application_metric_id, field, comparator, threshold, for_seconds, severity, enabled, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := repo.db.ExecContext(ctx, core.Rebind(sqlString),
+		rule.ID, rule.ApplicationMetricID, rule.Field, rule.Comparator, *rule.Threshold,
+		*rule.ForSeconds, rule.Severity, *rule.Enabled, rule.CreatedAt, rule.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Update changes the condition, severity and enabled flag of a rule. The
+// application metric it belongs to is fixed.
+func (repo *repository) Update(ctx context.Context, rule *alertRuleModel.AlertRule) error {
+	var params []interface{}
+
+	sqlString := `UPDATE alert_rules SET `
+
+	if rule.Field != "" {
+		sqlString = fmt.Sprintf("%s field = ?, ", sqlString)
+		params = append(params, rule.Field)
+	}
+	if rule.Comparator != "" {
+		sqlString = fmt.Sprintf("%s comparator = ?, ", sqlString)
+		params = append(params, rule.Comparator)
+	}
+	if rule.Threshold != nil {
+		sqlString = fmt.Sprintf("%s threshold = ?, ", sqlString)
+		params = append(params, *rule.Threshold)
+	}
+	if rule.ForSeconds != nil {
+		sqlString = fmt.Sprintf("%s for_seconds = ?, ", sqlString)
+		params = append(params, *rule.ForSeconds)
+	}
+	if rule.Severity != "" {
+		sqlString = fmt.Sprintf("%s severity = ?, ", sqlString)
+		params = append(params, rule.Severity)
+	}
+	if rule.Enabled != nil {
+		sqlString = fmt.Sprintf("%s enabled = ?, ", sqlString)
+		params = append(params, *rule.Enabled)
+	}
+	if len(params) == 0 {
+		log.Warn().Msg("no fields to update")
+		return nil
+	}
+
+	sqlString = fmt.Sprintf("%s updated_at = CURRENT_TIMESTAMP WHERE id = ?", sqlString)
+	params = append(params, rule.ID)
+
+	result, err := repo.db.ExecContext(ctx, core.Rebind(sqlString), params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (repo *repository) Delete(ctx context.Context, id string) error {
+	sqlString := `DELETE FROM alert_rules WHERE id = ?`
+
+	result, err := repo.db.ExecContext(ctx, core.Rebind(sqlString), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package alert_rule
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"k8s-monitoring-app/internal/alerts"
+	"k8s-monitoring-app/internal/collector"
+	"k8s-monitoring-app/internal/core"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	model "k8s-monitoring-app/pkg/alert_rule/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+type service struct{}
+
+func NewService() model.Service {
+	return &service{}
+}
+
+func (s *service) Get(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	id := sc.Param("id")
+
+	if len(id) == 0 {
+		log.Error().Err(errors.New("id is empty")).Msg("error getting alert rule")
+		return sc.String(http.StatusBadRequest, "invalid request")
+	}
+
+	rule, err := serverModel.ServerRepos.AlertRule.Get(ctx, id)
+	if err != nil {
+		log.Error().Msg("error getting alert rule")
+		return sc.String(http.StatusNotFound, "alert rule not found")
+	}
+
+	return sc.JSON(http.StatusOK, rule)
+}
+
+func (s *service) List(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	rules, err := serverModel.ServerRepos.AlertRule.List(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("error listing alert rules")
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	}
+
+	return sc.JSON(http.StatusOK, rules)
+}
+
+func (s *service) ListByApplicationMetric(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	applicationMetricID := sc.Param("id")
+
+	if len(applicationMetricID) == 0 {
+		log.Error().Msg("application metric id is empty")
+		return sc.String(http.StatusBadRequest, "invalid request")
+	}
+
+	rules, err := serverModel.ServerRepos.AlertRule.ListByApplicationMetric(ctx, applicationMetricID)
+	if err != nil {
+		log.Error().Err(err).Msg("error listing alert rules by application metric")
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	}
+
+	return sc.JSON(http.StatusOK, rules)
+}
+
+func (s *service) Add(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	rule := model.AlertRule{}
+	if err := sc.Bind(&rule); err != nil {
+		log.Error().Msg("error binding alert rule")
+		return sc.String(http.StatusBadRequest, "invalid request body")
+	}
+
+	// Validate that the application metric exists
+	if _, err := serverModel.ServerRepos.ApplicationMetric.Get(ctx, rule.ApplicationMetricID); err != nil {
+		log.Error().Msg("error getting application metric")
+		return sc.String(http.StatusBadRequest, "application metric not found")
+	}
+
+	if rule.Severity == "" {
+		rule.Severity = alerts.SeverityWarning
+	}
+	if err := validate(ctx, rule); err != nil {
+		return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid rule",
+			"message": err.Error(),
+		})
+	}
+
+	if err := serverModel.ServerRepos.AlertRule.Add(ctx, &rule); err != nil {
+		log.Error().Err(err).Msg("error add alert rule")
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	}
+
+	return sc.JSON(http.StatusCreated, rule)
+}
+
+func (s *service) Update(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+	id := sc.Param("id")
+
+	existing, err := serverModel.ServerRepos.AlertRule.Get(ctx, id)
+	if err != nil {
+		log.Error().Msg("error getting alert rule")
+		return sc.String(http.StatusNotFound, "alert rule not found")
+	}
+
+	rule := model.AlertRule{}
+	if err := sc.Bind(&rule); err != nil {
+		log.Error().Msg("error binding alert rule")
+		return sc.String(http.StatusBadRequest, "Invalid Request")
+	}
+
+	// The application metric of a rule is fixed
+	rule.ID = id
+	rule.ApplicationMetricID = existing.ApplicationMetricID
+
+	// Validate the rule as it will be stored
+	merged := existing
+	if rule.Field != "" {
+		merged.Field = rule.Field
+	}
+	if rule.Comparator != "" {
+		merged.Comparator = rule.Comparator
+	}
+	if rule.Threshold != nil {
+		merged.Threshold = rule.Threshold
+	}
+	if rule.ForSeconds != nil {
+		merged.ForSeconds = rule.ForSeconds
+	}
+	if rule.Severity != "" {
+		merged.Severity = rule.Severity
+	}
+	if err := validate(ctx, merged); err != nil {
+		return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid rule",
+			"message": err.Error(),
+		})
+	}
+
+	if err := serverModel.ServerRepos.AlertRule.Update(ctx, &rule); err != nil {
+		log.Error().Err(err).Msg("error updating alert rule")
+		return sc.String(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	updated, err := serverModel.ServerRepos.AlertRule.Get(ctx, id)
+	if err != nil {
+		log.Error().Err(err).Msg("error getting alert rule")
+		return sc.String(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	return sc.JSON(http.StatusOK, updated)
+}
+
+func (s *service) Delete(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+	id := sc.Param("id")
+
+	if len(id) == 0 {
+		log.Error().Err(errors.New("id is empty")).Msg("error deleting alert rule")
+		return sc.String(http.StatusBadRequest, "Invalid Request")
+	}
+
+	err := serverModel.ServerRepos.AlertRule.Delete(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sc.String(http.StatusNotFound, "alert rule not found")
+		}
+		log.Error().Err(err).Str("id", id).Msg("error deleting alert rule")
+		return sc.String(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	return sc.JSON(http.StatusOK, map[string]bool{"success": true})
+}
+
+// validate checks a rule and that the collector of its metric fills its field
+func validate(ctx context.Context, rule model.AlertRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	appMetric, err := serverModel.ServerRepos.ApplicationMetric.Get(ctx, rule.ApplicationMetricID)
+	if err != nil {
+		return fmt.Errorf("application metric %s not found", rule.ApplicationMetricID)
+	}
+	metricType, err := serverModel.ServerRepos.MetricType.Get(ctx, appMetric.TypeID)
+	if err != nil {
+		return fmt.Errorf("metric type %s not found", appMetric.TypeID)
+	}
+	c, ok := collector.Get(metricType.Name)
+	if !ok {
+		return fmt.Errorf("unsupported metric type: %s", metricType.Name)
+	}
+	return rule.ValidateField(metricType.Name, c.ValueFields())
+}
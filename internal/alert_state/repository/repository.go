@@ -0,0 +1,158 @@
+package alert_state
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"k8s-monitoring-app/internal/core"
+	alertStateModel "k8s-monitoring-app/pkg/alert_state/model"
+)
+
+type Repository interface {
+	// Get returns sql.ErrNoRows when the metric never had a problem
+	Get(ctx context.Context, applicationMetricID string) (alertStateModel.AlertState, error)
+	List(ctx context.Context) ([]alertStateModel.AlertState, error)
+	// Save inserts or replaces the state of a metric
+	Save(ctx context.Context, state *alertStateModel.AlertState) error
+	Delete(ctx context.Context, applicationMetricID string) error
+	GetDB() *sql.DB
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepo(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (repo *repository) GetDB() *sql.DB {
+	return repo.db
+}
+
+const selectStates = `
+	SELECT
+		application_metric_id, state, reason, severity, consecutive_failures, consecutive_successes,
+		started_at, fired_at, resolved_at, notified_at, silence_id, alert_id, updated_at,
+		flapping, flap_score, flapping_since, flap_alert_id
+	FROM
+		alert_states`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanState(row scanner) (alertStateModel.AlertState, error) {
+	state := alertStateModel.AlertState{}
+	var reason, silenceID, alertID, flapAlertID sql.NullString
+	var startedAt, firedAt, resolvedAt, notifiedAt, flappingSince sql.NullTime
+	err := row.Scan(
+		&state.ApplicationMetricID, &state.State, &reason, &state.Severity, &state.ConsecutiveFailures, &state.ConsecutiveSuccesses,
+		&startedAt, &firedAt, &resolvedAt, &notifiedAt, &silenceID, &alertID, &state.UpdatedAt,
+		&state.Flapping, &state.FlapScore, &flappingSince, &flapAlertID)
+	if err != nil {
+		return state, err
+	}
+	state.Reason = reason.String
+	state.SilenceID = silenceID.String
+	state.AlertID = alertID.String
+	state.StartedAt = timePtr(startedAt)
+	state.FiredAt = timePtr(firedAt)
+	state.ResolvedAt = timePtr(resolvedAt)
+	state.NotifiedAt = timePtr(notifiedAt)
+	state.FlappingSince = timePtr(flappingSince)
+	state.FlapAlertID = flapAlertID.String
+
+	return state, nil
+}
+
+func (repo *repository) Get(ctx context.Context, applicationMetricID string) (alertStateModel.AlertState, error) {
+	sqlString := fmt.Sprintf("%s WHERE application_metric_id = ?", selectStates)
+
+	return scanState(repo.db.QueryRowContext(ctx, core.Rebind(sqlString), applicationMetricID))
+}
+
+func (repo *repository) List(ctx context.Context) ([]alertStateModel.AlertState, error) {
+	states := []alertStateModel.AlertState{}
+
+	rows, err := repo.db.QueryContext(ctx, core.Rebind(selectStates))
+	if err != nil {
+		return states, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		state, err := scanState(rows)
+		if err != nil {
+			return states, err
+		}
+
+		states = append(states, state)
+	}
+
+	return states, rows.Err()
+}
+
+func (repo *repository) Save(ctx context.Context, state *alertStateModel.AlertState) error {
+	state.UpdatedAt = time.Now()
+
+	sqlString := `INSERT INTO alert_states(
+		application_metric_id, state, reason, severity, consecutive_failures, consecutive_successes,
+		started_at, fired_at, resolved_at, notified_at, silence_id, alert_id, updated_at,
+		flapping, flap_score, flapping_since, flap_alert_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(application_metric_id) DO UPDATE SET
+			state = excluded.state,
+			reason = excluded.reason,
+			severity = excluded.severity,
+			consecutive_failures = excluded.consecutive_failures,
+			consecutive_successes = excluded.consecutive_successes,
+			started_at = excluded.started_at,
+			fired_at = excluded.fired_at,
+			resolved_at = excluded.resolved_at,
+			notified_at = excluded.notified_at,
+			silence_id = excluded.silence_id,
+			alert_id = excluded.alert_id,
+			updated_at = excluded.updated_at,
+			flapping = excluded.flapping,
+			flap_score = excluded.flap_score,
+			flapping_since = excluded.flapping_since,
+			flap_alert_id = excluded.flap_alert_id`
+
+	_, err := repo.db.ExecContext(ctx, core.Rebind(sqlString),
+		state.ApplicationMetricID, state.State, nullString(state.Reason), state.Severity, state.ConsecutiveFailures, state.ConsecutiveSuccesses,
+		nullTime(state.StartedAt), nullTime(state.FiredAt), nullTime(state.ResolvedAt), nullTime(state.NotifiedAt),
+		nullString(state.SilenceID), nullString(state.AlertID), state.UpdatedAt,
+		state.Flapping, state.FlapScore, nullTime(state.FlappingSince), nullString(state.FlapAlertID),
+	)
+	return err
+}
+
+func (repo *repository) Delete(ctx context.Context, applicationMetricID string) error {
+	sqlString := `DELETE FROM alert_states WHERE application_metric_id = ?`
+
+	_, err := repo.db.ExecContext(ctx, core.Rebind(sqlString), applicationMetricID)
+	return err
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
@@ -0,0 +1,161 @@
+package alert_template
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"k8s-monitoring-app/internal/core"
+	alertTemplateModel "k8s-monitoring-app/pkg/alert_template/model"
+)
+
+// generateUUID generates a simple UUID v4
+func generateUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+type Repository interface {
+	Get(ctx context.Context, id string) (alertTemplateModel.AlertTemplate, error)
+	List(ctx context.Context) ([]alertTemplateModel.AlertTemplate, error)
+	Add(ctx context.Context, t *alertTemplateModel.AlertTemplate) error
+	Update(ctx context.Context, t *alertTemplateModel.AlertTemplate) error
+	Delete(ctx context.Context, id string) error
+	GetDB() *sql.DB
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepo(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (repo *repository) GetDB() *sql.DB {
+	return repo.db
+}
+
+const selectTemplates = `
+	SELECT
+		id, metric_type_id, channel, title, body, created_at, updated_at
+	FROM
+		alert_templates`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTemplate(row scanner) (alertTemplateModel.AlertTemplate, error) {
+	t := alertTemplateModel.AlertTemplate{}
+	var metricTypeID, channel, title sql.NullString
+	err := row.Scan(&t.ID, &metricTypeID, &channel, &title, &t.Body, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return t, err
+	}
+	t.MetricTypeID = metricTypeID.String
+	t.Channel = channel.String
+	t.Title = title.String
+
+	return t, nil
+}
+
+func (repo *repository) Get(ctx context.Context, id string) (alertTemplateModel.AlertTemplate, error) {
+	sqlString := fmt.Sprintf("%s WHERE id = ?", selectTemplates)
+
+	return scanTemplate(repo.db.QueryRowContext(ctx, core.Rebind(sqlString), id))
+}
+
+func (repo *repository) List(ctx context.Context) ([]alertTemplateModel.AlertTemplate, error) {
+	templates := []alertTemplateModel.AlertTemplate{}
+
+	sqlString := fmt.Sprintf("%s ORDER BY created_at", selectTemplates)
+
+	rows, err := repo.db.QueryContext(ctx, core.Rebind(sqlString))
+	if err != nil {
+		return templates, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		t, err := scanTemplate(rows)
+		if err != nil {
+			return templates, err
+		}
+
+		templates = append(templates, t)
+	}
+
+	return templates, rows.Err()
+}
+
+func (repo *repository) Add(ctx context.Context, t *alertTemplateModel.AlertTemplate) error {
+	t.ID = generateUUID()
+	now := time.Now()
+	t.CreatedAt = now
+	t.UpdatedAt = now
+
+	sqlString := `INSERT INTO alert_templates(
+		id, metric_type_id, channel, title, body, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := repo.db.ExecContext(ctx, core.Rebind(sqlString),
+		t.ID, nullString(t.MetricTypeID), nullString(t.Channel), nullString(t.Title), t.Body,
+		t.CreatedAt, t.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Update replaces the title and body of a template. Its scope is fixed.
+func (repo *repository) Update(ctx context.Context, t *alertTemplateModel.AlertTemplate) error {
+	sqlString := `UPDATE alert_templates SET title = ?, body = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`
+
+	result, err := repo.db.ExecContext(ctx, core.Rebind(sqlString), nullString(t.Title), t.Body, t.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (repo *repository) Delete(ctx context.Context, id string) error {
+	sqlString := `DELETE FROM alert_templates WHERE id = ?`
+
+	result, err := repo.db.ExecContext(ctx, core.Rebind(sqlString), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
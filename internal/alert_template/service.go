@@ -0,0 +1,169 @@
+package alert_template
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"k8s-monitoring-app/internal/core"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	model "k8s-monitoring-app/pkg/alert_template/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+type service struct{}
+
+func NewService() model.Service {
+	return &service{}
+}
+
+func (s *service) Get(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	id := sc.Param("id")
+
+	if len(id) == 0 {
+		log.Error().Err(errors.New("id is empty")).Msg("error getting alert template")
+		return sc.String(http.StatusBadRequest, "invalid request")
+	}
+
+	t, err := serverModel.ServerRepos.AlertTemplate.Get(ctx, id)
+	if err != nil {
+		log.Error().Msg("error getting alert template")
+		return sc.String(http.StatusNotFound, "alert template not found")
+	}
+
+	return sc.JSON(http.StatusOK, t)
+}
+
+func (s *service) List(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	templates, err := serverModel.ServerRepos.AlertTemplate.List(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("error listing alert templates")
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	}
+
+	return sc.JSON(http.StatusOK, templates)
+}
+
+// Add creates a template for a metric type and/or channel kind. There is at
+// most one template per scope.
+func (s *service) Add(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	t := model.AlertTemplate{}
+	if err := sc.Bind(&t); err != nil {
+		log.Error().Msg("error binding alert template")
+		return sc.String(http.StatusBadRequest, "invalid request body")
+	}
+
+	if err := t.Validate(); err != nil {
+		return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid template",
+			"message": err.Error(),
+		})
+	}
+
+	// Validate that the metric type exists
+	if t.MetricTypeID != "" {
+		if _, err := serverModel.ServerRepos.MetricType.Get(ctx, t.MetricTypeID); err != nil {
+			log.Error().Msg("error getting metric type")
+			return sc.String(http.StatusBadRequest, "metric type not found")
+		}
+	}
+
+	templates, err := serverModel.ServerRepos.AlertTemplate.List(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("error listing alert templates")
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	}
+	for _, existing := range templates {
+		if existing.MetricTypeID == t.MetricTypeID && existing.Channel == t.Channel {
+			return sc.JSON(http.StatusConflict, map[string]interface{}{
+				"error":   "template exists",
+				"message": "a template with this metric_type_id and channel already exists: " + existing.ID,
+			})
+		}
+	}
+
+	if err := serverModel.ServerRepos.AlertTemplate.Add(ctx, &t); err != nil {
+		log.Error().Err(err).Msg("error add alert template")
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	}
+
+	return sc.JSON(http.StatusCreated, t)
+}
+
+// Update changes the title and body of a template. Omitted fields are left
+// unchanged; an empty title restores the default title.
+func (s *service) Update(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+	id := sc.Param("id")
+
+	existing, err := serverModel.ServerRepos.AlertTemplate.Get(ctx, id)
+	if err != nil {
+		log.Error().Msg("error getting alert template")
+		return sc.String(http.StatusNotFound, "alert template not found")
+	}
+
+	body := struct {
+		Title *string `json:"title"`
+		Body  *string `json:"body"`
+	}{}
+	if err := sc.Bind(&body); err != nil {
+		log.Error().Msg("error binding alert template")
+		return sc.String(http.StatusBadRequest, "Invalid Request")
+	}
+
+	// The scope of a template is fixed
+	t := existing
+	if body.Title != nil {
+		t.Title = *body.Title
+	}
+	if body.Body != nil {
+		t.Body = *body.Body
+	}
+	if err := t.Validate(); err != nil {
+		return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid template",
+			"message": err.Error(),
+		})
+	}
+
+	if err := serverModel.ServerRepos.AlertTemplate.Update(ctx, &t); err != nil {
+		log.Error().Err(err).Msg("error updating alert template")
+		return sc.String(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	updated, err := serverModel.ServerRepos.AlertTemplate.Get(ctx, id)
+	if err != nil {
+		log.Error().Err(err).Msg("error getting alert template")
+		return sc.String(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	return sc.JSON(http.StatusOK, updated)
+}
+
+func (s *service) Delete(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+	id := sc.Param("id")
+
+	if len(id) == 0 {
+		log.Error().Err(errors.New("id is empty")).Msg("error deleting alert template")
+		return sc.String(http.StatusBadRequest, "Invalid Request")
+	}
+
+	err := serverModel.ServerRepos.AlertTemplate.Delete(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sc.String(http.StatusNotFound, "alert template not found")
+		}
+		log.Error().Err(err).Str("id", id).Msg("error deleting alert template")
+		return sc.String(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	return sc.JSON(http.StatusOK, map[string]bool{"success": true})
+}
@@ -0,0 +1,156 @@
+package alerts
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"k8s-monitoring-app/internal/env"
+)
+
+// FromEnv returns a notifier for every channel configured through environment
+// variables. Slack also needs SLACK_ALERTS_ENABLED.
+func FromEnv() []Notifier {
+	notifiers := []Notifier{}
+
+	if env.SLACK_ALERTS_ENABLED && env.SLACK_WEBHOOK_URL != "" {
+		notifiers = append(notifiers, NewSlack(env.SLACK_WEBHOOK_URL))
+	}
+	if env.ALERT_WEBHOOK_URL != "" {
+		notifiers = append(notifiers, NewWebhook(env.ALERT_WEBHOOK_URL, env.ALERT_WEBHOOK_SECRET))
+	}
+	if env.TEAMS_WEBHOOK_URL != "" {
+		notifiers = append(notifiers, NewTeams(env.TEAMS_WEBHOOK_URL))
+	}
+	if env.GOOGLE_CHAT_WEBHOOK_URL != "" {
+		notifiers = append(notifiers, NewGoogleChat(env.GOOGLE_CHAT_WEBHOOK_URL))
+	}
+	if env.DISCORD_WEBHOOK_URL != "" {
+		notifiers = append(notifiers, NewDiscord(env.DISCORD_WEBHOOK_URL))
+	}
+	if env.TELEGRAM_BOT_TOKEN != "" && env.TELEGRAM_CHAT_ID != "" {
+		notifiers = append(notifiers, NewTelegram(env.TELEGRAM_BOT_TOKEN, env.TELEGRAM_CHAT_ID))
+	}
+	if env.SMTP_HOST != "" && env.SMTP_TO != "" {
+		notifiers = append(notifiers, NewEmail(SMTPConfig{
+			Host:     env.SMTP_HOST,
+			Port:     env.SMTP_PORT,
+			Username: env.SMTP_USERNAME,
+			Password: env.SMTP_PASSWORD,
+			From:     env.SMTP_FROM,
+			To:       splitList(env.SMTP_TO),
+		}))
+	}
+	if env.PAGERDUTY_ROUTING_KEY != "" {
+		notifiers = append(notifiers, NewPagerDuty("", RoutingKeys{Default: env.PAGERDUTY_ROUTING_KEY}))
+	}
+	if env.OPSGENIE_API_KEY != "" {
+		notifiers = append(notifiers, NewOpsgenie(env.OPSGENIE_API_URL, RoutingKeys{Default: env.OPSGENIE_API_KEY}))
+	}
+
+	return notifiers
+}
+
+// splitList splits a comma-separated list, dropping empty entries
+func splitList(s string) []string {
+	out := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// Kinds lists the channel kinds that can be stored per project or application
+var Kinds = []string{KindSlack, KindWebhook, KindTeams, KindGoogleChat, KindDiscord, KindTelegram, KindEmail, KindPagerDuty, KindOpsgenie}
+
+// Settings configures a notification channel stored in the database. Each kind
+// reads its own keys.
+type Settings struct {
+	URL      string `json:"url,omitempty"`       // slack, webhook, teams, google_chat, discord; pagerduty, opsgenie: API URL override
+	Secret   string `json:"secret,omitempty"`    // webhook: HMAC-SHA256 signing secret
+	BotToken string `json:"bot_token,omitempty"` // telegram
+	ChatID   string `json:"chat_id,omitempty"`   // telegram
+	Host     string `json:"host,omitempty"`      // email: SMTP server
+	Port     int    `json:"port,omitempty"`      // email: defaults to 587
+	Username string `json:"username,omitempty"`  // email
+	Password string `json:"password,omitempty"`  // email
+	From     string `json:"from,omitempty"`      // email: defaults to username
+	To       string `json:"to,omitempty"`        // email: comma-separated recipients
+
+	// pagerduty: Events v2 integration keys; opsgenie: API integration keys.
+	// An alert goes with the key of its severity, or the default key.
+	RoutingKey         string `json:"routing_key,omitempty"`
+	RoutingKeyInfo     string `json:"routing_key_info,omitempty"`
+	RoutingKeyWarning  string `json:"routing_key_warning,omitempty"`
+	RoutingKeyCritical string `json:"routing_key_critical,omitempty"`
+}
+
+// New returns the notifier of a channel kind, failing when a setting the kind
+// needs is missing
+func New(kind string, settings Settings) (Notifier, error) {
+	switch kind {
+	case KindSlack, KindWebhook, KindTeams, KindGoogleChat, KindDiscord:
+		if u, err := url.Parse(settings.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("url is required for %s channels and must be an http(s) URL", kind)
+		}
+		switch kind {
+		case KindSlack:
+			return NewSlack(settings.URL), nil
+		case KindWebhook:
+			return NewWebhook(settings.URL, settings.Secret), nil
+		case KindTeams:
+			return NewTeams(settings.URL), nil
+		case KindGoogleChat:
+			return NewGoogleChat(settings.URL), nil
+		default:
+			return NewDiscord(settings.URL), nil
+		}
+
+	case KindTelegram:
+		if settings.BotToken == "" || settings.ChatID == "" {
+			return nil, fmt.Errorf("bot_token and chat_id are required for telegram channels")
+		}
+		return NewTelegram(settings.BotToken, settings.ChatID), nil
+
+	case KindEmail:
+		to := splitList(settings.To)
+		if settings.Host == "" || len(to) == 0 {
+			return nil, fmt.Errorf("host and to are required for email channels")
+		}
+		if settings.Port < 0 || settings.Port > 65535 {
+			return nil, fmt.Errorf("port must be between 1 and 65535")
+		}
+		from := settings.From
+		if from == "" {
+			from = settings.Username
+		}
+		return NewEmail(SMTPConfig{
+			Host:     settings.Host,
+			Port:     settings.Port,
+			Username: settings.Username,
+			Password: settings.Password,
+			From:     from,
+			To:       to,
+		}), nil
+
+	case KindPagerDuty, KindOpsgenie:
+		if settings.URL != "" {
+			if u, err := url.Parse(settings.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return nil, fmt.Errorf("url must be an http(s) URL")
+			}
+		}
+		keys, err := routingKeys(settings)
+		if err != nil {
+			return nil, err
+		}
+		if kind == KindPagerDuty {
+			return NewPagerDuty(settings.URL, keys), nil
+		}
+		return NewOpsgenie(settings.URL, keys), nil
+
+	default:
+		return nil, fmt.Errorf("unknown kind %q, use one of: %s", kind, strings.Join(Kinds, ", "))
+	}
+}
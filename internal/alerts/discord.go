@@ -0,0 +1,74 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Side bar colors of alert embeds
+const (
+	discordAlertColor    = 0xE01E5A
+	discordResolvedColor = 0x2EB67D
+)
+
+// Discord webhook structures
+type discordMessage struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	Color       int            `json:"color"`
+	Fields      []discordField `json:"fields"`
+	Timestamp   string         `json:"timestamp,omitempty"`
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+// discordNotifier posts alerts to a Discord channel webhook
+type discordNotifier struct {
+	webhookURL string
+}
+
+// NewDiscord returns a notifier posting embeds to a Discord channel webhook
+func NewDiscord(webhookURL string) Notifier {
+	return &discordNotifier{webhookURL: webhookURL}
+}
+
+func (n *discordNotifier) Kind() string {
+	return KindDiscord
+}
+
+func (n *discordNotifier) Notify(ctx context.Context, alert Alert) error {
+	embed := discordEmbed{Title: alert.Title, Color: discordAlertColor}
+	if alert.Resolved() {
+		embed.Color = discordResolvedColor
+	}
+	if !alert.Time.IsZero() {
+		embed.Timestamp = alert.Time.UTC().Format(time.RFC3339)
+	}
+	fields := alert.Fields()
+	if alert.Message != "" {
+		embed.Description = alert.Message
+		fields = nil
+	}
+	for _, f := range fields {
+		value := f.Value
+		if value == "" {
+			// Discord rejects empty field values
+			value = "-"
+		}
+		embed.Fields = append(embed.Fields, discordField{Name: f.Label, Value: value, Inline: f.Label != "Reason"})
+	}
+
+	if err := postJSON(ctx, n.webhookURL, discordMessage{Embeds: []discordEmbed{embed}}, nil); err != nil {
+		return fmt.Errorf("discord: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,140 @@
+package alerts
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTPConfig holds the SMTP server and addresses used to email alerts
+type SMTPConfig struct {
+	Host     string
+	Port     int // 465 uses implicit TLS, other ports STARTTLS when the server offers it
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// emailNotifier emails alerts over SMTP
+type emailNotifier struct {
+	config SMTPConfig
+}
+
+// NewEmail returns a notifier emailing alerts over SMTP
+func NewEmail(config SMTPConfig) Notifier {
+	if config.Port == 0 {
+		config.Port = 587
+	}
+	return &emailNotifier{config: config}
+}
+
+func (n *emailNotifier) Kind() string {
+	return KindEmail
+}
+
+func (n *emailNotifier) Notify(ctx context.Context, alert Alert) error {
+	if len(n.config.To) == 0 {
+		return fmt.Errorf("email: no recipients")
+	}
+
+	subject := fmt.Sprintf("[K8s Monitoring] %s: %s / %s", alert.Title, alert.Application, alert.Metric)
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s\r\n\r\n", alert.Title)
+	if alert.Message != "" {
+		fmt.Fprintf(&body, "%s\r\n", strings.ReplaceAll(alert.Message, "\n", "\r\n"))
+	} else {
+		for _, f := range alert.Fields() {
+			fmt.Fprintf(&body, "%s: %s\r\n", f.Label, f.Value)
+		}
+	}
+
+	date := alert.Time
+	if date.IsZero() {
+		date = time.Now()
+	}
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(n.config.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(body.String())
+
+	if err := n.send(ctx, []byte(msg.String())); err != nil {
+		return fmt.Errorf("email: %w", err)
+	}
+	return nil
+}
+
+// send delivers a message within the notification timeout
+func (n *emailNotifier) send(ctx context.Context, msg []byte) error {
+	addr := net.JoinHostPort(n.config.Host, strconv.Itoa(n.config.Port))
+	tlsConfig := &tls.Config{ServerName: n.config.Host}
+
+	ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+	defer cancel()
+
+	var conn net.Conn
+	var err error
+	if n.config.Port == 465 {
+		dialer := &tls.Dialer{Config: tlsConfig}
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	} else {
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("connect to %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, n.config.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if n.config.Port != 465 {
+		if ok, _ := c.Extension("STARTTLS"); ok {
+			if err := c.StartTLS(tlsConfig); err != nil {
+				return fmt.Errorf("starttls: %w", err)
+			}
+		}
+	}
+	if n.config.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", n.config.Username, n.config.Password, n.config.Host)); err != nil {
+			return fmt.Errorf("auth: %w", err)
+		}
+	}
+
+	if err := c.Mail(n.config.From); err != nil {
+		return err
+	}
+	for _, to := range n.config.To {
+		if err := c.Rcpt(to); err != nil {
+			return fmt.Errorf("recipient %s: %w", to, err)
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
@@ -0,0 +1,39 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// googleChatNotifier posts alerts to a Google Chat space webhook
+type googleChatNotifier struct {
+	webhookURL string
+}
+
+// NewGoogleChat returns a notifier posting to a Google Chat space webhook
+func NewGoogleChat(webhookURL string) Notifier {
+	return &googleChatNotifier{webhookURL: webhookURL}
+}
+
+func (n *googleChatNotifier) Kind() string {
+	return KindGoogleChat
+}
+
+func (n *googleChatNotifier) Notify(ctx context.Context, alert Alert) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s *K8S Monitoring App Alert*\n*%s*\n", statusEmoji(alert), alert.Title)
+	if alert.Message != "" {
+		fmt.Fprintf(&b, "\n%s", alert.Message)
+	} else {
+		for _, f := range alert.Fields() {
+			fmt.Fprintf(&b, "\n*%s*: %s", f.Label, f.Value)
+		}
+	}
+
+	payload := map[string]string{"text": b.String()}
+	if err := postJSON(ctx, n.webhookURL, payload, nil); err != nil {
+		return fmt.Errorf("google chat: %w", err)
+	}
+	return nil
+}
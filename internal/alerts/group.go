@@ -0,0 +1,82 @@
+package alerts
+
+import (
+	"fmt"
+	"strings"
+)
+
+// groupNamesMax is how many distinct names a grouped alert field lists
+const groupNamesMax = 3
+
+// Group returns one alert standing for several alerts, e.g. every metric that
+// failed when a node went down. Its message lists the alerts, one per line,
+// and it takes the most urgent severity and the earliest start. The alerts are
+// kept in Alerts for webhook receivers.
+func Group(title string, group []Alert) Alert {
+	grouped := Alert{
+		Status: StatusFiring,
+		Title:  title,
+		Reason: fmt.Sprintf("%d affected metrics", len(group)),
+		Alerts: group,
+	}
+	if len(group) == 0 {
+		return grouped
+	}
+
+	grouped.Status = group[0].Status
+	grouped.Project = groupNames(group, func(a Alert) string { return a.Project })
+	grouped.Application = groupNames(group, func(a Alert) string { return a.Application })
+	grouped.Namespace = groupNames(group, func(a Alert) string { return a.Namespace })
+	grouped.Metric = groupNames(group, func(a Alert) string { return a.Metric })
+
+	lines := []string{}
+	for _, a := range group {
+		if SeverityRank(a.Severity) > SeverityRank(grouped.Severity) {
+			grouped.Severity = a.Severity
+		}
+		if !a.StartedAt.IsZero() && (grouped.StartedAt.IsZero() || a.StartedAt.Before(grouped.StartedAt)) {
+			grouped.StartedAt = a.StartedAt
+		}
+		if a.Time.After(grouped.Time) {
+			grouped.Time = a.Time
+		}
+		lines = append(lines, groupLine(a))
+	}
+	grouped.Message = strings.Join(lines, "\n")
+
+	return grouped
+}
+
+// groupLine describes one alert of a group
+func groupLine(a Alert) string {
+	line := fmt.Sprintf("%s %s / %s · %s", statusEmoji(a), a.Project, a.Application, a.Metric)
+	if a.Severity != "" && !a.Resolved() {
+		line += " (" + a.Severity + ")"
+	}
+	if a.Resolved() {
+		return fmt.Sprintf("%s: recovered after %s", line, a.Duration())
+	}
+	if a.Reason != "" {
+		line += ": " + a.Reason
+	}
+	return line
+}
+
+// groupNames lists the distinct values of a field, in order of appearance,
+// shortening long lists to their first names
+func groupNames(group []Alert, field func(Alert) string) string {
+	names := []string{}
+	seen := map[string]bool{}
+	for _, a := range group {
+		name := field(a)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	if len(names) > groupNamesMax {
+		return fmt.Sprintf("%s and %d more", strings.Join(names[:groupNamesMax], ", "), len(names)-groupNamesMax)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,137 @@
+package alerts
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Filter is implemented by notifiers that only take some alerts, like the
+// incident channels without a routing key for the alert severity
+type Filter interface {
+	Accepts(alert Alert) bool
+}
+
+// Accepts reports whether a notifier takes an alert
+func Accepts(notifier Notifier, alert Alert) bool {
+	if filter, ok := notifier.(Filter); ok {
+		return filter.Accepts(alert)
+	}
+	return true
+}
+
+// IsIncidentKind reports whether a channel kind opens incidents that stay open
+// until a resolved alert closes them
+func IsIncidentKind(kind string) bool {
+	return kind == KindPagerDuty || kind == KindOpsgenie
+}
+
+// DedupKey identifies the incident of an alert, so that the alerts of a
+// problem update one incident and its recovery resolves it. It is the
+// application metric ID.
+func (a Alert) DedupKey() string {
+	if a.ApplicationMetricID != "" {
+		return a.ApplicationMetricID
+	}
+	return strings.Join([]string{"k8s-monitoring-app", a.Project, a.Application, a.Metric}, "/")
+}
+
+// RoutingKeys picks the key an incident is routed with by alert severity,
+// falling back to the default key. Severities without a key aren't paged.
+type RoutingKeys struct {
+	Default    string
+	BySeverity map[string]string
+}
+
+// For returns the key of a severity, "" when it isn't paged
+func (k RoutingKeys) For(severity string) string {
+	if key := k.BySeverity[severity]; key != "" {
+		return key
+	}
+	return k.Default
+}
+
+// All returns every distinct key. Recoveries resolve the incident on all of
+// them, since the severity of a problem can change while it fires.
+func (k RoutingKeys) All() []string {
+	keys := []string{}
+	seen := map[string]bool{}
+	for _, key := range append([]string{k.Default}, k.severityKeys()...) {
+		if key != "" && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// severityKeys returns the severity keys, from the least to the most urgent
+func (k RoutingKeys) severityKeys() []string {
+	keys := []string{}
+	for _, severity := range Severities {
+		keys = append(keys, k.BySeverity[severity])
+	}
+	return keys
+}
+
+// accepts reports whether an alert has a key to go with
+func (k RoutingKeys) accepts(alert Alert) bool {
+	if alert.Resolved() {
+		return len(k.All()) > 0
+	}
+	return k.For(alert.Severity) != ""
+}
+
+// keysFor returns the keys an alert is sent with: the key of its severity when
+// firing, every key when resolved
+func (k RoutingKeys) keysFor(alert Alert) ([]string, error) {
+	if alert.Resolved() {
+		return k.All(), nil
+	}
+	key := k.For(alert.Severity)
+	if key == "" {
+		return nil, fmt.Errorf("no routing key for severity %s", alert.Severity)
+	}
+	return []string{key}, nil
+}
+
+// routingKeys reads the routing keys of incident channel settings
+func routingKeys(settings Settings) (RoutingKeys, error) {
+	keys := RoutingKeys{
+		Default: settings.RoutingKey,
+		BySeverity: map[string]string{
+			SeverityInfo:     settings.RoutingKeyInfo,
+			SeverityWarning:  settings.RoutingKeyWarning,
+			SeverityCritical: settings.RoutingKeyCritical,
+		},
+	}
+	if len(keys.All()) == 0 {
+		return keys, errors.New("routing_key or a routing key per severity (routing_key_info, routing_key_warning, routing_key_critical) is required")
+	}
+	return keys, nil
+}
+
+// incidentSummary is the one-line description of an incident
+func incidentSummary(alert Alert, max int) string {
+	summary := fmt.Sprintf("%s: %s/%s %s", alert.Title, alert.Project, alert.Application, alert.Metric)
+	if alert.Reason != "" {
+		summary += " - " + alert.Reason
+	}
+	if runes := []rune(summary); len(runes) > max {
+		summary = string(runes[:max-3]) + "..."
+	}
+	return summary
+}
+
+// incidentDetails returns the alert fields as key/value details
+func incidentDetails(alert Alert) map[string]string {
+	details := map[string]string{}
+	for _, f := range alert.Fields() {
+		details[strings.ToLower(f.Label)] = f.Value
+	}
+	if !alert.StartedAt.IsZero() {
+		details["started_at"] = alert.StartedAt.UTC().Format(time.RFC3339)
+	}
+	return details
+}
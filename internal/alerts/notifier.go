@@ -0,0 +1,159 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Notification channel kinds
+const (
+	KindSlack      = "slack"
+	KindWebhook    = "webhook"
+	KindTeams      = "teams"
+	KindGoogleChat = "google_chat"
+	KindDiscord    = "discord"
+	KindTelegram   = "telegram"
+	KindEmail      = "email"
+	KindPagerDuty  = "pagerduty"
+	KindOpsgenie   = "opsgenie"
+)
+
+// notifyTimeout bounds a single delivery
+const notifyTimeout = 10 * time.Second
+
+// Alert statuses
+const (
+	StatusFiring   = "firing"
+	StatusResolved = "resolved"
+)
+
+// Alert severities, from the least to the most urgent
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Severities lists the alert severities, from the least to the most urgent
+var Severities = []string{SeverityInfo, SeverityWarning, SeverityCritical}
+
+// SeverityRank orders severities, returning -1 for unknown ones
+func SeverityRank(severity string) int {
+	for i, s := range Severities {
+		if s == severity {
+			return i
+		}
+	}
+	return -1
+}
+
+// Alert describes a problem detected on an application metric, or its recovery
+type Alert struct {
+	ID                  string    `json:"id,omitempty"` // Alert history entry
+	ApplicationMetricID string    `json:"application_metric_id,omitempty"`
+	Status              string    `json:"status"`   // firing or resolved
+	Severity            string    `json:"severity"` // info, warning or critical
+	Title               string    `json:"title"`    // e.g. "Metric failure detected"
+	Project             string    `json:"project"`
+	Application         string    `json:"application"`
+	Namespace           string    `json:"namespace"`
+	Metric              string    `json:"metric"` // Metric type name
+	Reason              string    `json:"reason"`
+	Message             string    `json:"message,omitempty"` // Rendered from an alert template; shown instead of the fields
+	StartedAt           time.Time `json:"started_at"`        // When the problem was first seen
+	Time                time.Time `json:"time"`
+	Alerts              []Alert   `json:"alerts,omitempty"` // Alerts sent together in this one, see Group
+}
+
+// Resolved reports whether the alert announces a recovery
+func (a Alert) Resolved() bool {
+	return a.Status == StatusResolved
+}
+
+// Duration returns how long the problem lasted, up to the alert time
+func (a Alert) Duration() time.Duration {
+	if a.StartedAt.IsZero() || a.Time.Before(a.StartedAt) {
+		return 0
+	}
+	return a.Time.Sub(a.StartedAt).Round(time.Second)
+}
+
+// Field is a labeled value of an alert, in display order
+type Field struct {
+	Label string
+	Value string
+}
+
+// Fields returns the labeled values shown by every channel. Resolved alerts
+// also show how long the problem lasted.
+func (a Alert) Fields() []Field {
+	fields := []Field{
+		{Label: "Project", Value: a.Project},
+		{Label: "Application", Value: a.Application},
+		{Label: "Namespace", Value: a.Namespace},
+		{Label: "Metric", Value: a.Metric},
+		{Label: "Severity", Value: a.Severity},
+		{Label: "Reason", Value: a.Reason},
+	}
+	if a.Resolved() {
+		fields = append(fields, Field{Label: "Duration", Value: a.Duration().String()})
+	}
+	return fields
+}
+
+// statusEmoji marks the status of an alert in plain text messages
+func statusEmoji(a Alert) string {
+	if a.Resolved() {
+		return "🟢"
+	}
+	return "🔴"
+}
+
+// Notifier delivers alerts to one destination
+type Notifier interface {
+	// Kind returns the channel kind, used in logs and delivery metrics
+	Kind() string
+	// Notify delivers the alert, returning an error when the destination rejected it
+	Notify(ctx context.Context, alert Alert) error
+}
+
+// postJSON sends a JSON payload and fails on non-2xx responses
+func postJSON(ctx context.Context, url string, payload interface{}, headers map[string]string) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("marshal payload: %w", err)
+	}
+	return postBody(ctx, url, body, headers)
+}
+
+// postBody sends a JSON body and fails on non-2xx responses
+func postBody(ctx context.Context, url string, body []byte, headers map[string]string) error {
+	ctx, cancel := context.WithTimeout(ctx, notifyTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("post: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("returned status %d: %s", resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	return nil
+}
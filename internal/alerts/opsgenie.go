@@ -0,0 +1,132 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// OpsgenieAPIURL is the Opsgenie API of the US region. EU accounts use
+// https://api.eu.opsgenie.com.
+const OpsgenieAPIURL = "https://api.opsgenie.com"
+
+// opsgenieMessageMax is the longest alert message Opsgenie accepts
+const opsgenieMessageMax = 130
+
+// opsgeniePriorities maps alert severities to Opsgenie priorities
+var opsgeniePriorities = map[string]string{
+	SeverityInfo:     "P5",
+	SeverityWarning:  "P3",
+	SeverityCritical: "P1",
+}
+
+// Opsgenie Alert API structures
+type opsgenieAlert struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description,omitempty"`
+	Priority    string            `json:"priority"`
+	Source      string            `json:"source"`
+	Entity      string            `json:"entity,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+}
+
+type opsgenieClose struct {
+	Source string `json:"source"`
+	Note   string `json:"note,omitempty"`
+}
+
+// opsgenieNotifier creates and closes Opsgenie alerts
+type opsgenieNotifier struct {
+	url  string
+	keys RoutingKeys
+}
+
+// NewOpsgenie returns a notifier sending alerts to the Opsgenie Alert API at
+// url (OpsgenieAPIURL when empty), with the API integration key of their
+// severity. Firing alerts create an Opsgenie alert and recoveries close it;
+// the application metric ID is the alias.
+func NewOpsgenie(url string, keys RoutingKeys) Notifier {
+	if url == "" {
+		url = OpsgenieAPIURL
+	}
+	return &opsgenieNotifier{url: strings.TrimSuffix(url, "/"), keys: keys}
+}
+
+func (n *opsgenieNotifier) Kind() string {
+	return KindOpsgenie
+}
+
+func (n *opsgenieNotifier) Accepts(alert Alert) bool {
+	return n.keys.accepts(alert)
+}
+
+func (n *opsgenieNotifier) Notify(ctx context.Context, alert Alert) error {
+	keys, err := n.keys.keysFor(alert)
+	if err != nil {
+		return fmt.Errorf("opsgenie: %w", err)
+	}
+
+	errs := []error{}
+	for _, key := range keys {
+		headers := map[string]string{"Authorization": "GenieKey " + key}
+		if alert.Resolved() {
+			err = postJSON(ctx, n.closeURL(alert), opsgenieClose{
+				Source: "k8s-monitoring-app",
+				Note:   fmt.Sprintf("Metric recovered after %s", alert.Duration()),
+			}, headers)
+		} else {
+			err = postJSON(ctx, n.url+"/v2/alerts", n.alert(alert), headers)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("opsgenie: %w", err)
+	}
+	return nil
+}
+
+// closeURL is the endpoint closing the Opsgenie alert of a problem
+func (n *opsgenieNotifier) closeURL(alert Alert) string {
+	return n.url + "/v2/alerts/" + url.PathEscape(alert.DedupKey()) + "/close?identifierType=alias"
+}
+
+// alert builds the Opsgenie alert of a firing alert
+func (n *opsgenieNotifier) alert(alert Alert) opsgenieAlert {
+	priority, ok := opsgeniePriorities[alert.Severity]
+	if !ok {
+		priority = opsgeniePriorities[SeverityCritical]
+	}
+
+	description := alert.Message
+	if description == "" {
+		lines := []string{}
+		for _, f := range alert.Fields() {
+			lines = append(lines, f.Label+": "+f.Value)
+		}
+		description = strings.Join(lines, "\n")
+	}
+
+	tags := []string{}
+	for _, tag := range []string{alert.Project, alert.Namespace, alert.Metric, alert.Severity} {
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return opsgenieAlert{
+		Message:     incidentSummary(alert, opsgenieMessageMax),
+		Alias:       alert.DedupKey(),
+		Description: description,
+		Priority:    priority,
+		Source:      "k8s-monitoring-app",
+		Entity:      alert.Application,
+		Tags:        tags,
+		Details:     incidentDetails(alert),
+	}
+}
@@ -0,0 +1,171 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestOpsgenieCreate(t *testing.T) {
+	server, requests := newRecorder(t, http.StatusAccepted)
+	notifier := NewOpsgenie(server.URL+"/", RoutingKeys{Default: "default-key"})
+
+	if err := notifier.Notify(context.Background(), testAlert()); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	got := requests()
+	if len(got) != 1 {
+		t.Fatalf("got %d requests, want 1", len(got))
+	}
+	if got[0].Path != "/v2/alerts" {
+		t.Errorf("path = %q, want /v2/alerts", got[0].Path)
+	}
+	if auth := got[0].Header.Get("Authorization"); auth != "GenieKey default-key" {
+		t.Errorf("Authorization = %q, want GenieKey default-key", auth)
+	}
+
+	alert := opsgenieAlert{}
+	if err := json.Unmarshal(got[0].Body, &alert); err != nil {
+		t.Fatalf("decode alert: %v", err)
+	}
+	if alert.Alias != "metric-1" {
+		t.Errorf("alias = %q, want metric-1", alert.Alias)
+	}
+	if want := "Metric failure detected: shop/api HealthCheck - connection refused"; alert.Message != want {
+		t.Errorf("message = %q, want %q", alert.Message, want)
+	}
+	if alert.Priority != "P1" {
+		t.Errorf("priority = %q, want P1", alert.Priority)
+	}
+	if alert.Source != "k8s-monitoring-app" {
+		t.Errorf("source = %q, want k8s-monitoring-app", alert.Source)
+	}
+	if alert.Entity != "api" {
+		t.Errorf("entity = %q, want api", alert.Entity)
+	}
+	wantTags := []string{"shop", "production", "HealthCheck", SeverityCritical}
+	if len(alert.Tags) != len(wantTags) {
+		t.Fatalf("tags = %v, want %v", alert.Tags, wantTags)
+	}
+	for i, tag := range wantTags {
+		if alert.Tags[i] != tag {
+			t.Errorf("tags = %v, want %v", alert.Tags, wantTags)
+			break
+		}
+	}
+}
+
+func TestOpsgenieCreateUsesSeverityKey(t *testing.T) {
+	server, requests := newRecorder(t, http.StatusAccepted)
+	notifier := NewOpsgenie(server.URL, RoutingKeys{
+		Default:    "default-key",
+		BySeverity: map[string]string{SeverityWarning: "warning-key"},
+	})
+
+	alert := testAlert()
+	alert.Severity = SeverityWarning
+	if err := notifier.Notify(context.Background(), alert); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	got := requests()
+	if len(got) != 1 {
+		t.Fatalf("got %d requests, want 1", len(got))
+	}
+	if auth := got[0].Header.Get("Authorization"); auth != "GenieKey warning-key" {
+		t.Errorf("Authorization = %q, want GenieKey warning-key", auth)
+	}
+	created := opsgenieAlert{}
+	if err := json.Unmarshal(got[0].Body, &created); err != nil {
+		t.Fatalf("decode alert: %v", err)
+	}
+	if created.Priority != "P3" {
+		t.Errorf("priority = %q, want P3", created.Priority)
+	}
+}
+
+func TestOpsgenieClose(t *testing.T) {
+	server, requests := newRecorder(t, http.StatusAccepted)
+	notifier := NewOpsgenie(server.URL, RoutingKeys{
+		Default:    "default-key",
+		BySeverity: map[string]string{SeverityCritical: "critical-key"},
+	})
+
+	if err := notifier.Notify(context.Background(), resolvedAlert(testAlert())); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	// The alert is closed with every key, since the severity may have changed
+	// while it fired
+	got := requests()
+	if len(got) != 2 {
+		t.Fatalf("got %d requests, want 2", len(got))
+	}
+	auths := map[string]bool{}
+	for _, request := range got {
+		if request.Path != "/v2/alerts/metric-1/close" {
+			t.Errorf("path = %q, want /v2/alerts/metric-1/close", request.Path)
+		}
+		if request.Query != "identifierType=alias" {
+			t.Errorf("query = %q, want identifierType=alias", request.Query)
+		}
+		closed := opsgenieClose{}
+		if err := json.Unmarshal(request.Body, &closed); err != nil {
+			t.Fatalf("decode close: %v", err)
+		}
+		if closed.Source != "k8s-monitoring-app" {
+			t.Errorf("source = %q, want k8s-monitoring-app", closed.Source)
+		}
+		if closed.Note != "Metric recovered after 10m0s" {
+			t.Errorf("note = %q, want Metric recovered after 10m0s", closed.Note)
+		}
+		auths[request.Header.Get("Authorization")] = true
+	}
+	if !auths["GenieKey default-key"] || !auths["GenieKey critical-key"] {
+		t.Errorf("closed with %v, want default-key and critical-key", auths)
+	}
+}
+
+func TestOpsgenieCreateAndCloseShareAlias(t *testing.T) {
+	server, requests := newRecorder(t, http.StatusAccepted)
+	notifier := NewOpsgenie(server.URL, RoutingKeys{Default: "default-key"})
+
+	alert := testAlert()
+	alert.ApplicationMetricID = "metric/1"
+	if err := notifier.Notify(context.Background(), alert); err != nil {
+		t.Fatalf("Notify create: %v", err)
+	}
+	// A later alert history entry of the same metric must close the same
+	// Opsgenie alert
+	recovery := resolvedAlert(alert)
+	recovery.ID = "history-2"
+	if err := notifier.Notify(context.Background(), recovery); err != nil {
+		t.Fatalf("Notify close: %v", err)
+	}
+
+	got := requests()
+	if len(got) != 2 {
+		t.Fatalf("got %d requests, want 2", len(got))
+	}
+	created := opsgenieAlert{}
+	if err := json.Unmarshal(got[0].Body, &created); err != nil {
+		t.Fatalf("decode alert: %v", err)
+	}
+	if created.Alias != "metric/1" {
+		t.Errorf("alias = %q, want metric/1", created.Alias)
+	}
+	if got[1].Path != "/v2/alerts/metric%2F1/close" {
+		t.Errorf("close path = %q, want /v2/alerts/metric%%2F1/close", got[1].Path)
+	}
+}
+
+func TestOpsgenieErrorStatus(t *testing.T) {
+	server, _ := newRecorder(t, http.StatusUnauthorized)
+	notifier := NewOpsgenie(server.URL, RoutingKeys{Default: "default-key"})
+
+	if err := notifier.Notify(context.Background(), testAlert()); err == nil {
+		t.Fatal("Notify succeeded on a 401 response")
+	}
+}
@@ -0,0 +1,115 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s-monitoring-app/internal/env"
+)
+
+// PagerDutyEventsURL is the PagerDuty Events API v2 endpoint
+const PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// pagerDutySummaryMax is the longest summary PagerDuty accepts
+const pagerDutySummaryMax = 1024
+
+// PagerDuty Events API v2 structures
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"` // trigger or resolve
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+	Client      string            `json:"client,omitempty"`
+	ClientURL   string            `json:"client_url,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"` // critical, error, warning or info
+	Timestamp     string            `json:"timestamp,omitempty"`
+	Component     string            `json:"component,omitempty"`
+	Group         string            `json:"group,omitempty"`
+	Class         string            `json:"class,omitempty"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+// pagerDutyNotifier triggers and resolves PagerDuty incidents
+type pagerDutyNotifier struct {
+	url  string
+	keys RoutingKeys
+}
+
+// NewPagerDuty returns a notifier sending alerts to the PagerDuty Events API
+// v2 at url (PagerDutyEventsURL when empty). Firing alerts trigger an incident
+// with the routing key of their severity and recoveries resolve it; the
+// application metric ID is the dedup key.
+func NewPagerDuty(url string, keys RoutingKeys) Notifier {
+	if url == "" {
+		url = PagerDutyEventsURL
+	}
+	return &pagerDutyNotifier{url: url, keys: keys}
+}
+
+func (n *pagerDutyNotifier) Kind() string {
+	return KindPagerDuty
+}
+
+func (n *pagerDutyNotifier) Accepts(alert Alert) bool {
+	return n.keys.accepts(alert)
+}
+
+func (n *pagerDutyNotifier) Notify(ctx context.Context, alert Alert) error {
+	keys, err := n.keys.keysFor(alert)
+	if err != nil {
+		return fmt.Errorf("pagerduty: %w", err)
+	}
+
+	errs := []error{}
+	for _, key := range keys {
+		if err := postJSON(ctx, n.url, n.event(key, alert), nil); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("pagerduty: %w", err)
+	}
+	return nil
+}
+
+// event builds the trigger or resolve event of an alert
+func (n *pagerDutyNotifier) event(key string, alert Alert) pagerDutyEvent {
+	event := pagerDutyEvent{
+		RoutingKey:  key,
+		EventAction: "trigger",
+		DedupKey:    alert.DedupKey(),
+		Client:      "k8s-monitoring-app",
+		ClientURL:   env.APP_URL,
+	}
+	if alert.Resolved() {
+		event.EventAction = "resolve"
+		return event
+	}
+
+	event.Payload = &pagerDutyPayload{
+		Summary:       incidentSummary(alert, pagerDutySummaryMax),
+		Source:        alert.Namespace + "/" + alert.Application,
+		Severity:      alert.Severity,
+		Component:     alert.Application,
+		Group:         alert.Project,
+		Class:         alert.Metric,
+		CustomDetails: incidentDetails(alert),
+	}
+	if alert.Message != "" {
+		event.Payload.CustomDetails["message"] = alert.Message
+	}
+	if SeverityRank(alert.Severity) < 0 {
+		event.Payload.Severity = SeverityCritical
+	}
+	if !alert.Time.IsZero() {
+		event.Payload.Timestamp = alert.Time.UTC().Format(time.RFC3339)
+	}
+	return event
+}
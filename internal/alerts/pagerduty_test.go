@@ -0,0 +1,225 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordedRequest is a request received by a test server
+type recordedRequest struct {
+	Path   string // Escaped
+	Query  string
+	Header http.Header
+	Body   []byte
+}
+
+// newRecorder returns a test server replying status to every request, and the
+// requests it received
+func newRecorder(t *testing.T, status int) (*httptest.Server, func() []recordedRequest) {
+	t.Helper()
+
+	var mu sync.Mutex
+	requests := []recordedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("read body: %v", err)
+		}
+		mu.Lock()
+		requests = append(requests, recordedRequest{Path: r.URL.EscapedPath(), Query: r.URL.RawQuery, Header: r.Header.Clone(), Body: body})
+		mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() []recordedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]recordedRequest{}, requests...)
+	}
+}
+
+// testAlert returns a firing alert of an application metric
+func testAlert() Alert {
+	started := time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	return Alert{
+		ID:                  "history-1",
+		ApplicationMetricID: "metric-1",
+		Status:              StatusFiring,
+		Severity:            SeverityCritical,
+		Title:               "Metric failure detected",
+		Project:             "shop",
+		Application:         "api",
+		Namespace:           "production",
+		Metric:              "HealthCheck",
+		Reason:              "connection refused",
+		StartedAt:           started,
+		Time:                started.Add(time.Minute),
+	}
+}
+
+// resolvedAlert returns the recovery of an alert
+func resolvedAlert(alert Alert) Alert {
+	alert.Status = StatusResolved
+	alert.Time = alert.StartedAt.Add(10 * time.Minute)
+	return alert
+}
+
+func TestPagerDutyTrigger(t *testing.T) {
+	server, requests := newRecorder(t, http.StatusAccepted)
+	notifier := NewPagerDuty(server.URL, RoutingKeys{Default: "default-key"})
+
+	if err := notifier.Notify(context.Background(), testAlert()); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	got := requests()
+	if len(got) != 1 {
+		t.Fatalf("got %d requests, want 1", len(got))
+	}
+	if ct := got[0].Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+
+	event := pagerDutyEvent{}
+	if err := json.Unmarshal(got[0].Body, &event); err != nil {
+		t.Fatalf("decode event: %v", err)
+	}
+	if event.RoutingKey != "default-key" {
+		t.Errorf("routing_key = %q, want default-key", event.RoutingKey)
+	}
+	if event.EventAction != "trigger" {
+		t.Errorf("event_action = %q, want trigger", event.EventAction)
+	}
+	if event.DedupKey != "metric-1" {
+		t.Errorf("dedup_key = %q, want metric-1", event.DedupKey)
+	}
+	if event.Payload == nil {
+		t.Fatal("trigger event has no payload")
+	}
+	if want := "Metric failure detected: shop/api HealthCheck - connection refused"; event.Payload.Summary != want {
+		t.Errorf("summary = %q, want %q", event.Payload.Summary, want)
+	}
+	if event.Payload.Source != "production/api" {
+		t.Errorf("source = %q, want production/api", event.Payload.Source)
+	}
+	if event.Payload.Severity != SeverityCritical {
+		t.Errorf("severity = %q, want critical", event.Payload.Severity)
+	}
+	if event.Payload.Timestamp != "2024-10-01T12:01:00Z" {
+		t.Errorf("timestamp = %q, want 2024-10-01T12:01:00Z", event.Payload.Timestamp)
+	}
+	if event.Payload.CustomDetails["started_at"] != "2024-10-01T12:00:00Z" {
+		t.Errorf("started_at detail = %q, want 2024-10-01T12:00:00Z", event.Payload.CustomDetails["started_at"])
+	}
+}
+
+func TestPagerDutyTriggerUsesSeverityKey(t *testing.T) {
+	server, requests := newRecorder(t, http.StatusAccepted)
+	notifier := NewPagerDuty(server.URL, RoutingKeys{
+		Default:    "default-key",
+		BySeverity: map[string]string{SeverityCritical: "critical-key"},
+	})
+
+	if err := notifier.Notify(context.Background(), testAlert()); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	got := requests()
+	if len(got) != 1 {
+		t.Fatalf("got %d requests, want 1", len(got))
+	}
+	event := pagerDutyEvent{}
+	if err := json.Unmarshal(got[0].Body, &event); err != nil {
+		t.Fatalf("decode event: %v", err)
+	}
+	if event.RoutingKey != "critical-key" {
+		t.Errorf("routing_key = %q, want critical-key", event.RoutingKey)
+	}
+}
+
+func TestPagerDutyResolve(t *testing.T) {
+	server, requests := newRecorder(t, http.StatusAccepted)
+	notifier := NewPagerDuty(server.URL, RoutingKeys{
+		Default:    "default-key",
+		BySeverity: map[string]string{SeverityCritical: "critical-key"},
+	})
+
+	if err := notifier.Notify(context.Background(), resolvedAlert(testAlert())); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	// The incident is resolved with every key, since the severity may have
+	// changed while the alert fired
+	got := requests()
+	if len(got) != 2 {
+		t.Fatalf("got %d requests, want 2", len(got))
+	}
+	keys := map[string]bool{}
+	for _, request := range got {
+		event := map[string]interface{}{}
+		if err := json.Unmarshal(request.Body, &event); err != nil {
+			t.Fatalf("decode event: %v", err)
+		}
+		if event["event_action"] != "resolve" {
+			t.Errorf("event_action = %v, want resolve", event["event_action"])
+		}
+		if event["dedup_key"] != "metric-1" {
+			t.Errorf("dedup_key = %v, want metric-1", event["dedup_key"])
+		}
+		if _, ok := event["payload"]; ok {
+			t.Error("resolve event has a payload")
+		}
+		keys[event["routing_key"].(string)] = true
+	}
+	if !keys["default-key"] || !keys["critical-key"] {
+		t.Errorf("resolved with keys %v, want default-key and critical-key", keys)
+	}
+}
+
+func TestPagerDutyTriggerAndResolveShareDedupKey(t *testing.T) {
+	server, requests := newRecorder(t, http.StatusAccepted)
+	notifier := NewPagerDuty(server.URL, RoutingKeys{Default: "default-key"})
+
+	alert := testAlert()
+	if err := notifier.Notify(context.Background(), alert); err != nil {
+		t.Fatalf("Notify trigger: %v", err)
+	}
+	// A later alert history entry of the same metric must resolve the same
+	// incident
+	recovery := resolvedAlert(alert)
+	recovery.ID = "history-2"
+	if err := notifier.Notify(context.Background(), recovery); err != nil {
+		t.Fatalf("Notify resolve: %v", err)
+	}
+
+	got := requests()
+	if len(got) != 2 {
+		t.Fatalf("got %d requests, want 2", len(got))
+	}
+	trigger, resolve := pagerDutyEvent{}, pagerDutyEvent{}
+	if err := json.Unmarshal(got[0].Body, &trigger); err != nil {
+		t.Fatalf("decode trigger: %v", err)
+	}
+	if err := json.Unmarshal(got[1].Body, &resolve); err != nil {
+		t.Fatalf("decode resolve: %v", err)
+	}
+	if trigger.DedupKey == "" || trigger.DedupKey != resolve.DedupKey {
+		t.Errorf("trigger dedup_key %q and resolve dedup_key %q differ", trigger.DedupKey, resolve.DedupKey)
+	}
+}
+
+func TestPagerDutyErrorStatus(t *testing.T) {
+	server, _ := newRecorder(t, http.StatusBadRequest)
+	notifier := NewPagerDuty(server.URL, RoutingKeys{Default: "default-key"})
+
+	if err := notifier.Notify(context.Background(), testAlert()); err == nil {
+		t.Fatal("Notify succeeded on a 400 response")
+	}
+}
@@ -7,6 +7,8 @@ import (
     "fmt"
     "net/http"
     "time"
+
+    "k8s-monitoring-app/internal/env"
 )
 
 // slackMessage is the payload for a Slack incoming webhook
@@ -39,8 +41,21 @@ type slackBlockText struct {
 }
 
 type slackBlock struct {
-    Type string          `json:"type"`
-    Text *slackBlockText `json:"text,omitempty"`
+    Type     string          `json:"type"`
+    BlockID  string          `json:"block_id,omitempty"`
+    Text     *slackBlockText `json:"text,omitempty"`
+    Elements []slackButton   `json:"elements,omitempty"`
+}
+
+// slackButton is an interactive button of an actions block. Buttons with a
+// URL open it; the others are sent to the interactions endpoint.
+type slackButton struct {
+    Type     string          `json:"type"`
+    Text     *slackBlockText `json:"text"`
+    ActionID string          `json:"action_id"`
+    Value    string          `json:"value,omitempty"`
+    URL      string          `json:"url,omitempty"`
+    Style    string          `json:"style,omitempty"`
 }
 
 // SendSlackMessage posts a simple text message to a Slack webhook URL.
@@ -78,11 +93,22 @@ func SendSlackMessage(ctx context.Context, webhookURL, text string) error {
 // SendSlackAlert posts a message using Slack attachments with a colored bar on the left.
 // The attachment will use a red color to highlight alert severity.
 func SendSlackAlert(ctx context.Context, webhookURL, title string, fields map[string]string, extraText string) error {
-    if webhookURL == "" {
-        return fmt.Errorf("missing webhook URL")
-    }
+    return sendSlackAttachment(ctx, webhookURL, "danger", title, fields, extraText)
+}
+
+// sendSlackAttachment posts an alert attachment with the given bar color
+func sendSlackAttachment(ctx context.Context, webhookURL, color, title string, fields map[string]string, extraText string) error {
+    return sendSlackAlertMessage(ctx, webhookURL, color, title, fields, extraText, nil)
+}
 
-    // Build a markdown text with clear section spacing
+// sendSlackAlertMessage posts an alert attachment with the given bar color,
+// followed by the given action buttons when there are any
+func sendSlackAlertMessage(ctx context.Context, webhookURL, color, title string, fields map[string]string, extraText string, actions []slackButton) error {
+    return sendSlackText(ctx, webhookURL, color, title, slackFieldsText(fields, extraText), actions)
+}
+
+// slackFieldsText builds a markdown text of the alert fields with clear section spacing
+func slackFieldsText(fields map[string]string, extraText string) string {
     project := fields["Project"]
     application := fields["Application"]
     namespace := fields["Namespace"]
@@ -98,6 +124,15 @@ func SendSlackAlert(ctx context.Context, webhookURL, title string, fields map[st
     if extraText != "" {
         text = fmt.Sprintf("%s\n\n%s", text, extraText)
     }
+    return text
+}
+
+// sendSlackText posts an alert attachment with the given bar color and
+// markdown text, followed by the given action buttons when there are any
+func sendSlackText(ctx context.Context, webhookURL, color, title, text string, actions []slackButton) error {
+    if webhookURL == "" {
+        return fmt.Errorf("missing webhook URL")
+    }
 
     payload := slackMessage{
         Blocks: []slackBlock{
@@ -109,13 +144,16 @@ func SendSlackAlert(ctx context.Context, webhookURL, title string, fields map[st
         },
         Attachments: []slackAttachment{
             {
-                Color:    "danger",
+                Color:    color,
                 Title:    title,
                 Text:     text,
                 MrkdwnIn: []string{"text"},
             },
         },
     }
+    if len(actions) > 0 {
+        payload.Blocks = append(payload.Blocks, slackBlock{Type: "actions", BlockID: "alert_actions", Elements: actions})
+    }
 
     body, err := json.Marshal(payload)
     if err != nil {
@@ -140,3 +178,69 @@ func SendSlackAlert(ctx context.Context, webhookURL, title string, fields map[st
     }
     return nil
 }
+
+// slackNotifier posts alerts to a Slack incoming webhook
+type slackNotifier struct {
+    webhookURL string
+}
+
+// NewSlack returns a notifier posting to a Slack incoming webhook
+func NewSlack(webhookURL string) Notifier {
+    return &slackNotifier{webhookURL: webhookURL}
+}
+
+func (n *slackNotifier) Kind() string {
+    return KindSlack
+}
+
+func (n *slackNotifier) Notify(ctx context.Context, alert Alert) error {
+    if alert.Message != "" {
+        color, actions := "danger", slackAlertActions(alert)
+        if alert.Resolved() {
+            color, actions = "good", nil
+        }
+        return sendSlackText(ctx, n.webhookURL, color, alert.Title, alert.Message, actions)
+    }
+
+    fields := map[string]string{}
+    for _, f := range alert.Fields() {
+        fields[f.Label] = f.Value
+    }
+    if alert.Resolved() {
+        return sendSlackAttachment(ctx, n.webhookURL, "good", alert.Title, fields, fmt.Sprintf("*Duration*: %s", fields["Duration"]))
+    }
+    return sendSlackAlertMessage(ctx, n.webhookURL, "danger", alert.Title, fields, "", slackAlertActions(alert))
+}
+
+// slackAlertActions returns the buttons of a firing alert: Acknowledge and
+// Silence 1h when Slack interactions are set up, and Open dashboard when the
+// app URL is known
+func slackAlertActions(alert Alert) []slackButton {
+    actions := []slackButton{}
+    if env.SLACK_SIGNING_SECRET != "" && alert.ID != "" {
+        actions = append(actions,
+            slackButton{
+                Type:     "button",
+                Text:     &slackBlockText{Type: "plain_text", Text: "Acknowledge"},
+                ActionID: SlackActionAcknowledge,
+                Value:    alert.ID,
+                Style:    "primary",
+            },
+            slackButton{
+                Type:     "button",
+                Text:     &slackBlockText{Type: "plain_text", Text: "Silence 1h"},
+                ActionID: SlackActionSilence,
+                Value:    alert.ID,
+            },
+        )
+    }
+    if env.APP_URL != "" {
+        actions = append(actions, slackButton{
+            Type:     "button",
+            Text:     &slackBlockText{Type: "plain_text", Text: "Open dashboard"},
+            ActionID: SlackActionOpenDashboard,
+            URL:      env.APP_URL + "/",
+        })
+    }
+    return actions
+}
@@ -0,0 +1,111 @@
+package alerts
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Action IDs of the buttons sent with firing Slack alerts
+const (
+	SlackActionAcknowledge   = "acknowledge"
+	SlackActionSilence       = "silence_1h"
+	SlackActionOpenDashboard = "open_dashboard"
+)
+
+// Headers Slack signs interaction requests with
+const (
+	SlackSignatureHeader = "X-Slack-Signature"
+	SlackTimestampHeader = "X-Slack-Request-Timestamp"
+)
+
+// slackMaxRequestAge rejects replayed interaction requests
+const slackMaxRequestAge = 5 * time.Minute
+
+// SlackInteraction is the part of a Slack block_actions payload the alert
+// buttons need
+type SlackInteraction struct {
+	Type string `json:"type"`
+	User struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+		Name     string `json:"name"`
+	} `json:"user"`
+	Actions []struct {
+		ActionID string `json:"action_id"`
+		Value    string `json:"value"`
+	} `json:"actions"`
+	ResponseURL string `json:"response_url"`
+}
+
+// UserName returns the Slack handle of whoever clicked the button
+func (i SlackInteraction) UserName() string {
+	if i.User.Username != "" {
+		return i.User.Username
+	}
+	if i.User.Name != "" {
+		return i.User.Name
+	}
+	return i.User.ID
+}
+
+// VerifySlackSignature checks that an interaction request was signed by Slack
+// with the app signing secret, and that it is recent
+func VerifySlackSignature(secret, timestamp, signature string, body []byte, now time.Time) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New("invalid request timestamp")
+	}
+	age := now.Sub(time.Unix(seconds, 0))
+	if age > slackMaxRequestAge || age < -slackMaxRequestAge {
+		return errors.New("request timestamp is too old")
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+// ParseSlackInteraction reads the payload form field of an interaction request
+func ParseSlackInteraction(body []byte) (SlackInteraction, error) {
+	var interaction SlackInteraction
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return interaction, fmt.Errorf("parse form: %w", err)
+	}
+	payload := form.Get("payload")
+	if payload == "" {
+		return interaction, errors.New("payload is empty")
+	}
+	if err := json.Unmarshal([]byte(payload), &interaction); err != nil {
+		return interaction, fmt.Errorf("parse payload: %w", err)
+	}
+	return interaction, nil
+}
+
+// RespondSlack posts a reply to the channel of an interaction, leaving the
+// alert message as it is
+func RespondSlack(ctx context.Context, responseURL, text string) error {
+	payload := map[string]interface{}{
+		"response_type":    "in_channel",
+		"replace_original": false,
+		"text":             text,
+	}
+	if err := postJSON(ctx, responseURL, payload, nil); err != nil {
+		return fmt.Errorf("slack response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+)
+
+// Microsoft Teams Adaptive Card structures
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string    `json:"contentType"`
+	Content     teamsCard `json:"content"`
+}
+
+type teamsCard struct {
+	Schema  string         `json:"$schema"`
+	Type    string         `json:"type"`
+	Version string         `json:"version"`
+	Body    []teamsElement `json:"body"`
+}
+
+type teamsElement struct {
+	Type   string      `json:"type"`
+	Text   string      `json:"text,omitempty"`
+	Weight string      `json:"weight,omitempty"`
+	Size   string      `json:"size,omitempty"`
+	Color  string      `json:"color,omitempty"`
+	Wrap   bool        `json:"wrap,omitempty"`
+	Facts  []teamsFact `json:"facts,omitempty"`
+}
+
+type teamsFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// teamsNotifier posts alerts to a Microsoft Teams incoming webhook
+type teamsNotifier struct {
+	webhookURL string
+}
+
+// NewTeams returns a notifier posting Adaptive Cards to a Microsoft Teams
+// incoming webhook (Workflows or Office 365 connector)
+func NewTeams(webhookURL string) Notifier {
+	return &teamsNotifier{webhookURL: webhookURL}
+}
+
+func (n *teamsNotifier) Kind() string {
+	return KindTeams
+}
+
+func (n *teamsNotifier) Notify(ctx context.Context, alert Alert) error {
+	facts := []teamsFact{}
+	for _, f := range alert.Fields() {
+		facts = append(facts, teamsFact{Title: f.Label, Value: f.Value})
+	}
+
+	color := "Attention"
+	if alert.Resolved() {
+		color = "Good"
+	}
+
+	details := teamsElement{Type: "FactSet", Facts: facts}
+	if alert.Message != "" {
+		details = teamsElement{Type: "TextBlock", Text: alert.Message, Wrap: true}
+	}
+
+	payload := teamsMessage{
+		Type: "message",
+		Attachments: []teamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content: teamsCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.4",
+				Body: []teamsElement{
+					{Type: "TextBlock", Text: "K8S Monitoring App Alert", Weight: "Bolder", Size: "Medium", Color: color},
+					{Type: "TextBlock", Text: alert.Title, Weight: "Bolder", Wrap: true},
+					details,
+				},
+			},
+		}},
+	}
+
+	if err := postJSON(ctx, n.webhookURL, payload, nil); err != nil {
+		return fmt.Errorf("teams: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package alerts
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// telegramAPI is the base URL of the Telegram Bot API
+const telegramAPI = "https://api.telegram.org"
+
+// telegramNotifier sends alerts through a Telegram bot
+type telegramNotifier struct {
+	botToken string
+	chatID   string
+}
+
+// NewTelegram returns a notifier sending messages from a bot to a chat
+func NewTelegram(botToken, chatID string) Notifier {
+	return &telegramNotifier{botToken: botToken, chatID: chatID}
+}
+
+func (n *telegramNotifier) Kind() string {
+	return KindTelegram
+}
+
+func (n *telegramNotifier) Notify(ctx context.Context, alert Alert) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s <b>%s</b>\n", statusEmoji(alert), html.EscapeString(alert.Title))
+	if alert.Message != "" {
+		fmt.Fprintf(&b, "\n%s", html.EscapeString(alert.Message))
+	} else {
+		for _, f := range alert.Fields() {
+			fmt.Fprintf(&b, "\n<b>%s</b>: %s", html.EscapeString(f.Label), html.EscapeString(f.Value))
+		}
+	}
+
+	payload := map[string]interface{}{
+		"chat_id":                  n.chatID,
+		"text":                     b.String(),
+		"parse_mode":               "HTML",
+		"disable_web_page_preview": true,
+	}
+
+	// The URL holds the bot token, so errors never include it
+	url := fmt.Sprintf("%s/bot%s/sendMessage", telegramAPI, n.botToken)
+	if err := postJSON(ctx, url, payload, nil); err != nil {
+		return fmt.Errorf("telegram: %s", strings.ReplaceAll(err.Error(), n.botToken, "***"))
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package alerts
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// SignatureHeader carries the HMAC-SHA256 of the webhook body, as "sha256=<hex>"
+const SignatureHeader = "X-Signature-256"
+
+// webhookNotifier posts alerts as JSON to any HTTP endpoint
+type webhookNotifier struct {
+	url    string
+	secret string
+}
+
+// NewWebhook returns a notifier posting the alert as JSON to url. When secret
+// is set, the body is signed with HMAC-SHA256 in the X-Signature-256 header so
+// the receiver can check it came from this app.
+func NewWebhook(url, secret string) Notifier {
+	return &webhookNotifier{url: url, secret: secret}
+}
+
+func (n *webhookNotifier) Kind() string {
+	return KindWebhook
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("marshal webhook payload: %w", err)
+	}
+
+	headers := map[string]string{}
+	if n.secret != "" {
+		headers[SignatureHeader] = "sha256=" + Sign(n.secret, body)
+	}
+
+	if err := postBody(ctx, n.url, body, headers); err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	return nil
+}
+
+// Sign returns the hex HMAC-SHA256 of body keyed with secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"k8s-monitoring-app/internal/core"
 	applicationModel "k8s-monitoring-app/pkg/application/model"
 
 	"github.com/rs/zerolog/log"
@@ -59,7 +60,7 @@ func (repo *repository) Get(ctx context.Context, id string, customFieldName ...s
 		sqlString = fmt.Sprintf("%s a.id = ?", sqlString)
 	}
 
-	err := repo.db.QueryRowContext(ctx, sqlString, id).Scan(
+	err := repo.db.QueryRowContext(ctx, core.Rebind(sqlString), id).Scan(
 		&application.ID, &application.ProjectID, &application.Name, &application.Description,
 		&application.Namespace, &application.CreatedAt, &application.UpdatedAt)
 
@@ -112,7 +113,7 @@ func (repo *repository) ListByProject(ctx context.Context, projectID string) ([]
 	WHERE project_id = ?
 	ORDER BY name`
 
-	rows, err := repo.db.QueryContext(ctx, sqlString, projectID)
+	rows, err := repo.db.QueryContext(ctx, core.Rebind(sqlString), projectID)
 	if err != nil {
 		return applications, err
 	}
@@ -144,7 +145,7 @@ func (repo *repository) Add(ctx context.Context, application *applicationModel.A
 		id, project_id, name, description, namespace, created_at, updated_at
 		) VALUES (?, ?, ?, ?, ?, ?, ?)`
 
-	_, err := repo.db.ExecContext(ctx, sqlString,
+	_, err := repo.db.ExecContext(ctx, core.Rebind(sqlString),
 		application.ID, application.ProjectID, application.Name, application.Description, 
 		application.Namespace, application.CreatedAt, application.UpdatedAt,
 	)
@@ -184,7 +185,7 @@ func (repo *repository) Update(ctx context.Context, application *applicationMode
 	sqlString = fmt.Sprintf("%s WHERE id = ?", sqlString[:len(sqlString)-2])
 	params = append(params, application.ID)
 
-	result, err := repo.db.ExecContext(ctx, sqlString, params...)
+	result, err := repo.db.ExecContext(ctx, core.Rebind(sqlString), params...)
 	if err != nil {
 		return err
 	}
@@ -204,7 +205,7 @@ func (repo *repository) Update(ctx context.Context, application *applicationMode
 func (repo *repository) Delete(ctx context.Context, id string) error {
 	sqlString := `DELETE FROM applications WHERE id = ?`
 
-	result, err := repo.db.ExecContext(ctx, sqlString, id)
+	result, err := repo.db.ExecContext(ctx, core.Rebind(sqlString), id)
 	if err != nil {
 		return err
 	}
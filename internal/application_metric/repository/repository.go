@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"time"
 
+	"k8s-monitoring-app/internal/core"
 	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
 
 	"github.com/rs/zerolog/log"
@@ -48,7 +49,8 @@ func (repo *repository) Get(ctx context.Context, id string, customFieldName ...s
 
 	sqlString := `
 	SELECT
-		am.id, am.application_id, am.type_id, am.configuration, am.created_at, am.updated_at
+		am.id, am.application_id, am.type_id, am.configuration,
+		am.interval_seconds, am.cron_expression, am.jitter_seconds, am.retention_days, am.fail_after, am.recover_after, am.failure_alerts, am.created_at, am.updated_at
 	FROM 
 		application_metrics am
 	WHERE`
@@ -59,13 +61,23 @@ func (repo *repository) Get(ctx context.Context, id string, customFieldName ...s
 		sqlString = fmt.Sprintf("%s am.id = ?", sqlString)
 	}
 
-	err := repo.db.QueryRowContext(ctx, sqlString, id).Scan(
+	schedule := applicationMetricModel.Schedule{}
+	var retentionDays int
+	var failAfter, recoverAfter int
+	var failureAlerts sql.NullBool
+	err := repo.db.QueryRowContext(ctx, core.Rebind(sqlString), id).Scan(
 		&applicationMetric.ID, &applicationMetric.ApplicationID, &applicationMetric.TypeID,
-		&applicationMetric.Configuration, &applicationMetric.CreatedAt, &applicationMetric.UpdatedAt)
+		&applicationMetric.Configuration, &schedule.IntervalSeconds, &schedule.CronExpression,
+		&schedule.JitterSeconds, &retentionDays, &failAfter, &recoverAfter, &failureAlerts, &applicationMetric.CreatedAt, &applicationMetric.UpdatedAt)
 
 	if err != nil {
 		return applicationMetric, err
 	}
+	applicationMetric.Schedule = &schedule
+	applicationMetric.RetentionDays = &retentionDays
+	applicationMetric.FailAfter = &failAfter
+	applicationMetric.RecoverAfter = &recoverAfter
+	applicationMetric.FailureAlerts = nullBoolPtr(failureAlerts)
 
 	return applicationMetric, nil
 }
@@ -75,7 +87,8 @@ func (repo *repository) List(ctx context.Context) ([]applicationMetricModel.Appl
 
 	sqlString := `
 	SELECT
-		id, application_id, type_id, configuration, created_at, updated_at
+		id, application_id, type_id, configuration,
+		interval_seconds, cron_expression, jitter_seconds, retention_days, fail_after, recover_after, failure_alerts, created_at, updated_at
 	FROM
 		application_metrics
 	ORDER BY created_at DESC`
@@ -88,12 +101,23 @@ func (repo *repository) List(ctx context.Context) ([]applicationMetricModel.Appl
 
 	for rows.Next() {
 		applicationMetric := applicationMetricModel.ApplicationMetric{}
+		schedule := applicationMetricModel.Schedule{}
+		var retentionDays int
+		var failAfter, recoverAfter int
+		var failureAlerts sql.NullBool
 		err := rows.Scan(
 			&applicationMetric.ID, &applicationMetric.ApplicationID, &applicationMetric.TypeID,
-			&applicationMetric.Configuration, &applicationMetric.CreatedAt, &applicationMetric.UpdatedAt)
+			&applicationMetric.Configuration, &schedule.IntervalSeconds, &schedule.CronExpression,
+			&schedule.JitterSeconds, &retentionDays, &failAfter, &recoverAfter, &failureAlerts, &applicationMetric.CreatedAt, &applicationMetric.UpdatedAt)
 		if err != nil {
 			return applicationMetrics, err
 		}
+		applicationMetric.Schedule = &schedule
+		applicationMetric.RetentionDays = &retentionDays
+		applicationMetric.FailAfter = &failAfter
+		applicationMetric.RecoverAfter = &recoverAfter
+		applicationMetric.FailureAlerts = nullBoolPtr(failureAlerts)
+	applicationMetric.RetentionDays = &retentionDays
 
 		applicationMetrics = append(applicationMetrics, applicationMetric)
 	}
@@ -106,13 +130,14 @@ func (repo *repository) ListByApplication(ctx context.Context, applicationID str
 
 	sqlString := `
 	SELECT
-		id, application_id, type_id, configuration, created_at, updated_at
+		id, application_id, type_id, configuration,
+		interval_seconds, cron_expression, jitter_seconds, retention_days, fail_after, recover_after, failure_alerts, created_at, updated_at
 	FROM
 		application_metrics
 	WHERE application_id = ?
 	ORDER BY created_at DESC`
 
-	rows, err := repo.db.QueryContext(ctx, sqlString, applicationID)
+	rows, err := repo.db.QueryContext(ctx, core.Rebind(sqlString), applicationID)
 	if err != nil {
 		return applicationMetrics, err
 	}
@@ -120,12 +145,23 @@ func (repo *repository) ListByApplication(ctx context.Context, applicationID str
 
 	for rows.Next() {
 		applicationMetric := applicationMetricModel.ApplicationMetric{}
+		schedule := applicationMetricModel.Schedule{}
+		var retentionDays int
+		var failAfter, recoverAfter int
+		var failureAlerts sql.NullBool
 		err := rows.Scan(
 			&applicationMetric.ID, &applicationMetric.ApplicationID, &applicationMetric.TypeID,
-			&applicationMetric.Configuration, &applicationMetric.CreatedAt, &applicationMetric.UpdatedAt)
+			&applicationMetric.Configuration, &schedule.IntervalSeconds, &schedule.CronExpression,
+			&schedule.JitterSeconds, &retentionDays, &failAfter, &recoverAfter, &failureAlerts, &applicationMetric.CreatedAt, &applicationMetric.UpdatedAt)
 		if err != nil {
 			return applicationMetrics, err
 		}
+		applicationMetric.Schedule = &schedule
+		applicationMetric.RetentionDays = &retentionDays
+		applicationMetric.FailAfter = &failAfter
+		applicationMetric.RecoverAfter = &recoverAfter
+		applicationMetric.FailureAlerts = nullBoolPtr(failureAlerts)
+	applicationMetric.RetentionDays = &retentionDays
 
 		applicationMetrics = append(applicationMetrics, applicationMetric)
 	}
@@ -139,13 +175,30 @@ func (repo *repository) Add(ctx context.Context, applicationMetric *applicationM
 	applicationMetric.CreatedAt = time.Now()
 	applicationMetric.UpdatedAt = time.Now()
 
+	if applicationMetric.Schedule == nil {
+		applicationMetric.Schedule = &applicationMetricModel.Schedule{}
+	}
+	if applicationMetric.RetentionDays == nil {
+		applicationMetric.RetentionDays = new(int)
+	}
+	if applicationMetric.FailAfter == nil {
+		applicationMetric.FailAfter = new(int)
+	}
+	if applicationMetric.RecoverAfter == nil {
+		applicationMetric.RecoverAfter = new(int)
+	}
+
 	sqlString := `INSERT INTO application_metrics(
-		id, application_id, type_id, configuration, created_at, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?)`
+		id, application_id, type_id, configuration,
+		interval_seconds, cron_expression, jitter_seconds, retention_days, fail_after, recover_after, failure_alerts, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
-	_, err := repo.db.ExecContext(ctx, sqlString,
+	_, err := repo.db.ExecContext(ctx, core.Rebind(sqlString),
 		applicationMetric.ID, applicationMetric.ApplicationID, applicationMetric.TypeID,
-		applicationMetric.Configuration, applicationMetric.CreatedAt, applicationMetric.UpdatedAt,
+		applicationMetric.Configuration, applicationMetric.Schedule.IntervalSeconds,
+		applicationMetric.Schedule.CronExpression, applicationMetric.Schedule.JitterSeconds,
+		*applicationMetric.RetentionDays, *applicationMetric.FailAfter, *applicationMetric.RecoverAfter,
+		nullBool(applicationMetric.FailureAlerts), applicationMetric.CreatedAt, applicationMetric.UpdatedAt,
 	)
 	if err != nil {
 		return err
@@ -170,18 +223,44 @@ func (repo *repository) Update(ctx context.Context, applicationMetric *applicati
 		params = append(params, applicationMetric.Configuration)
 		paramIndex++
 	}
+	if applicationMetric.Schedule != nil {
+		sqlString = fmt.Sprintf("%s interval_seconds = ?, cron_expression = ?, jitter_seconds = ?, ", sqlString)
+		params = append(params, applicationMetric.Schedule.IntervalSeconds,
+			applicationMetric.Schedule.CronExpression, applicationMetric.Schedule.JitterSeconds)
+		paramIndex += 3
+	}
+	if applicationMetric.RetentionDays != nil {
+		sqlString = fmt.Sprintf("%s retention_days = ?, ", sqlString)
+		params = append(params, *applicationMetric.RetentionDays)
+		paramIndex++
+	}
+	if applicationMetric.FailAfter != nil {
+		sqlString = fmt.Sprintf("%s fail_after = ?, ", sqlString)
+		params = append(params, *applicationMetric.FailAfter)
+		paramIndex++
+	}
+	if applicationMetric.RecoverAfter != nil {
+		sqlString = fmt.Sprintf("%s recover_after = ?, ", sqlString)
+		params = append(params, *applicationMetric.RecoverAfter)
+		paramIndex++
+	}
+	if applicationMetric.FailureAlerts != nil {
+		sqlString = fmt.Sprintf("%s failure_alerts = ?, ", sqlString)
+		params = append(params, *applicationMetric.FailureAlerts)
+		paramIndex++
+	}
 	if len(params) == 0 {
 		log.Warn().Msg("no fields to update")
 		return nil
 	}
 
 	// Always update the updated_at field
-	sqlString = fmt.Sprintf("%s updated_at = DATETIME('now'), ", sqlString)
+	sqlString = fmt.Sprintf("%s updated_at = CURRENT_TIMESTAMP, ", sqlString)
 
 	sqlString = fmt.Sprintf("%s WHERE id = ?", sqlString[:len(sqlString)-2])
 	params = append(params, applicationMetric.ID)
 
-	result, err := repo.db.ExecContext(ctx, sqlString, params...)
+	result, err := repo.db.ExecContext(ctx, core.Rebind(sqlString), params...)
 	if err != nil {
 		return err
 	}
@@ -206,23 +285,39 @@ func (repo *repository) Delete(ctx context.Context, id string) error {
     }
     defer tx.Rollback() // Will be no-op if tx.Commit() is called
 
-    // Delete any alert deduplication rows referencing this metric to satisfy FK constraints
-    deleteAlertsSQL := `DELETE FROM alerts_sent_daily WHERE application_metric_id = ?`
-    alertsResult, err := tx.ExecContext(ctx, deleteAlertsSQL, id)
-    if err != nil {
-        return fmt.Errorf("failed to delete related alerts_sent_daily rows: %w", err)
+    // Delete the alert state, rules and silences of this metric
+    deleteAlertStateSQL := `DELETE FROM alert_states WHERE application_metric_id = ?`
+    if _, err := tx.ExecContext(ctx, core.Rebind(deleteAlertStateSQL), id); err != nil {
+        return fmt.Errorf("failed to delete alert state: %w", err)
+    }
+    deleteAlertRulesSQL := `DELETE FROM alert_rules WHERE application_metric_id = ?`
+    if _, err := tx.ExecContext(ctx, core.Rebind(deleteAlertRulesSQL), id); err != nil {
+        return fmt.Errorf("failed to delete alert rules: %w", err)
     }
-    alertsDeleted, _ := alertsResult.RowsAffected()
-    if alertsDeleted > 0 {
-        log.Info().
-            Str("metric_id", id).
-            Int64("alerts_deleted", alertsDeleted).
-            Msg("deleted alerts_sent_daily rows before deleting metric")
+    deleteSilencesSQL := `DELETE FROM silences WHERE application_metric_id = ?`
+    if _, err := tx.ExecContext(ctx, core.Rebind(deleteSilencesSQL), id); err != nil {
+        return fmt.Errorf("failed to delete silences: %w", err)
+    }
+
+    // Delete the alert history of this metric
+    deleteAlertDeliveriesSQL := `DELETE FROM alert_deliveries WHERE alert_id IN (SELECT id FROM alert_history WHERE application_metric_id = ?)`
+    if _, err := tx.ExecContext(ctx, core.Rebind(deleteAlertDeliveriesSQL), id); err != nil {
+        return fmt.Errorf("failed to delete alert deliveries: %w", err)
+    }
+    deleteAlertHistorySQL := `DELETE FROM alert_history WHERE application_metric_id = ?`
+    if _, err := tx.ExecContext(ctx, core.Rebind(deleteAlertHistorySQL), id); err != nil {
+        return fmt.Errorf("failed to delete alert history: %w", err)
+    }
+
+    // Delete the rollups of this metric
+    deleteRollupsSQL := `DELETE FROM application_metric_rollups WHERE application_metric_id = ?`
+    if _, err := tx.ExecContext(ctx, core.Rebind(deleteRollupsSQL), id); err != nil {
+        return fmt.Errorf("failed to delete metric rollups: %w", err)
     }
 
     // First, delete all metric values associated with this metric
     deleteValuesSQL := `DELETE FROM application_metric_values WHERE application_metric_id = ?`
-    valuesResult, err := tx.ExecContext(ctx, deleteValuesSQL, id)
+    valuesResult, err := tx.ExecContext(ctx, core.Rebind(deleteValuesSQL), id)
     if err != nil {
         return fmt.Errorf("failed to delete metric values: %w", err)
     }
@@ -238,7 +333,7 @@ func (repo *repository) Delete(ctx context.Context, id string) error {
 
 	// Then, delete the metric itself
 	deleteMetricSQL := `DELETE FROM application_metrics WHERE id = ?`
-	result, err := tx.ExecContext(ctx, deleteMetricSQL, id)
+	result, err := tx.ExecContext(ctx, core.Rebind(deleteMetricSQL), id)
 	if err != nil {
 		return fmt.Errorf("failed to delete metric: %w", err)
 	}
@@ -259,3 +354,19 @@ func (repo *repository) Delete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+// nullBool stores an unset switch as NULL
+func nullBool(b *bool) sql.NullBool {
+	if b == nil {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: *b, Valid: true}
+}
+
+// nullBoolPtr reads a NULL switch as unset
+func nullBoolPtr(b sql.NullBool) *bool {
+	if !b.Valid {
+		return nil
+	}
+	return &b.Bool
+}
@@ -3,13 +3,14 @@ package application_metric
 import (
 	"database/sql"
 	"encoding/json"
-	"fmt"
 	"net/http"
 
+	"k8s-monitoring-app/internal/collector"
 	"k8s-monitoring-app/internal/core"
 	"k8s-monitoring-app/internal/security"
 	serverModel "k8s-monitoring-app/internal/server/model"
 	model "k8s-monitoring-app/pkg/application_metric/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
 
 	"github.com/rs/zerolog/log"
 )
@@ -110,7 +111,7 @@ func (s *service) Add(sc *core.HTTPServerContext) error {
 		return sc.String(http.StatusInternalServerError, "internal server error")
 	}
 
-	if metricType.Name != "PvcUsage" {
+	if !allowsMultiple(metricType.Name) {
 		for _, existing := range existingMetrics {
 			if existing.TypeID == applicationMetric.TypeID {
 				log.Warn().
@@ -142,18 +143,55 @@ func (s *service) Add(sc *core.HTTPServerContext) error {
 		})
 	}
 
-	// Additional validation for connection-type metrics to avoid silent misconfigurations
-	if err := validateConfigByType(metricType.Name, cfg); err != nil {
+	// Additional validation by the metric type collector to avoid silent misconfigurations
+	if err := collector.Validate(metricType.Name, cfg); err != nil {
 		log.Warn().Err(err).
 			Str("application_id", applicationMetric.ApplicationID).
 			Str("metric_type", metricType.Name).
-			Msg("invalid connection configuration")
+			Msg("invalid metric configuration")
 		return sc.JSON(http.StatusBadRequest, map[string]interface{}{
 			"error":   "invalid configuration",
 			"message": err.Error(),
 		})
 	}
 
+	if applicationMetric.Schedule != nil {
+		if err := applicationMetric.Schedule.Validate(); err != nil {
+			log.Warn().Err(err).
+				Str("application_id", applicationMetric.ApplicationID).
+				Str("metric_type", metricType.Name).
+				Msg("invalid metric schedule")
+			return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+				"error":   "invalid schedule",
+				"message": err.Error(),
+			})
+		}
+	}
+
+	if applicationMetric.RetentionDays != nil {
+		if err := applicationMetricValueModel.ValidateRetentionDays(*applicationMetric.RetentionDays); err != nil {
+			log.Warn().Err(err).
+				Str("application_id", applicationMetric.ApplicationID).
+				Str("metric_type", metricType.Name).
+				Msg("invalid metric retention")
+			return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+				"error":   "invalid retention",
+				"message": err.Error(),
+			})
+		}
+	}
+
+	if err := applicationMetric.ValidateThresholds(); err != nil {
+		log.Warn().Err(err).
+			Str("application_id", applicationMetric.ApplicationID).
+			Str("metric_type", metricType.Name).
+			Msg("invalid metric alert thresholds")
+		return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid thresholds",
+			"message": err.Error(),
+		})
+	}
+
 	if err := serverModel.ServerRepos.ApplicationMetric.Add(ctx, &applicationMetric); err != nil {
 		log.Error().Msg("error add application metric")
 		return sc.String(http.StatusInternalServerError, "internal server error")
@@ -191,7 +229,7 @@ func (s *service) Update(sc *core.HTTPServerContext) error {
 		}
 
 		// If the type is being changed, check if another metric of the new type already exists
-		if applicationMetric.TypeID != existingMetric.TypeID && metricType.Name != "PvcUsage" {
+		if applicationMetric.TypeID != existingMetric.TypeID && !allowsMultiple(metricType.Name) {
 			existingMetrics, err3 := serverModel.ServerRepos.ApplicationMetric.ListByApplication(ctx, existingMetric.ApplicationID)
 			if err3 != nil {
 				log.Error().Msgf("error listing application metrics: %s", err3.Error())
@@ -248,13 +286,13 @@ func (s *service) Update(sc *core.HTTPServerContext) error {
 			})
 		}
 
-		// Additional validation for connection-type metrics
-		if err := validateConfigByType(metricTypeForValidation.Name, cfg); err != nil {
+		// Additional validation by the metric type collector
+		if err := collector.Validate(metricTypeForValidation.Name, cfg); err != nil {
 			log.Warn().Err(err).
 				Str("application_id", existingMetric.ApplicationID).
 				Str("metric_type", metricTypeForValidation.Name).
 				Str("application_metric_id", id).
-				Msg("invalid connection configuration on update")
+				Msg("invalid metric configuration on update")
 			return sc.JSON(http.StatusBadRequest, map[string]interface{}{
 				"error":   "invalid configuration",
 				"message": err.Error(),
@@ -262,6 +300,43 @@ func (s *service) Update(sc *core.HTTPServerContext) error {
 		}
 	}
 
+	if applicationMetric.Schedule != nil {
+		if err := applicationMetric.Schedule.Validate(); err != nil {
+			log.Warn().Err(err).
+				Str("application_id", existingMetric.ApplicationID).
+				Str("application_metric_id", id).
+				Msg("invalid metric schedule on update")
+			return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+				"error":   "invalid schedule",
+				"message": err.Error(),
+			})
+		}
+	}
+
+	if applicationMetric.RetentionDays != nil {
+		if err := applicationMetricValueModel.ValidateRetentionDays(*applicationMetric.RetentionDays); err != nil {
+			log.Warn().Err(err).
+				Str("application_id", existingMetric.ApplicationID).
+				Str("application_metric_id", id).
+				Msg("invalid metric retention on update")
+			return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+				"error":   "invalid retention",
+				"message": err.Error(),
+			})
+		}
+	}
+
+	if err := applicationMetric.ValidateThresholds(); err != nil {
+		log.Warn().Err(err).
+			Str("application_id", existingMetric.ApplicationID).
+			Str("application_metric_id", id).
+			Msg("invalid metric alert thresholds on update")
+		return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid thresholds",
+			"message": err.Error(),
+		})
+	}
+
 	if err := serverModel.ServerRepos.ApplicationMetric.Update(ctx, &applicationMetric); err != nil {
 		log.Error().Msg("error updating application metric")
 		return sc.String(http.StatusInternalServerError, "Internal Server Error")
@@ -293,91 +368,8 @@ func (s *service) Delete(sc *core.HTTPServerContext) error {
 	return sc.JSON(http.StatusOK, map[string]bool{"success": true})
 }
 
-// validateConfigByType performs required-field checks for connection-type metrics.
-// It prevents silent zero-values (e.g., port=0, timeout=0) from reaching collectors.
-func validateConfigByType(metricTypeName string, cfg model.Configuration) error {
-	switch metricTypeName {
-	case "PostgreSQLConnection":
-		if cfg.ConnectionHost == "" {
-			return fmt.Errorf("connection_host is required for %s", metricTypeName)
-		}
-		if cfg.ConnectionPort <= 0 {
-			return fmt.Errorf("connection_port must be a positive integer for %s", metricTypeName)
-		}
-		if cfg.ConnectionUsername == "" {
-			return fmt.Errorf("connection_username is required for %s", metricTypeName)
-		}
-		if cfg.ConnectionDatabase == "" {
-			return fmt.Errorf("connection_database is required for %s", metricTypeName)
-		}
-		if cfg.ConnectionTimeout <= 0 {
-			return fmt.Errorf("connection_timeout must be a positive integer for %s", metricTypeName)
-		}
-	case "MySQLConnection":
-		if cfg.ConnectionHost == "" {
-			return fmt.Errorf("connection_host is required for %s", metricTypeName)
-		}
-		if cfg.ConnectionPort <= 0 {
-			return fmt.Errorf("connection_port must be a positive integer for %s", metricTypeName)
-		}
-		if cfg.ConnectionUsername == "" {
-			return fmt.Errorf("connection_username is required for %s", metricTypeName)
-		}
-		if cfg.ConnectionDatabase == "" {
-			return fmt.Errorf("connection_database is required for %s", metricTypeName)
-		}
-		if cfg.ConnectionTimeout <= 0 {
-			return fmt.Errorf("connection_timeout must be a positive integer for %s", metricTypeName)
-		}
-	case "MongoDBConnection":
-		if cfg.ConnectionHost == "" {
-			return fmt.Errorf("connection_host is required for %s", metricTypeName)
-		}
-		if cfg.ConnectionPort <= 0 {
-			return fmt.Errorf("connection_port must be a positive integer for %s", metricTypeName)
-		}
-		if cfg.ConnectionUsername == "" {
-			return fmt.Errorf("connection_username is required for %s", metricTypeName)
-		}
-		if cfg.ConnectionPassword == "" {
-			return fmt.Errorf("connection_password is required for %s", metricTypeName)
-		}
-		if cfg.ConnectionDatabase == "" {
-			return fmt.Errorf("connection_database is required for %s", metricTypeName)
-		}
-		if cfg.ConnectionTimeout <= 0 {
-			return fmt.Errorf("connection_timeout must be a positive integer for %s", metricTypeName)
-		}
-	case "RedisConnection":
-		if cfg.ConnectionHost == "" {
-			return fmt.Errorf("connection_host is required for %s", metricTypeName)
-		}
-		if cfg.ConnectionPort <= 0 {
-			return fmt.Errorf("connection_port must be a positive integer for %s", metricTypeName)
-		}
-		if cfg.ConnectionTimeout <= 0 {
-			return fmt.Errorf("connection_timeout must be a positive integer for %s", metricTypeName)
-		}
-	case "KongConnection":
-		// Either explicit admin URL or host+port
-		if cfg.KongAdminURL == "" {
-			if cfg.ConnectionHost == "" || cfg.ConnectionPort <= 0 {
-				return fmt.Errorf("kong_admin_url or connection_host+connection_port are required for %s", metricTypeName)
-			}
-		}
-		if cfg.ConnectionTimeout <= 0 {
-			return fmt.Errorf("connection_timeout must be a positive integer for %s", metricTypeName)
-		}
-	default:
-		// Non-connection metric types: no additional checks here
-		return nil
-	}
-	return nil
-}
-
-// ValidateConfigByType is an exported wrapper to allow other internal packages
-// (e.g., web handlers) to validate configuration consistently with service logic.
-// It delegates to the internal validateConfigByType implementation.
-func ValidateConfigByType(metricTypeName string, cfg model.Configuration) error {
-	return validateConfigByType(metricTypeName, cfg)
+// allowsMultiple reports whether an application may hold several metrics of the given type
+func allowsMultiple(metricTypeName string) bool {
+	c, ok := collector.Get(metricTypeName)
+	return ok && c.AllowMultiple()
 }
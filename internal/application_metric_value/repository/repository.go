@@ -3,14 +3,29 @@ package application_metric_value
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
+	"k8s-monitoring-app/internal/core"
 	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
 )
 
 type Repository interface {
 	Get(ctx context.Context, id string) (applicationMetricValueModel.ApplicationMetricValue, error)
 	ListByApplicationMetric(ctx context.Context, applicationMetricID string, limit int) ([]applicationMetricValueModel.ApplicationMetricValue, error)
+	ListLatest(ctx context.Context) ([]applicationMetricValueModel.ApplicationMetricValue, error)
+	ListRange(ctx context.Context, applicationMetricID string, from, to time.Time) ([]applicationMetricValueModel.ApplicationMetricValue, error)
+	Series(ctx context.Context, query applicationMetricValueModel.SeriesQuery) ([]applicationMetricValueModel.SeriesPoint, error)
+	AddRollup(ctx context.Context, rollup *applicationMetricValueModel.Rollup) error
+	ListRollups(ctx context.Context, applicationMetricID, resolution string, from, to time.Time) ([]applicationMetricValueModel.Rollup, error)
+	LastRollup(ctx context.Context, applicationMetricID, resolution string) (time.Time, error)
+	FirstRollup(ctx context.Context, applicationMetricID, resolution string) (time.Time, error)
+	FirstValue(ctx context.Context, applicationMetricID string) (time.Time, error)
+	RollupSeries(ctx context.Context, query applicationMetricValueModel.SeriesQuery, resolution string) ([]applicationMetricValueModel.SeriesPoint, error)
+	DeleteRollupsBefore(ctx context.Context, resolution string, cutoff time.Time) (int64, error)
+	DeleteBefore(ctx context.Context, applicationMetricID string, cutoff time.Time) (int64, error)
 	Add(ctx context.Context, applicationMetricValue *applicationMetricValueModel.ApplicationMetricValue) error
 	GetDB() *sql.DB
 }
@@ -39,7 +54,7 @@ func (repo *repository) Get(ctx context.Context, id string) (applicationMetricVa
 		application_metric_values amv
     WHERE amv.id = ?`
 
-	err := repo.db.QueryRowContext(ctx, sqlString, id).Scan(
+	err := repo.db.QueryRowContext(ctx, core.Rebind(sqlString), id).Scan(
 		&applicationMetricValue.ID, &applicationMetricValue.ApplicationMetricID,
 		&applicationMetricValue.Value, &applicationMetricValue.CreatedAt, &applicationMetricValue.UpdatedAt)
 
@@ -65,7 +80,7 @@ func (repo *repository) ListByApplicationMetric(ctx context.Context, application
 		sqlString = fmt.Sprintf("%s LIMIT %d", sqlString, limit)
 	}
 
-	rows, err := repo.db.QueryContext(ctx, sqlString, applicationMetricID)
+	rows, err := repo.db.QueryContext(ctx, core.Rebind(sqlString), applicationMetricID)
 	if err != nil {
 		return applicationMetricValues, err
 	}
@@ -86,13 +101,305 @@ func (repo *repository) ListByApplicationMetric(ctx context.Context, application
 	return applicationMetricValues, nil
 }
 
+// ListLatest returns the most recent value of every application metric
+func (repo *repository) ListLatest(ctx context.Context) ([]applicationMetricValueModel.ApplicationMetricValue, error) {
+	applicationMetricValues := []applicationMetricValueModel.ApplicationMetricValue{}
+
+	sqlString := `
+	SELECT
+		amv.id, amv.application_metric_id, amv.value, amv.created_at, amv.updated_at
+	FROM
+		application_metric_values amv
+	JOIN (
+		SELECT application_metric_id, MAX(created_at) AS created_at
+		FROM application_metric_values
+		GROUP BY application_metric_id
+	) latest ON latest.application_metric_id = amv.application_metric_id AND latest.created_at = amv.created_at`
+
+	rows, err := repo.db.QueryContext(ctx, sqlString)
+	if err != nil {
+		return applicationMetricValues, err
+	}
+	defer rows.Close()
+
+	seen := map[string]struct{}{}
+	for rows.Next() {
+		applicationMetricValue := applicationMetricValueModel.ApplicationMetricValue{}
+		err := rows.Scan(
+			&applicationMetricValue.ID, &applicationMetricValue.ApplicationMetricID,
+			&applicationMetricValue.Value, &applicationMetricValue.CreatedAt, &applicationMetricValue.UpdatedAt)
+		if err != nil {
+			return applicationMetricValues, err
+		}
+
+		// Values stored within the same timestamp tie on MAX(created_at); keep one
+		if _, ok := seen[applicationMetricValue.ApplicationMetricID]; ok {
+			continue
+		}
+		seen[applicationMetricValue.ApplicationMetricID] = struct{}{}
+
+		applicationMetricValues = append(applicationMetricValues, applicationMetricValue)
+	}
+
+	return applicationMetricValues, rows.Err()
+}
+
+// ListRange returns the values stored in [from, to), oldest first
+func (repo *repository) ListRange(ctx context.Context, applicationMetricID string, from, to time.Time) ([]applicationMetricValueModel.ApplicationMetricValue, error) {
+	applicationMetricValues := []applicationMetricValueModel.ApplicationMetricValue{}
+
+	sqlString := `
+	SELECT
+		id, application_metric_id, value, created_at, updated_at
+	FROM
+		application_metric_values
+	WHERE application_metric_id = ? AND created_at >= ? AND created_at < ?
+	ORDER BY created_at`
+
+	rows, err := repo.db.QueryContext(ctx, core.Rebind(sqlString), applicationMetricID, from.UTC(), to.UTC())
+	if err != nil {
+		return applicationMetricValues, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		applicationMetricValue := applicationMetricValueModel.ApplicationMetricValue{}
+		err := rows.Scan(
+			&applicationMetricValue.ID, &applicationMetricValue.ApplicationMetricID,
+			&applicationMetricValue.Value, &applicationMetricValue.CreatedAt, &applicationMetricValue.UpdatedAt)
+		if err != nil {
+			return applicationMetricValues, err
+		}
+
+		applicationMetricValues = append(applicationMetricValues, applicationMetricValue)
+	}
+
+	return applicationMetricValues, rows.Err()
+}
+
+// Series reads a numeric field of the values stored in the query range and
+// aggregates it into buckets of query.Step, aligned to multiples of the step.
+// Values that omit the field count as zero, like the MetricValue they decode to.
+func (repo *repository) Series(ctx context.Context, query applicationMetricValueModel.SeriesQuery) ([]applicationMetricValueModel.SeriesPoint, error) {
+	if !applicationMetricValueModel.IsSeriesField(query.Field) {
+		return nil, fmt.Errorf("unknown series field %q", query.Field)
+	}
+	if query.Step <= 0 {
+		return nil, fmt.Errorf("invalid series step %s", query.Step)
+	}
+
+	sqlString := fmt.Sprintf(`
+	SELECT
+		created_at, COALESCE(%s, 0)
+	FROM
+		application_metric_values
+	WHERE application_metric_id = ? AND created_at >= ? AND created_at < ?
+	ORDER BY created_at`, core.JSONNumber("value", query.Field))
+
+	rows, err := repo.db.QueryContext(ctx, core.Rebind(sqlString),
+		query.ApplicationMetricID, query.From.UTC(), query.To.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var samples []applicationMetricValueModel.Sample
+	for rows.Next() {
+		var sample applicationMetricValueModel.Sample
+		if err := rows.Scan(&sample.Time, &sample.Value); err != nil {
+			return nil, err
+		}
+		samples = append(samples, sample)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return applicationMetricValueModel.BucketSamples(samples, query.Step, query.Aggregation), nil
+}
+
+// AddRollup stores a rollup, replacing the one of the same bucket
+func (repo *repository) AddRollup(ctx context.Context, rollup *applicationMetricValueModel.Rollup) error {
+	fieldsJSON, err := json.Marshal(rollup.Fields)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rollup fields: %w", err)
+	}
+
+	sqlString := `INSERT INTO application_metric_rollups(
+		application_metric_id, resolution, bucket_start, samples, up_samples, fields
+		) VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(application_metric_id, resolution, bucket_start)
+		DO UPDATE SET samples = excluded.samples, up_samples = excluded.up_samples, fields = excluded.fields`
+
+	_, err = repo.db.ExecContext(ctx, core.Rebind(sqlString),
+		rollup.ApplicationMetricID, rollup.Resolution, rollup.BucketStart.UTC(),
+		rollup.Samples, rollup.UpSamples, string(fieldsJSON),
+	)
+	return err
+}
+
+// ListRollups returns the rollups of a resolution with buckets starting in [from, to), oldest first
+func (repo *repository) ListRollups(ctx context.Context, applicationMetricID, resolution string, from, to time.Time) ([]applicationMetricValueModel.Rollup, error) {
+	rollups := []applicationMetricValueModel.Rollup{}
+
+	sqlString := `
+	SELECT
+		application_metric_id, resolution, bucket_start, samples, up_samples, fields
+	FROM
+		application_metric_rollups
+	WHERE application_metric_id = ? AND resolution = ? AND bucket_start >= ? AND bucket_start < ?
+	ORDER BY bucket_start`
+
+	rows, err := repo.db.QueryContext(ctx, core.Rebind(sqlString), applicationMetricID, resolution, from.UTC(), to.UTC())
+	if err != nil {
+		return rollups, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		rollup := applicationMetricValueModel.Rollup{}
+		var fieldsJSON []byte
+		err := rows.Scan(
+			&rollup.ApplicationMetricID, &rollup.Resolution, &rollup.BucketStart,
+			&rollup.Samples, &rollup.UpSamples, &fieldsJSON)
+		if err != nil {
+			return rollups, err
+		}
+		if err := json.Unmarshal(fieldsJSON, &rollup.Fields); err != nil {
+			return rollups, fmt.Errorf("failed to unmarshal rollup fields: %w", err)
+		}
+		rollup.BucketStart = rollup.BucketStart.UTC()
+
+		rollups = append(rollups, rollup)
+	}
+
+	return rollups, rows.Err()
+}
+
+// LastRollup returns the start of the latest bucket rolled up at a
+// resolution, or the zero time when there is none
+func (repo *repository) LastRollup(ctx context.Context, applicationMetricID, resolution string) (time.Time, error) {
+	var bucketStart time.Time
+
+	sqlString := `
+	SELECT
+		bucket_start
+	FROM
+		application_metric_rollups
+	WHERE application_metric_id = ? AND resolution = ?
+	ORDER BY bucket_start DESC
+	LIMIT 1`
+
+	err := repo.db.QueryRowContext(ctx, core.Rebind(sqlString), applicationMetricID, resolution).Scan(&bucketStart)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return bucketStart.UTC(), nil
+}
+
+// FirstRollup returns the start of the oldest bucket stored at a resolution,
+// or the zero time when there is none
+func (repo *repository) FirstRollup(ctx context.Context, applicationMetricID, resolution string) (time.Time, error) {
+	var bucketStart time.Time
+
+	sqlString := `
+	SELECT
+		bucket_start
+	FROM
+		application_metric_rollups
+	WHERE application_metric_id = ? AND resolution = ?
+	ORDER BY bucket_start ASC
+	LIMIT 1`
+
+	err := repo.db.QueryRowContext(ctx, core.Rebind(sqlString), applicationMetricID, resolution).Scan(&bucketStart)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return bucketStart.UTC(), nil
+}
+
+// FirstValue returns when the oldest stored value of an application metric
+// was collected, or the zero time when there is none
+func (repo *repository) FirstValue(ctx context.Context, applicationMetricID string) (time.Time, error) {
+	var createdAt time.Time
+
+	sqlString := `
+	SELECT
+		created_at
+	FROM
+		application_metric_values
+	WHERE application_metric_id = ?
+	ORDER BY created_at ASC
+	LIMIT 1`
+
+	err := repo.db.QueryRowContext(ctx, core.Rebind(sqlString), applicationMetricID).Scan(&createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return createdAt.UTC(), nil
+}
+
+// RollupSeries aggregates the rollups of a resolution in the query range into
+// buckets of query.Step
+func (repo *repository) RollupSeries(ctx context.Context, query applicationMetricValueModel.SeriesQuery, resolution string) ([]applicationMetricValueModel.SeriesPoint, error) {
+	if query.Field != applicationMetricValueModel.SeriesFieldUp && !applicationMetricValueModel.IsSeriesField(query.Field) {
+		return nil, fmt.Errorf("unknown series field %q", query.Field)
+	}
+	if query.Step <= 0 {
+		return nil, fmt.Errorf("invalid series step %s", query.Step)
+	}
+
+	rollups, err := repo.ListRollups(ctx, query.ApplicationMetricID, resolution, query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return applicationMetricValueModel.RollupPoints(rollups, query.Field, query.Step, query.Aggregation), nil
+}
+
+// DeleteRollupsBefore removes the rollups of a resolution with buckets starting before cutoff
+func (repo *repository) DeleteRollupsBefore(ctx context.Context, resolution string, cutoff time.Time) (int64, error) {
+	sqlString := `DELETE FROM application_metric_rollups WHERE resolution = ? AND bucket_start < ?`
+
+	result, err := repo.db.ExecContext(ctx, core.Rebind(sqlString), resolution, cutoff.UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// DeleteBefore removes the values of an application metric stored before cutoff
+func (repo *repository) DeleteBefore(ctx context.Context, applicationMetricID string, cutoff time.Time) (int64, error) {
+	sqlString := `DELETE FROM application_metric_values WHERE application_metric_id = ? AND created_at < ?`
+
+	result, err := repo.db.ExecContext(ctx, core.Rebind(sqlString), applicationMetricID, cutoff.UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
 func (repo *repository) Add(ctx context.Context, applicationMetricValue *applicationMetricValueModel.ApplicationMetricValue) error {
 	sqlString := `INSERT INTO application_metric_values(
 		application_metric_id, value
 		) VALUES ($1, $2)
 		RETURNING id, created_at, updated_at`
 
-	err := repo.db.QueryRowContext(ctx, sqlString,
+	err := repo.db.QueryRowContext(ctx, core.Rebind(sqlString),
 		applicationMetricValue.ApplicationMetricID, applicationMetricValue.Value,
 	).Scan(&applicationMetricValue.ID, &applicationMetricValue.CreatedAt, &applicationMetricValue.UpdatedAt)
 	if err != nil {
@@ -0,0 +1,300 @@
+package application_metric_value
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s-monitoring-app/internal/collector"
+	"k8s-monitoring-app/internal/core"
+	"k8s-monitoring-app/internal/prometheus"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+	model "k8s-monitoring-app/pkg/application_metric_value/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	defaultSeriesRange  = 24 * time.Hour
+	defaultSeriesPoints = 200   // Buckets returned when no step is given
+	maxSeriesPoints     = 10000 // Buckets a single request may return
+)
+
+// Series returns a numeric field of the values of an application metric as a
+// bucketed time series
+func (s *service) Series(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	id := sc.Param("id")
+	if len(id) == 0 {
+		log.Error().Err(errors.New("id is empty")).Msg("error getting metric series")
+		return sc.String(http.StatusBadRequest, "invalid request")
+	}
+
+	appMetric, err := serverModel.ServerRepos.ApplicationMetric.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sc.String(http.StatusNotFound, "application metric not found")
+		}
+		log.Error().Err(err).Str("application_metric_id", id).Msg("error getting application metric")
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	}
+
+	now := time.Now()
+	query, err := parseSeriesQuery(sc, now)
+	if err != nil {
+		return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid request",
+			"message": err.Error(),
+		})
+	}
+	query.ApplicationMetricID = id
+	resolution := seriesResolution(&query, now, metricRawRetention(ctx, appMetric))
+
+	// The up series needs the collector to tell healthy values from failures
+	var c collector.Collector
+	if metricType, err := serverModel.ServerRepos.MetricType.Get(ctx, appMetric.TypeID); err == nil {
+		c, _ = collector.Get(metricType.Name)
+	}
+
+	points, err := seriesPoints(ctx, query, resolution, c)
+	if err != nil {
+		log.Error().Err(err).Str("application_metric_id", id).Msg("error getting metric series")
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	}
+
+	return sc.JSON(http.StatusOK, model.Series{
+		ApplicationMetricID: id,
+		Field:               query.Field,
+		Aggregation:         query.Aggregation,
+		From:                query.From,
+		To:                  query.To,
+		StepSeconds:         int64(query.Step.Seconds()),
+		Resolution:          resolution,
+		Points:              points,
+	})
+}
+
+// metricRawRetention returns how long the raw values of a metric are kept,
+// following the metric and project retention overrides
+func metricRawRetention(ctx context.Context, appMetric applicationMetricModel.ApplicationMetric) time.Duration {
+	metricDays, projectDays := 0, 0
+	if appMetric.RetentionDays != nil {
+		metricDays = *appMetric.RetentionDays
+	}
+	if application, err := serverModel.ServerRepos.Application.Get(ctx, appMetric.ApplicationID); err == nil {
+		if project, err := serverModel.ServerRepos.Project.Get(ctx, application.ProjectID); err == nil && project.RetentionDays != nil {
+			projectDays = *project.RetentionDays
+		}
+	}
+	return model.RawRetention(metricDays, projectDays)
+}
+
+// seriesResolution picks the data a series is read from. Steps in whole days
+// or hours read the daily or hourly rollups, other steps the raw values. When
+// the range starts before the chosen data is retained, the next coarser one is
+// used and the step is rounded up to it.
+func seriesResolution(query *model.SeriesQuery, now time.Time, rawRetention time.Duration) string {
+	const day = 24 * time.Hour
+
+	resolution := model.ResolutionRaw
+	switch {
+	case query.Step%day == 0:
+		resolution = model.ResolutionDay
+	case query.Step%time.Hour == 0:
+		resolution = model.ResolutionHour
+	}
+
+	if resolution == model.ResolutionRaw && query.From.Before(now.Add(-rawRetention)) {
+		resolution = model.ResolutionHour
+		query.Step = roundUp(query.Step, time.Hour)
+	}
+	if resolution == model.ResolutionHour && query.From.Before(now.Add(-model.Retention(model.ResolutionHour))) {
+		resolution = model.ResolutionDay
+		query.Step = roundUp(query.Step, day)
+	}
+
+	return resolution
+}
+
+func roundUp(d, unit time.Duration) time.Duration {
+	return (d + unit - 1) / unit * unit
+}
+
+// seriesPoints reads the series at the given resolution. Rollups only exist
+// for completed hours and days, so the buckets after the latest rollup are
+// read from the raw values.
+func seriesPoints(ctx context.Context, query model.SeriesQuery, resolution string, c collector.Collector) ([]model.SeriesPoint, error) {
+	if resolution == model.ResolutionRaw {
+		return rawSeriesPoints(ctx, query, c)
+	}
+
+	size := time.Hour
+	if resolution == model.ResolutionDay {
+		size = 24 * time.Hour
+	}
+
+	last, err := serverModel.ServerRepos.ApplicationMetricValue.LastRollup(ctx, query.ApplicationMetricID, resolution)
+	if err != nil {
+		return nil, err
+	}
+	cut := query.From
+	if !last.IsZero() {
+		cut = last.Add(size).Truncate(query.Step)
+	}
+	if cut.Before(query.From) {
+		cut = query.From
+	}
+	if cut.After(query.To) {
+		cut = query.To
+	}
+
+	points := []model.SeriesPoint{}
+	if rollupQuery := query; cut.After(query.From) {
+		rollupQuery.To = cut
+		points, err = serverModel.ServerRepos.ApplicationMetricValue.RollupSeries(ctx, rollupQuery, resolution)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if rawQuery := query; cut.Before(query.To) {
+		rawQuery.From = cut
+		tail, err := rawSeriesPoints(ctx, rawQuery, c)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, tail...)
+	}
+
+	return points, nil
+}
+
+// rawSeriesPoints reads the series from the raw values
+func rawSeriesPoints(ctx context.Context, query model.SeriesQuery, c collector.Collector) ([]model.SeriesPoint, error) {
+	if query.Field != model.SeriesFieldUp {
+		return serverModel.ServerRepos.ApplicationMetricValue.Series(ctx, query)
+	}
+
+	values, err := serverModel.ServerRepos.ApplicationMetricValue.ListRange(ctx, query.ApplicationMetricID, query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]model.Sample, 0, len(values))
+	for _, value := range values {
+		up := true
+		if c != nil {
+			var metricValue model.MetricValue
+			if err := json.Unmarshal(value.Value, &metricValue); err == nil {
+				alert, _ := c.EvaluateAlert(metricValue)
+				up = !alert
+			}
+		}
+		samples = append(samples, model.Sample{Time: value.CreatedAt, Value: prometheus.Bool(up)})
+	}
+
+	return model.BucketSamples(samples, query.Step, query.Aggregation), nil
+}
+
+// parseSeriesQuery reads the field, aggregation, range and step of a series
+// request, filling in the defaults
+func parseSeriesQuery(sc *core.HTTPServerContext, now time.Time) (model.SeriesQuery, error) {
+	query := model.SeriesQuery{
+		Field:       sc.QueryParam("field"),
+		Aggregation: sc.QueryParam("agg"),
+		To:          now.UTC(),
+	}
+
+	fields := strings.Join(append([]string{model.SeriesFieldUp}, model.SeriesFields...), ", ")
+	if query.Field == "" {
+		return query, fmt.Errorf("field is required, one of: %s", fields)
+	}
+	if query.Field != model.SeriesFieldUp && !model.IsSeriesField(query.Field) {
+		return query, fmt.Errorf("unknown field %q, use one of: %s", query.Field, fields)
+	}
+
+	switch query.Aggregation {
+	case "":
+		query.Aggregation = model.AggregationAvg
+	case model.AggregationAvg, model.AggregationMin, model.AggregationMax, model.AggregationP95:
+	default:
+		return query, fmt.Errorf("unknown agg %q, use avg, min, max or p95", query.Aggregation)
+	}
+
+	if v := sc.QueryParam("to"); v != "" {
+		to, err := parseSeriesTime(v)
+		if err != nil {
+			return query, fmt.Errorf("invalid to: %w", err)
+		}
+		query.To = to
+	}
+	query.From = query.To.Add(-defaultSeriesRange)
+	if v := sc.QueryParam("from"); v != "" {
+		from, err := parseSeriesTime(v)
+		if err != nil {
+			return query, fmt.Errorf("invalid from: %w", err)
+		}
+		query.From = from
+	}
+	if !query.From.Before(query.To) {
+		return query, errors.New("from must be before to")
+	}
+
+	span := query.To.Sub(query.From)
+	if v := sc.QueryParam("step"); v != "" {
+		step, err := parseSeriesStep(v)
+		if err != nil {
+			return query, fmt.Errorf("invalid step: %w", err)
+		}
+		query.Step = step
+	} else {
+		// Round the default step up to whole minutes, at least one: a range of
+		// a few nanoseconds would round down to a zero step
+		query.Step = (span/defaultSeriesPoints + time.Minute - 1).Truncate(time.Minute)
+		if query.Step < time.Minute {
+			query.Step = time.Minute
+		}
+	}
+	if span/query.Step > maxSeriesPoints {
+		return query, fmt.Errorf("step %s is too small for the range, it would return more than %d points", query.Step, maxSeriesPoints)
+	}
+
+	return query, nil
+}
+
+// parseSeriesTime accepts RFC 3339 timestamps and Unix seconds
+func parseSeriesTime(v string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, errors.New("use RFC 3339 (2024-01-15T10:30:00Z) or Unix seconds")
+	}
+	return t.UTC(), nil
+}
+
+// parseSeriesStep accepts durations (5m, 1h), whole days (1d) and whole seconds
+func parseSeriesStep(v string) (time.Duration, error) {
+	step, err := time.ParseDuration(v)
+	if err != nil {
+		if days, convErr := strconv.Atoi(strings.TrimSuffix(v, "d")); convErr == nil && strings.HasSuffix(v, "d") {
+			step = time.Duration(days) * 24 * time.Hour
+		} else if seconds, convErr := strconv.Atoi(v); convErr == nil {
+			step = time.Duration(seconds) * time.Second
+		} else {
+			return 0, errors.New("use a duration (5m, 1h, 1d) or seconds")
+		}
+	}
+	if step < time.Second {
+		return 0, errors.New("step must be at least 1s")
+	}
+	return step, nil
+}
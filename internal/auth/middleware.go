@@ -58,6 +58,9 @@ func isPublicRoute(path string) bool {
 		"/auth/logout",
 		"/auth/error",
 		"/health",
+		"/health/ready",
+		"/metrics",
+		"/slack/interactions",
 	}
 
 	for _, route := range publicRoutes {
@@ -67,7 +67,7 @@ func CreateSession(ctx context.Context, email, name, picture string, token *oaut
         VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
     `
 
-	_, err = db.ExecContext(ctx, query,
+	_, err = db.ExecContext(ctx, core.Rebind(query),
 		session.ID,
 		session.UserEmail,
 		session.UserName,
@@ -101,7 +101,7 @@ func GetSession(ctx context.Context, sessionID string) (*Session, error) {
 
 	var session Session
 	var userPicture sql.NullString
-	err = db.QueryRowContext(ctx, query, sessionID, time.Now().UTC()).Scan(
+	err = db.QueryRowContext(ctx, core.Rebind(query), sessionID, time.Now().UTC()).Scan(
 		&session.ID,
 		&session.UserEmail,
 		&session.UserName,
@@ -133,7 +133,7 @@ func DeleteSession(ctx context.Context, sessionID string) error {
 	}
 
 	query := `DELETE FROM sessions WHERE id = ?`
-	_, err = db.ExecContext(ctx, query, sessionID)
+	_, err = db.ExecContext(ctx, core.Rebind(query), sessionID)
 	if err != nil {
 		return fmt.Errorf("failed to delete session: %w", err)
 	}
@@ -149,7 +149,7 @@ func CleanupExpiredSessions(ctx context.Context) error {
 	}
 
 	query := `DELETE FROM sessions WHERE expires_at <= ?`
-	result, err := db.ExecContext(ctx, query, time.Now().UTC())
+	result, err := db.ExecContext(ctx, core.Rebind(query), time.Now().UTC())
 	if err != nil {
 		return fmt.Errorf("failed to cleanup expired sessions: %w", err)
 	}
@@ -173,7 +173,7 @@ func UpdateSessionExpiry(ctx context.Context, sessionID string) error {
 	expiresAt := time.Now().UTC().Add(24 * time.Hour)
 	query := `UPDATE sessions SET expires_at = ? WHERE id = ?`
 
-	_, err = db.ExecContext(ctx, query, expiresAt, sessionID)
+	_, err = db.ExecContext(ctx, core.Rebind(query), expiresAt, sessionID)
 	if err != nil {
 		return fmt.Errorf("update session error for session %s: %s", sessionID, err.Error())
 	}
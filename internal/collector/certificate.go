@@ -0,0 +1,110 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+
+	"k8s-monitoring-app/internal/prometheus"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+)
+
+func init() {
+	Register(&ingressCertificate{base{
+		name:        "IngressCertificate",
+		description: "Ingress certificate expiration monitoring",
+		fields: []ConfigField{
+			{
+				Name:        "ingress_name",
+				Label:       "Nome do Ingress:",
+				Type:        FieldText,
+				Required:    true,
+				Placeholder: "minha-aplicacao-ingress",
+				Aliases:     []string{"ingressName"},
+			},
+			{
+				Name:        "ingress_namespace",
+				Label:       "Namespace (opcional):",
+				Type:        FieldText,
+				Placeholder: "default",
+				Aliases:     []string{"ingressNamespace"},
+			},
+			{
+				Name:        "tls_secret_name",
+				Label:       "Nome do Secret TLS (opcional):",
+				Type:        FieldText,
+				Placeholder: "minha-aplicacao-tls",
+				Aliases:     []string{"tlsSecretName"},
+			},
+			{
+				Name:     "warning_days",
+				Label:    "Dias de Aviso:",
+				Type:     FieldNumber,
+				Required: true,
+				Default:  "30",
+				Min:      "1",
+				Max:      "365",
+				Aliases:  []string{"warningDays"},
+			},
+		},
+	}})
+}
+
+type ingressCertificate struct {
+	base
+}
+
+func (c *ingressCertificate) Collect(ctx context.Context, target Target) (applicationMetricValueModel.MetricValue, error) {
+	config := target.Config
+
+	// Determine namespace (use application namespace if not specified)
+	namespace := config.IngressNamespace
+	if namespace == "" {
+		namespace = target.Application.Namespace
+	}
+
+	// Get warning threshold (default: 30 days)
+	warningDays := config.WarningDays
+	if warningDays <= 0 {
+		warningDays = 30
+	}
+
+	// Get certificate information
+	certInfo, err := target.K8s.GetIngressCertificateInfo(
+		ctx,
+		namespace,
+		config.IngressName,
+		config.TLSSecretName,
+		warningDays,
+	)
+	if err != nil {
+		return applicationMetricValueModel.MetricValue{}, fmt.Errorf("failed to get certificate info: %w", err)
+	}
+
+	return applicationMetricValueModel.MetricValue{
+		CertificateStatus:       certInfo.Status,
+		CertificateExpiration:   certInfo.Expiration,
+		CertificateDaysToExpire: certInfo.DaysToExpire,
+		CertificateIssuer:       certInfo.Issuer,
+		CertificateSubject:      certInfo.Subject,
+		CertificateDomains:      certInfo.Domains,
+		CertificateError:        certInfo.ErrorMessage,
+	}, nil
+}
+
+func (c *ingressCertificate) ValueFields() []string {
+	return []string{"certificate_days_to_expire"}
+}
+
+func (c *ingressCertificate) Export(cfg applicationMetricModel.Configuration, v applicationMetricValueModel.MetricValue) []prometheus.Sample {
+	samples := []prometheus.Sample{
+		gauge("certificate_valid", "Whether the certificate was found and is not expired (1) or not (0)",
+			prometheus.Bool(v.CertificateStatus == "valid" || v.CertificateStatus == "expiring_soon")),
+		gauge("certificate_days_to_expire", "Days until the certificate expires (negative if expired)", float64(v.CertificateDaysToExpire)),
+	}
+	if !v.CertificateExpiration.IsZero() {
+		samples = append(samples, gauge("certificate_expiration_timestamp_seconds",
+			"Certificate expiration as a Unix timestamp", float64(v.CertificateExpiration.Unix())))
+	}
+	return samples
+}
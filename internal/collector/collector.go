@@ -0,0 +1,91 @@
+package collector
+
+import (
+	"context"
+
+	"k8s-monitoring-app/internal/k8s"
+	"k8s-monitoring-app/internal/prometheus"
+	applicationModel "k8s-monitoring-app/pkg/application/model"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+)
+
+// Collector describes a metric type end-to-end: its configuration schema,
+// how the configuration is validated, how a value is collected and when a
+// collected value should raise an alert.
+type Collector interface {
+	// Name is the metric type name as stored in metric_types.name
+	Name() string
+	// Description is seeded into metric_types.description
+	Description() string
+	// ConfigFields describes the configuration accepted by this metric type
+	ConfigFields() []ConfigField
+	// Validate performs required-field checks on a parsed configuration
+	Validate(cfg applicationMetricModel.Configuration) error
+	// Collect gathers a single value for the given target
+	Collect(ctx context.Context, target Target) (applicationMetricValueModel.MetricValue, error)
+	// Export converts a collected value into Prometheus samples. Samples carry
+	// only collector-specific labels; the exporter adds the common ones.
+	Export(cfg applicationMetricModel.Configuration, value applicationMetricValueModel.MetricValue) []prometheus.Sample
+	// EvaluateAlert reports whether a collected value represents a failure,
+	// besides the alert rules of the metric
+	EvaluateAlert(value applicationMetricValueModel.MetricValue) (bool, string)
+	// FailureAlerts reports whether the failures found by EvaluateAlert fire an
+	// alert by default. A metric overrides it with its failure_alerts.
+	FailureAlerts() bool
+	// ValueFields lists the numeric value fields this collector fills, the
+	// ones alert rules can watch
+	ValueFields() []string
+	// AllowMultiple reports whether an application may have more than one metric of this type
+	AllowMultiple() bool
+}
+
+// Target carries everything a collector needs to gather a value
+type Target struct {
+	K8s         *k8s.Client
+	Application *applicationModel.Application
+	Config      *applicationMetricModel.Configuration
+}
+
+// base provides default behaviour shared by the built-in collectors
+type base struct {
+	name        string
+	description string
+	fields      []ConfigField
+}
+
+func (b base) Name() string {
+	return b.name
+}
+
+func (b base) Description() string {
+	return b.description
+}
+
+func (b base) ConfigFields() []ConfigField {
+	return b.fields
+}
+
+func (b base) Validate(cfg applicationMetricModel.Configuration) error {
+	return nil
+}
+
+func (b base) Export(cfg applicationMetricModel.Configuration, value applicationMetricValueModel.MetricValue) []prometheus.Sample {
+	return nil
+}
+
+func (b base) EvaluateAlert(value applicationMetricValueModel.MetricValue) (bool, string) {
+	return false, ""
+}
+
+func (b base) FailureAlerts() bool {
+	return false
+}
+
+func (b base) ValueFields() []string {
+	return nil
+}
+
+func (b base) AllowMultiple() bool {
+	return false
+}
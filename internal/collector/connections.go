@@ -0,0 +1,237 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+
+	"k8s-monitoring-app/internal/connections"
+	"k8s-monitoring-app/internal/prometheus"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+)
+
+func init() {
+	Register(&connection{
+		base: base{
+			name:        "RedisConnection",
+			description: "Test Redis connection with authentication",
+			fields: []ConfigField{
+				connectionHostField("Host do Redis:", "redis.default.svc.cluster.local"),
+				connectionPortField("6379"),
+				connectionPasswordField("Senha (opcional):", "senha-do-redis", false),
+				{
+					Name:     "connection_db",
+					Label:    "Database:",
+					Type:     FieldNumber,
+					Required: true,
+					Default:  "0",
+					Min:      "0",
+					Max:      "15",
+					Aliases:  []string{"db", "connectionDB"},
+				},
+				connectionSSLField(),
+				connectionTimeoutField("5"),
+			},
+		},
+		test:      connections.TestRedisConnection,
+		alertable: true,
+		validate:  validateRedis,
+	})
+	Register(&connection{
+		base: base{
+			name:        "PostgreSQLConnection",
+			description: "Test PostgreSQL database connection with authentication",
+			fields: []ConfigField{
+				connectionHostField("Host do PostgreSQL:", "postgres.default.svc.cluster.local"),
+				connectionPortField("5432"),
+				connectionUsernameField("Usuário:", "usuario", true),
+				connectionPasswordField("Senha:", "senha", true),
+				connectionDatabaseField(),
+				connectionSSLField(),
+				connectionTimeoutField("10"),
+			},
+		},
+		test:      connections.TestPostgreSQLConnection,
+		alertable: true,
+		validate:  validateSQL,
+	})
+	Register(&connection{
+		base: base{
+			name:        "MongoDBConnection",
+			description: "Test MongoDB database connection with authentication",
+			fields: []ConfigField{
+				connectionHostField("Host do MongoDB:", "mongodb.default.svc.cluster.local"),
+				connectionPortField("27017"),
+				connectionUsernameField("Usuário:", "admin", true),
+				connectionPasswordField("Senha:", "senha", true),
+				connectionDatabaseField(),
+				{
+					Name:        "connection_auth_source",
+					Label:       "Auth Source:",
+					Type:        FieldText,
+					Required:    true,
+					Default:     "admin",
+					Placeholder: "admin",
+					Aliases:     []string{"authSource", "connectionAuthSource"},
+				},
+				connectionSSLField(),
+				connectionTimeoutField("5"),
+			},
+		},
+		test:      connections.TestMongoDBConnection,
+		alertable: true,
+		validate:  validateMongo,
+	})
+	Register(&connection{
+		base: base{
+			name:        "MySQLConnection",
+			description: "Test MySQL database connection with authentication",
+			fields: []ConfigField{
+				connectionHostField("Host do MySQL:", "mysql.default.svc.cluster.local"),
+				connectionPortField("3306"),
+				connectionUsernameField("Usuário:", "root", true),
+				connectionPasswordField("Senha:", "senha", true),
+				connectionDatabaseField(),
+				connectionSSLField(),
+				connectionTimeoutField("5"),
+			},
+		},
+		test:     connections.TestMySQLConnection,
+		validate: validateSQL,
+	})
+	Register(&connection{
+		base: base{
+			name:        "KongConnection",
+			description: "Test Kong API Gateway connection and health",
+			fields: []ConfigField{
+				connectionHostField("Host do Kong Admin:", "kong-admin.default.svc.cluster.local"),
+				connectionPortField("8001"),
+				{
+					Name:        "kong_admin_url",
+					Label:       "URL Admin do Kong:",
+					Type:        FieldURL,
+					Required:    true,
+					Placeholder: "http://kong-admin.default.svc.cluster.local:8001",
+					Aliases:     []string{"adminUrl", "kongAdminUrl"},
+				},
+				connectionUsernameField("Usuário (opcional):", "admin", false),
+				connectionPasswordField("Senha (opcional):", "senha", false),
+				connectionSSLField(),
+				connectionTimeoutField("5"),
+			},
+		},
+		test:     connections.TestKongConnection,
+		validate: validateKong,
+	})
+}
+
+// connection wraps the connection testers from the connections package
+type connection struct {
+	base
+	test      func(ctx context.Context, config *applicationMetricModel.Configuration) applicationMetricValueModel.MetricValue
+	validate  func(metricTypeName string, cfg applicationMetricModel.Configuration) error
+	alertable bool // Whether a failed connection raises an alert by default, see FailureAlerts
+}
+
+func (c *connection) Collect(ctx context.Context, target Target) (applicationMetricValueModel.MetricValue, error) {
+	return c.test(ctx, target.Config), nil
+}
+
+// Validate prevents silent zero-values (e.g., port=0, timeout=0) from reaching the testers
+func (c *connection) Validate(cfg applicationMetricModel.Configuration) error {
+	return c.validate(c.name, cfg)
+}
+
+func (c *connection) Export(cfg applicationMetricModel.Configuration, v applicationMetricValueModel.MetricValue) []prometheus.Sample {
+	return []prometheus.Sample{
+		gauge("connection_up", "Whether the connection succeeded (1) or not (0)", prometheus.Bool(v.ConnectionStatus == connections.StatusConnected)),
+		gauge("connection_time_ms", "Time to establish the connection in milliseconds", float64(v.ConnectionTimeMs)),
+		gauge("connection_ping_time_ms", "Ping or test query time in milliseconds", float64(v.ConnectionPingTimeMs)),
+	}
+}
+
+func (c *connection) ValueFields() []string {
+	return []string{"connection_time_ms", "connection_ping_time_ms"}
+}
+
+func (c *connection) FailureAlerts() bool {
+	return c.alertable
+}
+
+func (c *connection) EvaluateAlert(v applicationMetricValueModel.MetricValue) (bool, string) {
+	if v.ConnectionStatus != connections.StatusConnected {
+		reason := v.ConnectionStatus
+		if v.ConnectionError != "" {
+			reason = fmt.Sprintf("%s - %s", reason, v.ConnectionError)
+		}
+		return true, reason
+	}
+	return false, ""
+}
+
+func validateSQL(metricTypeName string, cfg applicationMetricModel.Configuration) error {
+	if cfg.ConnectionHost == "" {
+		return fmt.Errorf("connection_host is required for %s", metricTypeName)
+	}
+	if cfg.ConnectionPort <= 0 {
+		return fmt.Errorf("connection_port must be a positive integer for %s", metricTypeName)
+	}
+	if cfg.ConnectionUsername == "" {
+		return fmt.Errorf("connection_username is required for %s", metricTypeName)
+	}
+	if cfg.ConnectionDatabase == "" {
+		return fmt.Errorf("connection_database is required for %s", metricTypeName)
+	}
+	if cfg.ConnectionTimeout <= 0 {
+		return fmt.Errorf("connection_timeout must be a positive integer for %s", metricTypeName)
+	}
+	return nil
+}
+
+func validateMongo(metricTypeName string, cfg applicationMetricModel.Configuration) error {
+	if cfg.ConnectionHost == "" {
+		return fmt.Errorf("connection_host is required for %s", metricTypeName)
+	}
+	if cfg.ConnectionPort <= 0 {
+		return fmt.Errorf("connection_port must be a positive integer for %s", metricTypeName)
+	}
+	if cfg.ConnectionUsername == "" {
+		return fmt.Errorf("connection_username is required for %s", metricTypeName)
+	}
+	if cfg.ConnectionPassword == "" {
+		return fmt.Errorf("connection_password is required for %s", metricTypeName)
+	}
+	if cfg.ConnectionDatabase == "" {
+		return fmt.Errorf("connection_database is required for %s", metricTypeName)
+	}
+	if cfg.ConnectionTimeout <= 0 {
+		return fmt.Errorf("connection_timeout must be a positive integer for %s", metricTypeName)
+	}
+	return nil
+}
+
+func validateRedis(metricTypeName string, cfg applicationMetricModel.Configuration) error {
+	if cfg.ConnectionHost == "" {
+		return fmt.Errorf("connection_host is required for %s", metricTypeName)
+	}
+	if cfg.ConnectionPort <= 0 {
+		return fmt.Errorf("connection_port must be a positive integer for %s", metricTypeName)
+	}
+	if cfg.ConnectionTimeout <= 0 {
+		return fmt.Errorf("connection_timeout must be a positive integer for %s", metricTypeName)
+	}
+	return nil
+}
+
+func validateKong(metricTypeName string, cfg applicationMetricModel.Configuration) error {
+	// Either explicit admin URL or host+port
+	if cfg.KongAdminURL == "" {
+		if cfg.ConnectionHost == "" || cfg.ConnectionPort <= 0 {
+			return fmt.Errorf("kong_admin_url or connection_host+connection_port are required for %s", metricTypeName)
+		}
+	}
+	if cfg.ConnectionTimeout <= 0 {
+		return fmt.Errorf("connection_timeout must be a positive integer for %s", metricTypeName)
+	}
+	return nil
+}
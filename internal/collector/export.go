@@ -0,0 +1,18 @@
+package collector
+
+import (
+	"k8s-monitoring-app/internal/prometheus"
+)
+
+// MetricPrefix namespaces every metric exported on /metrics
+const MetricPrefix = "k8s_monitoring_"
+
+// gauge builds a collector sample under the exporter prefix
+func gauge(name, help string, value float64, labels ...prometheus.Label) prometheus.Sample {
+	return prometheus.Sample{
+		Name:   MetricPrefix + name,
+		Help:   help,
+		Labels: labels,
+		Value:  value,
+	}
+}
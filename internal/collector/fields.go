@@ -0,0 +1,142 @@
+package collector
+
+// FieldType is the HTML input type used to render a configuration field
+type FieldType string
+
+const (
+	FieldText     FieldType = "text"
+	FieldURL      FieldType = "url"
+	FieldNumber   FieldType = "number"
+	FieldPassword FieldType = "password"
+	FieldSelect   FieldType = "select"
+)
+
+// FieldOption is a single choice of a select field
+type FieldOption struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+// ConfigField describes one key of the metric configuration JSON
+type ConfigField struct {
+	Name        string        `json:"name"`                  // JSON key in Configuration
+	Label       string        `json:"label"`                 // Form label
+	Type        FieldType     `json:"type"`                  // Input type
+	Required    bool          `json:"required"`              // Marks the input as required in the form
+	Placeholder string        `json:"placeholder,omitempty"` // Input placeholder
+	Default     string        `json:"default,omitempty"`     // Pre-filled value
+	Min         string        `json:"min,omitempty"`         // Minimum for number inputs
+	Max         string        `json:"max,omitempty"`         // Maximum for number inputs
+	Options     []FieldOption `json:"options,omitempty"`     // Choices for select inputs
+	Aliases     []string      `json:"aliases,omitempty"`     // Alternative keys accepted by the YAML importer
+}
+
+// Shared field definitions reused across collectors
+
+var sslOptions = []FieldOption{
+	{Value: "false", Label: "Não"},
+	{Value: "true", Label: "Sim"},
+}
+
+func podLabelSelectorField() ConfigField {
+	return ConfigField{
+		Name:        "pod_label_selector",
+		Label:       "Seletor de Labels do Pod:",
+		Type:        FieldText,
+		Required:    true,
+		Placeholder: "app=minha-aplicacao",
+		Aliases:     []string{"podLabelSelector"},
+	}
+}
+
+func containerNameField() ConfigField {
+	return ConfigField{
+		Name:        "container_name",
+		Label:       "Nome do Container:",
+		Type:        FieldText,
+		Required:    true,
+		Placeholder: "web",
+		Aliases:     []string{"containerName"},
+	}
+}
+
+func connectionHostField(label, placeholder string) ConfigField {
+	return ConfigField{
+		Name:        "connection_host",
+		Label:       label,
+		Type:        FieldText,
+		Required:    true,
+		Placeholder: placeholder,
+		Aliases:     []string{"host", "connectionHost"},
+	}
+}
+
+func connectionPortField(defaultPort string) ConfigField {
+	return ConfigField{
+		Name:     "connection_port",
+		Label:    "Porta:",
+		Type:     FieldNumber,
+		Required: true,
+		Default:  defaultPort,
+		Min:      "1",
+		Max:      "65535",
+		Aliases:  []string{"port", "connectionPort"},
+	}
+}
+
+func connectionUsernameField(label, placeholder string, required bool) ConfigField {
+	return ConfigField{
+		Name:        "connection_username",
+		Label:       label,
+		Type:        FieldText,
+		Required:    required,
+		Placeholder: placeholder,
+		Aliases:     []string{"username", "connectionUsername"},
+	}
+}
+
+func connectionPasswordField(label, placeholder string, required bool) ConfigField {
+	return ConfigField{
+		Name:        "connection_password",
+		Label:       label,
+		Type:        FieldPassword,
+		Required:    required,
+		Placeholder: placeholder,
+		Aliases:     []string{"password", "connectionPassword"},
+	}
+}
+
+func connectionDatabaseField() ConfigField {
+	return ConfigField{
+		Name:        "connection_database",
+		Label:       "Database:",
+		Type:        FieldText,
+		Required:    true,
+		Placeholder: "minha_base",
+		Aliases:     []string{"database", "connectionDatabase"},
+	}
+}
+
+func connectionSSLField() ConfigField {
+	return ConfigField{
+		Name:     "connection_ssl",
+		Label:    "SSL:",
+		Type:     FieldSelect,
+		Required: true,
+		Options:  sslOptions,
+		Aliases:  []string{"ssl", "connectionSSL"},
+	}
+}
+
+func connectionTimeoutField(defaultTimeout string) ConfigField {
+	return ConfigField{
+		Name:     "connection_timeout",
+		Label:    "Timeout (segundos):",
+		Type:     FieldNumber,
+		Required: true,
+		Default:  defaultTimeout,
+		Min:      "1",
+		Max:      "300",
+		Aliases:  []string{"timeout", "timeoutSeconds", "connectionTimeout"},
+	}
+}
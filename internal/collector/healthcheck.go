@@ -0,0 +1,110 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+
+	"k8s-monitoring-app/internal/prometheus"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+)
+
+func init() {
+	Register(&healthCheck{base{
+		name:        "HealthCheck",
+		description: "Health check of the pod",
+		fields: []ConfigField{
+			{
+				Name:        "health_check_url",
+				Label:       "URL do Health Check:",
+				Type:        FieldURL,
+				Required:    true,
+				Placeholder: "http://app.namespace.svc.cluster.local:8080/health",
+				Aliases:     []string{"url", "healthCheckUrl"},
+			},
+			{
+				Name:     "method",
+				Label:    "Método HTTP:",
+				Type:     FieldSelect,
+				Required: true,
+				Options: []FieldOption{
+					{Value: "GET", Label: "GET"},
+					{Value: "POST", Label: "POST"},
+					{Value: "HEAD", Label: "HEAD"},
+				},
+			},
+			{
+				Name:     "expected_status",
+				Label:    "Status HTTP Esperado:",
+				Type:     FieldNumber,
+				Required: true,
+				Default:  "200",
+				Min:      "100",
+				Max:      "599",
+				Aliases:  []string{"expectedStatus"},
+			},
+			{
+				Name:     "timeout_seconds",
+				Label:    "Timeout (segundos):",
+				Type:     FieldNumber,
+				Required: true,
+				Default:  "10",
+				Min:      "1",
+				Max:      "300",
+				Aliases:  []string{"timeout", "timeoutSeconds"},
+			},
+		},
+	}})
+}
+
+type healthCheck struct {
+	base
+}
+
+func (c *healthCheck) Collect(ctx context.Context, target Target) (applicationMetricValueModel.MetricValue, error) {
+	config := target.Config
+	result := target.K8s.PerformHealthCheck(
+		ctx,
+		config.HealthCheckURL,
+		config.Method,
+		config.ExpectedStatus,
+		config.TimeoutSeconds,
+	)
+
+	return applicationMetricValueModel.MetricValue{
+		Status:         result.Status,
+		ResponseTimeMs: result.ResponseTimeMs,
+		StatusCode:     result.StatusCode,
+		ErrorMessage:   result.ErrorMessage,
+	}, nil
+}
+
+func (c *healthCheck) Export(cfg applicationMetricModel.Configuration, v applicationMetricValueModel.MetricValue) []prometheus.Sample {
+	return []prometheus.Sample{
+		gauge("health_up", "Whether the health check endpoint is up (1) or down (0)", prometheus.Bool(v.Status == "up")),
+		gauge("health_response_time_ms", "Health check response time in milliseconds", float64(v.ResponseTimeMs)),
+		gauge("health_status_code", "HTTP status code returned by the health check", float64(v.StatusCode)),
+	}
+}
+
+func (c *healthCheck) ValueFields() []string {
+	return []string{"response_time_ms", "status_code"}
+}
+
+func (c *healthCheck) FailureAlerts() bool {
+	return true
+}
+
+func (c *healthCheck) EvaluateAlert(v applicationMetricValueModel.MetricValue) (bool, string) {
+	if v.Status == "down" || v.StatusCode >= 400 {
+		reason := "healthcheck down"
+		if v.StatusCode > 0 {
+			reason = fmt.Sprintf("status %d", v.StatusCode)
+		}
+		if v.ErrorMessage != "" {
+			reason = fmt.Sprintf("%s - %s", reason, v.ErrorMessage)
+		}
+		return true, reason
+	}
+	return false, ""
+}
@@ -0,0 +1,131 @@
+package collector
+
+import (
+	"context"
+	"strconv"
+
+	"k8s-monitoring-app/internal/kafka"
+	"k8s-monitoring-app/internal/prometheus"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+)
+
+func init() {
+	Register(&kafkaConsumerLag{base{
+		name:        "KafkaConsumerLag",
+		description: "Monitor Kafka consumer lag for topics and consumer groups",
+		fields: []ConfigField{
+			{
+				Name:        "kafka_bootstrap_servers",
+				Label:       "Servidores Bootstrap do Kafka:",
+				Type:        FieldText,
+				Required:    true,
+				Placeholder: "kafka:9092",
+				Aliases:     []string{"bootstrapServers"},
+			},
+			{
+				Name:        "kafka_consumer_group",
+				Label:       "Grupo de Consumidores (opcional):",
+				Type:        FieldText,
+				Placeholder: "meu-grupo-consumidor",
+				Aliases:     []string{"consumerGroup"},
+			},
+			{
+				Name:        "kafka_topic",
+				Label:       "Tópico (opcional):",
+				Type:        FieldText,
+				Placeholder: "meu-topico",
+				Aliases:     []string{"topic"},
+			},
+			{
+				Name:     "kafka_lag_threshold",
+				Label:    "Limite de Lag:",
+				Type:     FieldNumber,
+				Required: true,
+				Default:  "1000",
+				Min:      "0",
+				Aliases:  []string{"lagThreshold"},
+			},
+			{
+				Name:  "kafka_security_protocol",
+				Label: "Protocolo de Segurança (opcional):",
+				Type:  FieldSelect,
+				Options: []FieldOption{
+					{Value: "", Label: "Nenhum"},
+					{Value: "PLAINTEXT", Label: "PLAINTEXT"},
+					{Value: "SASL_PLAINTEXT", Label: "SASL_PLAINTEXT"},
+					{Value: "SASL_SSL", Label: "SASL_SSL"},
+					{Value: "SSL", Label: "SSL"},
+				},
+				Aliases: []string{"securityProtocol"},
+			},
+			{
+				Name:  "kafka_sasl_mechanism",
+				Label: "Mecanismo SASL (opcional):",
+				Type:  FieldSelect,
+				Options: []FieldOption{
+					{Value: "", Label: "Nenhum"},
+					{Value: "PLAIN", Label: "PLAIN"},
+					{Value: "SCRAM-SHA-256", Label: "SCRAM-SHA-256"},
+					{Value: "SCRAM-SHA-512", Label: "SCRAM-SHA-512"},
+				},
+				Aliases: []string{"saslMechanism"},
+			},
+			{
+				Name:        "kafka_sasl_username",
+				Label:       "Usuário SASL (opcional):",
+				Type:        FieldText,
+				Placeholder: "usuario-kafka",
+				Aliases:     []string{"saslUsername"},
+			},
+			{
+				Name:        "kafka_sasl_password",
+				Label:       "Senha SASL (opcional):",
+				Type:        FieldPassword,
+				Placeholder: "senha-kafka",
+				Aliases:     []string{"saslPassword"},
+			},
+		},
+	}})
+}
+
+type kafkaConsumerLag struct {
+	base
+}
+
+func (c *kafkaConsumerLag) Collect(ctx context.Context, target Target) (applicationMetricValueModel.MetricValue, error) {
+	return kafka.CollectConsumerLag(ctx, target.Config), nil
+}
+
+func (c *kafkaConsumerLag) ValueFields() []string {
+	return []string{"kafka_total_lag"}
+}
+
+func (c *kafkaConsumerLag) Export(cfg applicationMetricModel.Configuration, v applicationMetricValueModel.MetricValue) []prometheus.Sample {
+	samples := []prometheus.Sample{
+		gauge("kafka_consumer_lag_total", "Total consumer lag across the monitored groups and topics", float64(v.KafkaTotalLag)),
+	}
+
+	// A single group reports its topics directly; listing all groups nests them per group
+	groups := v.KafkaGroupLags
+	if len(v.KafkaTopicLags) > 0 {
+		group := v.KafkaConsumerGroup
+		if group == "" {
+			group = cfg.KafkaConsumerGroup
+		}
+		groups = append(groups, applicationMetricValueModel.KafkaGroupLag{Group: group, TopicLags: v.KafkaTopicLags})
+	}
+
+	for _, g := range groups {
+		for _, t := range g.TopicLags {
+			for _, p := range t.PartitionLags {
+				samples = append(samples, gauge("kafka_consumer_lag", "Consumer lag per group, topic and partition", float64(p.Lag),
+					prometheus.Label{Name: "group", Value: g.Group},
+					prometheus.Label{Name: "topic", Value: t.Topic},
+					prometheus.Label{Name: "partition", Value: strconv.Itoa(int(p.Partition))},
+				))
+			}
+		}
+	}
+	return samples
+}
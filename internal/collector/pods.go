@@ -0,0 +1,363 @@
+package collector
+
+import (
+	"context"
+
+	"k8s-monitoring-app/internal/prometheus"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func init() {
+	Register(&podStatus{base{
+		name:        "PodStatus",
+		description: "Status of the pod",
+		fields:      []ConfigField{podLabelSelectorField(), containerNameField()},
+	}})
+	Register(&podMemoryUsage{base{
+		name:        "PodMemoryUsage",
+		description: "Memory usage of the pod",
+		fields:      []ConfigField{podLabelSelectorField(), containerNameField()},
+	}})
+	Register(&podCpuUsage{base{
+		name:        "PodCpuUsage",
+		description: "CPU usage of the pod",
+		fields:      []ConfigField{podLabelSelectorField(), containerNameField()},
+	}})
+	Register(&podActiveNodes{base{
+		name:        "PodActiveNodes",
+		description: "Number of active nodes in the pod",
+		fields:      []ConfigField{podLabelSelectorField()},
+	}})
+}
+
+type podStatus struct {
+	base
+}
+
+func (c *podStatus) Collect(ctx context.Context, target Target) (applicationMetricValueModel.MetricValue, error) {
+	application, config := target.Application, target.Config
+
+	pods, err := target.K8s.GetPodsByLabelSelector(ctx, application.Namespace, config.PodLabelSelector)
+	if err != nil {
+		return applicationMetricValueModel.MetricValue{}, err
+	}
+
+	if len(pods.Items) == 0 {
+		return applicationMetricValueModel.MetricValue{
+			PodPhase:     "NotFound",
+			PodReady:     false,
+			RestartCount: 0,
+			TotalPods:    0,
+			ReadyPods:    0,
+			Pods:         []applicationMetricValueModel.PodInfo{},
+		}, nil
+	}
+
+	// Aggregate status from all pods
+	totalPods := len(pods.Items)
+	runningPods := 0
+	readyPods := 0
+	totalRestarts := int32(0)
+	hasFailedPods := false
+	hasPendingPods := false
+
+	// Collect individual pod information
+	podInfos := make([]applicationMetricValueModel.PodInfo, 0, len(pods.Items))
+
+	for _, pod := range pods.Items {
+		switch pod.Status.Phase {
+		case corev1.PodRunning:
+			runningPods++
+		case corev1.PodFailed:
+			hasFailedPods = true
+		case corev1.PodPending:
+			hasPendingPods = true
+		}
+
+		// Check container status for this specific pod
+		podReady := false
+		podRestartCount := int32(0)
+
+		if config.ContainerName != "" {
+			for _, containerStatus := range pod.Status.ContainerStatuses {
+				if containerStatus.Name == config.ContainerName {
+					podReady = containerStatus.Ready
+					podRestartCount = containerStatus.RestartCount
+					if containerStatus.Ready {
+						readyPods++
+					}
+					totalRestarts += containerStatus.RestartCount
+					break
+				}
+			}
+		} else {
+			// Use first container
+			if len(pod.Status.ContainerStatuses) > 0 {
+				podReady = pod.Status.ContainerStatuses[0].Ready
+				podRestartCount = pod.Status.ContainerStatuses[0].RestartCount
+				if pod.Status.ContainerStatuses[0].Ready {
+					readyPods++
+				}
+				totalRestarts += pod.Status.ContainerStatuses[0].RestartCount
+			}
+		}
+
+		// Add individual pod info
+		podInfos = append(podInfos, applicationMetricValueModel.PodInfo{
+			Name:         pod.Name,
+			Phase:        string(pod.Status.Phase),
+			Ready:        podReady,
+			RestartCount: podRestartCount,
+			NodeName:     pod.Spec.NodeName,
+			IP:           pod.Status.PodIP,
+		})
+	}
+
+	// Determine overall phase
+	overallPhase := "Running"
+	overallReady := true
+
+	if hasFailedPods {
+		overallPhase = "Degraded" // Some pods failed
+		overallReady = false
+	} else if readyPods < totalPods {
+		overallPhase = "Running"
+		overallReady = false // Not all pods ready
+	} else if hasPendingPods {
+		overallPhase = "Pending"
+		overallReady = false
+	}
+
+	return applicationMetricValueModel.MetricValue{
+		PodPhase:     overallPhase,
+		PodReady:     overallReady,
+		RestartCount: totalRestarts,
+		TotalPods:    totalPods,
+		ReadyPods:    readyPods,
+		Pods:         podInfos,
+	}, nil
+}
+
+func (c *podStatus) ValueFields() []string {
+	return []string{"restart_count", "total_pods", "ready_pods"}
+}
+
+func (c *podStatus) Export(cfg applicationMetricModel.Configuration, v applicationMetricValueModel.MetricValue) []prometheus.Sample {
+	samples := []prometheus.Sample{
+		gauge("pods_total", "Number of pods matching the label selector", float64(v.TotalPods)),
+		gauge("pods_ready", "Number of ready pods matching the label selector", float64(v.ReadyPods)),
+	}
+	for _, pod := range v.Pods {
+		podLabel := prometheus.Label{Name: "pod", Value: pod.Name}
+		samples = append(samples,
+			gauge("pod_ready", "Whether the pod is ready (1) or not (0)", prometheus.Bool(pod.Ready), podLabel),
+			gauge("pod_restarts", "Container restarts of the pod", float64(pod.RestartCount), podLabel),
+		)
+	}
+	return samples
+}
+
+type podMemoryUsage struct {
+	base
+}
+
+func (c *podMemoryUsage) Collect(ctx context.Context, target Target) (applicationMetricValueModel.MetricValue, error) {
+	application, config := target.Application, target.Config
+
+	pods, err := target.K8s.GetPodsByLabelSelector(ctx, application.Namespace, config.PodLabelSelector)
+	if err != nil {
+		return applicationMetricValueModel.MetricValue{}, err
+	}
+
+	if len(pods.Items) == 0 {
+		return applicationMetricValueModel.MetricValue{
+			MemoryUsageBytes: 0,
+			MemoryLimitBytes: 0,
+			MemoryPercent:    0,
+		}, nil
+	}
+
+	pod := pods.Items[0]
+
+	// Get pod metrics
+	podMetrics, err := target.K8s.GetPodMetrics(ctx, application.Namespace, pod.Name)
+	if err != nil {
+		return applicationMetricValueModel.MetricValue{}, err
+	}
+
+	var memoryUsage int64
+	var memoryLimit int64
+
+	// Find the specific container
+	for i, container := range podMetrics.Containers {
+		if config.ContainerName == "" || container.Name == config.ContainerName {
+			memoryUsage = container.Usage.Memory().Value()
+
+			// Get memory limit from pod spec
+			if i < len(pod.Spec.Containers) {
+				if limit, ok := pod.Spec.Containers[i].Resources.Limits[corev1.ResourceMemory]; ok {
+					memoryLimit = limit.Value()
+				}
+			}
+			break
+		}
+	}
+
+	var memoryPercent float64
+	if memoryLimit > 0 {
+		memoryPercent = float64(memoryUsage) / float64(memoryLimit) * 100
+	}
+
+	return applicationMetricValueModel.MetricValue{
+		MemoryUsageBytes: memoryUsage,
+		MemoryLimitBytes: memoryLimit,
+		MemoryPercent:    memoryPercent,
+	}, nil
+}
+
+func (c *podMemoryUsage) ValueFields() []string {
+	return []string{"memory_usage_bytes", "memory_limit_bytes", "memory_percent"}
+}
+
+func (c *podMemoryUsage) Export(cfg applicationMetricModel.Configuration, v applicationMetricValueModel.MetricValue) []prometheus.Sample {
+	return []prometheus.Sample{
+		gauge("memory_usage_bytes", "Memory used by the container", float64(v.MemoryUsageBytes)),
+		gauge("memory_limit_bytes", "Memory limit of the container", float64(v.MemoryLimitBytes)),
+		gauge("memory_usage_percent", "Memory usage as a percentage of the limit", v.MemoryPercent),
+	}
+}
+
+type podCpuUsage struct {
+	base
+}
+
+func (c *podCpuUsage) Collect(ctx context.Context, target Target) (applicationMetricValueModel.MetricValue, error) {
+	application, config := target.Application, target.Config
+
+	pods, err := target.K8s.GetPodsByLabelSelector(ctx, application.Namespace, config.PodLabelSelector)
+	if err != nil {
+		return applicationMetricValueModel.MetricValue{}, err
+	}
+
+	if len(pods.Items) == 0 {
+		return applicationMetricValueModel.MetricValue{
+			CpuUsageMillicores: 0,
+			CpuLimitMillicores: 0,
+			CpuPercent:         0,
+		}, nil
+	}
+
+	pod := pods.Items[0]
+
+	// Get pod metrics
+	podMetrics, err := target.K8s.GetPodMetrics(ctx, application.Namespace, pod.Name)
+	if err != nil {
+		return applicationMetricValueModel.MetricValue{}, err
+	}
+
+	var cpuUsage int64
+	var cpuLimit int64
+
+	// Find the specific container
+	for i, container := range podMetrics.Containers {
+		if config.ContainerName == "" || container.Name == config.ContainerName {
+			cpuUsage = container.Usage.Cpu().MilliValue()
+
+			// Get CPU limit from pod spec
+			if i < len(pod.Spec.Containers) {
+				if limit, ok := pod.Spec.Containers[i].Resources.Limits[corev1.ResourceCPU]; ok {
+					cpuLimit = limit.MilliValue()
+				}
+			}
+			break
+		}
+	}
+
+	var cpuPercent float64
+	if cpuLimit > 0 {
+		cpuPercent = float64(cpuUsage) / float64(cpuLimit) * 100
+	}
+
+	return applicationMetricValueModel.MetricValue{
+		CpuUsageMillicores: cpuUsage,
+		CpuLimitMillicores: cpuLimit,
+		CpuPercent:         cpuPercent,
+	}, nil
+}
+
+func (c *podCpuUsage) ValueFields() []string {
+	return []string{"cpu_usage_millicores", "cpu_limit_millicores", "cpu_percent"}
+}
+
+func (c *podCpuUsage) Export(cfg applicationMetricModel.Configuration, v applicationMetricValueModel.MetricValue) []prometheus.Sample {
+	return []prometheus.Sample{
+		gauge("cpu_usage_millicores", "CPU used by the container in millicores", float64(v.CpuUsageMillicores)),
+		gauge("cpu_limit_millicores", "CPU limit of the container in millicores", float64(v.CpuLimitMillicores)),
+		gauge("cpu_usage_percent", "CPU usage as a percentage of the limit", v.CpuPercent),
+	}
+}
+
+type podActiveNodes struct {
+	base
+}
+
+func (c *podActiveNodes) Collect(ctx context.Context, target Target) (applicationMetricValueModel.MetricValue, error) {
+	// Get detailed node information
+	nodesInfo, err := target.K8s.GetNodesInfoForPods(ctx, target.Application.Namespace, target.Config.PodLabelSelector)
+	if err != nil {
+		return applicationMetricValueModel.MetricValue{}, err
+	}
+
+	// Extract node names for backward compatibility
+	nodeNames := make([]string, 0, len(nodesInfo))
+
+	// Convert k8s.NodeInfo to model.NodeInfo
+	nodes := make([]applicationMetricValueModel.NodeInfo, 0, len(nodesInfo))
+	for _, nodeInfo := range nodesInfo {
+		nodeNames = append(nodeNames, nodeInfo.Name)
+
+		// Convert conditions
+		conditions := make([]applicationMetricValueModel.NodeCondition, 0, len(nodeInfo.Conditions))
+		for _, cond := range nodeInfo.Conditions {
+			conditions = append(conditions, applicationMetricValueModel.NodeCondition{
+				Type:    cond.Type,
+				Status:  cond.Status,
+				Reason:  cond.Reason,
+				Message: cond.Message,
+			})
+		}
+
+		nodes = append(nodes, applicationMetricValueModel.NodeInfo{
+			Name:       nodeInfo.Name,
+			Ready:      nodeInfo.Ready,
+			Status:     nodeInfo.Status,
+			Conditions: conditions,
+			Labels:     nodeInfo.Labels,
+			PodCount:   nodeInfo.PodCount,
+		})
+	}
+
+	return applicationMetricValueModel.MetricValue{
+		ActiveNodesCount: len(nodes),
+		NodeNames:        nodeNames,
+		Nodes:            nodes,
+	}, nil
+}
+
+func (c *podActiveNodes) ValueFields() []string {
+	return []string{"active_nodes_count"}
+}
+
+func (c *podActiveNodes) Export(cfg applicationMetricModel.Configuration, v applicationMetricValueModel.MetricValue) []prometheus.Sample {
+	samples := []prometheus.Sample{
+		gauge("active_nodes", "Number of nodes running pods of the application", float64(v.ActiveNodesCount)),
+	}
+	for _, node := range v.Nodes {
+		samples = append(samples, gauge("node_ready", "Whether the node is ready (1) or not (0)",
+			prometheus.Bool(node.Ready), prometheus.Label{Name: "node", Value: node.Name}))
+	}
+	return samples
+}
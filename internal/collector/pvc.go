@@ -0,0 +1,82 @@
+package collector
+
+import (
+	"context"
+	"fmt"
+
+	"k8s-monitoring-app/internal/prometheus"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+)
+
+func init() {
+	Register(&pvcUsage{base{
+		name:        "PvcUsage",
+		description: "Usage of the PVC",
+		fields: []ConfigField{
+			{
+				Name:        "pvc_name",
+				Label:       "Nome do PVC:",
+				Type:        FieldText,
+				Required:    true,
+				Placeholder: "minha-aplicacao-data",
+				Aliases:     []string{"pvcName"},
+			},
+			podLabelSelectorField(),
+			containerNameField(),
+			{
+				Name:        "pvc_mount_path",
+				Label:       "Caminho de Montagem do PVC:",
+				Type:        FieldText,
+				Required:    true,
+				Placeholder: "/data",
+				Aliases:     []string{"pvcMountPath"},
+			},
+		},
+	}})
+}
+
+type pvcUsage struct {
+	base
+}
+
+func (c *pvcUsage) Collect(ctx context.Context, target Target) (applicationMetricValueModel.MetricValue, error) {
+	config := target.Config
+
+	// Get PVC usage with disk info by executing df in the pod
+	usageInfo, err := target.K8s.GetPVCUsageWithDiskInfo(
+		ctx,
+		target.Application.Namespace,
+		config.PvcName,
+		config.PodLabelSelector,
+		config.ContainerName,
+		config.PvcMountPath,
+	)
+	if err != nil {
+		return applicationMetricValueModel.MetricValue{}, fmt.Errorf("failed to get PVC usage: %w", err)
+	}
+
+	return applicationMetricValueModel.MetricValue{
+		PvcCapacityBytes: usageInfo.CapacityBytes,
+		PvcUsedBytes:     usageInfo.UsedBytes,
+		PvcPercent:       usageInfo.Percent,
+	}, nil
+}
+
+func (c *pvcUsage) ValueFields() []string {
+	return []string{"pvc_capacity_bytes", "pvc_used_bytes", "pvc_percent"}
+}
+
+func (c *pvcUsage) Export(cfg applicationMetricModel.Configuration, v applicationMetricValueModel.MetricValue) []prometheus.Sample {
+	pvcLabel := prometheus.Label{Name: "pvc", Value: cfg.PvcName}
+	return []prometheus.Sample{
+		gauge("pvc_capacity_bytes", "Capacity of the persistent volume claim", float64(v.PvcCapacityBytes), pvcLabel),
+		gauge("pvc_used_bytes", "Bytes used on the persistent volume claim", float64(v.PvcUsedBytes), pvcLabel),
+		gauge("pvc_usage_percent", "Persistent volume claim usage as a percentage of its capacity", v.PvcPercent, pvcLabel),
+	}
+}
+
+// AllowMultiple allows one PvcUsage metric per volume of the application
+func (c *pvcUsage) AllowMultiple() bool {
+	return true
+}
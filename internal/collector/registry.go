@@ -0,0 +1,57 @@
+package collector
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+)
+
+var (
+	mu         sync.RWMutex
+	collectors = map[string]Collector{}
+)
+
+// Register adds a collector to the registry. It panics on duplicate names,
+// since that can only happen through a programming error.
+func Register(c Collector) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, exists := collectors[c.Name()]; exists {
+		panic(fmt.Sprintf("collector already registered: %s", c.Name()))
+	}
+	collectors[c.Name()] = c
+}
+
+// Get returns the collector registered for a metric type name
+func Get(name string) (Collector, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	c, ok := collectors[name]
+	return c, ok
+}
+
+// All returns every registered collector ordered by name
+func All() []Collector {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	all := make([]Collector, 0, len(collectors))
+	for _, c := range collectors {
+		all = append(all, c)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name() < all[j].Name() })
+	return all
+}
+
+// Validate checks a configuration against the collector registered for the metric type
+func Validate(metricTypeName string, cfg applicationMetricModel.Configuration) error {
+	c, ok := Get(metricTypeName)
+	if !ok {
+		return fmt.Errorf("unsupported metric type: %s", metricTypeName)
+	}
+	return c.Validate(cfg)
+}
@@ -7,48 +7,173 @@ import (
 	"k8s-monitoring-app/internal/env"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 	"go.elastic.co/apm/module/apmsql"
+	_ "go.elastic.co/apm/module/apmsql/pq"
+	_ "go.elastic.co/apm/module/apmsql/sqlite3"
 )
 
+// Supported DB_DRIVER values
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+)
+
+var (
+	dbMu sync.Mutex
+	db   *sql.DB
+)
+
+// DatabaseDriver returns the configured database backend
+func DatabaseDriver() string {
+	switch env.DB_DRIVER {
+	case "", "sqlite", "sqlite3":
+		return DriverSQLite
+	case "postgres", "postgresql":
+		return DriverPostgres
+	}
+	return env.DB_DRIVER
+}
+
+// Rebind rewrites the ? placeholders of a query into the $1, $2... form used by
+// PostgreSQL. Queries are written with ? and passed through Rebind, so the same
+// SQL runs on both backends. A ? inside a quoted string or identifier is kept
+// as is; any other ? is a placeholder, so queries can't use the PostgreSQL
+// jsonb ? operators.
+func Rebind(query string) string {
+	if DatabaseDriver() != DriverPostgres || !strings.Contains(query, "?") {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+	n := 0
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			// A doubled quote is an escaped one and toggles the quote twice
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '?':
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// ConnectDatabase returns the connection pool of the configured database. The
+// pool is opened on first use and shared by every caller.
 func ConnectDatabase() (*sql.DB, error) {
+	dbMu.Lock()
+	defer dbMu.Unlock()
+
+	if db != nil {
+		return db, nil
+	}
+
+	var conn *sql.DB
+	var err error
+	switch DatabaseDriver() {
+	case DriverSQLite:
+		conn, err = connectSQLite()
+	case DriverPostgres:
+		conn, err = connectPostgres()
+	default:
+		return nil, fmt.Errorf("unsupported DB_DRIVER %q, use sqlite or postgres", env.DB_DRIVER)
+	}
+	if err != nil {
+		if conn != nil {
+			conn.Close()
+		}
+		return nil, err
+	}
+
+	var count int
+	err = conn.QueryRow("SELECT 1 AS count;").Scan(&count)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to test database: %s", err.Error())
+	}
+	if count != 1 {
+		conn.Close()
+		return nil, errors.New("failed to return test database")
+	}
+
+	db = conn
+	return db, nil
+}
+
+func connectSQLite() (*sql.DB, error) {
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(env.DB_PATH)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create database directory: %s", err.Error())
 	}
 
-    db, err := apmsql.Open("sqlite3", env.DB_PATH)
-    if err != nil {
-        return db, fmt.Errorf("failed to connect to database: %s | %s", err.Error(), env.DB_PATH)
-    }
+	db, err := apmsql.Open("sqlite3", env.DB_PATH)
+	if err != nil {
+		return db, fmt.Errorf("failed to connect to database: %s | %s", err.Error(), env.DB_PATH)
+	}
 
-    // Limit connection pool to reduce concurrent writers (SQLite is single-writer)
-    db.SetMaxOpenConns(1)
-    db.SetMaxIdleConns(1)
+	// Limit connection pool to reduce concurrent writers (SQLite is single-writer)
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
 
-    // Improve concurrency and reduce lock contention
-    if _, err = db.Exec("PRAGMA journal_mode = WAL"); err != nil {
-        return db, fmt.Errorf("failed to set WAL mode: %s", err.Error())
-    }
-    if _, err = db.Exec("PRAGMA busy_timeout = 5000"); err != nil {
-        return db, fmt.Errorf("failed to set busy_timeout: %s", err.Error())
-    }
+	// Improve concurrency and reduce lock contention
+	if _, err = db.Exec("PRAGMA journal_mode = WAL"); err != nil {
+		return db, fmt.Errorf("failed to set WAL mode: %s", err.Error())
+	}
+	if _, err = db.Exec("PRAGMA busy_timeout = 5000"); err != nil {
+		return db, fmt.Errorf("failed to set busy_timeout: %s", err.Error())
+	}
 
 	// Enable foreign keys for SQLite
-    _, err = db.Exec("PRAGMA foreign_keys = ON")
-    if err != nil {
-        return db, fmt.Errorf("failed to enable foreign keys: %s", err.Error())
-    }
+	_, err = db.Exec("PRAGMA foreign_keys = ON")
+	if err != nil {
+		return db, fmt.Errorf("failed to enable foreign keys: %s", err.Error())
+	}
 
-	var count int
-	err = db.QueryRow("SELECT 1 AS count;").Scan(&count)
+	return db, nil
+}
+
+func connectPostgres() (*sql.DB, error) {
+	if env.DB_URL == "" {
+		return nil, errors.New("DB_URL is required when DB_DRIVER is postgres")
+	}
+
+	// The connection string may hold credentials, so it's never logged
+	db, err := apmsql.Open("postgres", env.DB_URL)
 	if err != nil {
-		return db, fmt.Errorf("failed to test database: %s | %s", err.Error(), env.DB_PATH)
+		return db, fmt.Errorf("failed to connect to database: %s", err.Error())
 	}
-	if count != 1 {
-		return nil, errors.New("failed to return test database")
+
+	db.SetMaxOpenConns(env.DB_MAX_OPEN_CONNS)
+	db.SetMaxIdleConns(env.DB_MAX_OPEN_CONNS)
+	db.SetConnMaxIdleTime(5 * time.Minute)
+
+	return db, nil
+}
+
+// JSONNumber returns the SQL expression reading a numeric field of a JSON
+// column. The field is inlined in the query, so callers must only pass known
+// field names.
+func JSONNumber(column, field string) string {
+	if DatabaseDriver() == DriverPostgres {
+		return fmt.Sprintf("CAST(%s->>'%s' AS DOUBLE PRECISION)", column, field)
 	}
-	return db, err
+	return fmt.Sprintf("CAST(json_extract(%s, '$.%s') AS REAL)", column, field)
 }
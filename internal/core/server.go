@@ -14,7 +14,7 @@ import (
 type HTTPServer struct {
 	Config *ApiServiceConfiguration
 	Api    *echo.Echo
-	SQLite *sql.DB
+	DB     *sql.DB
 }
 
 type ApiServiceConfiguration struct {
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -11,15 +12,53 @@ import (
 var (
     ENV                         string
     PORT                        int
+    DB_DRIVER                   string // Database backend: sqlite (default) or postgres
     DB_PATH                     string
+    DB_URL                      string // PostgreSQL connection string, used when DB_DRIVER is postgres
+    DB_MAX_OPEN_CONNS           int    // PostgreSQL connection pool size (default: 10)
     METRICS_RETENTION_DAYS      int
     METRICS_CLEANUP_INTERVAL    string
+    METRICS_ROLLUP_HOURLY_RETENTION_DAYS int // Days to keep hourly rollups (default: 90)
+    METRICS_ROLLUP_DAILY_RETENTION_DAYS  int // Days to keep daily rollups (default: 730)
     METRICS_COLLECTION_INTERVAL int // Collection interval in seconds (default: 60)
+    METRICS_COLLECTION_WORKERS  int // Number of metrics collected concurrently (default: 10)
+    METRICS_COLLECTION_TIMEOUT  int // Per-metric collection deadline in seconds (default: 30)
+    METRICS_READINESS_MISSED_INTERVALS int // Collection intervals a due metric may wait before /health/ready fails (default: 3)
+
+	// Leader Election Configuration
+	LEADER_ELECTION_ENABLED        bool
+	LEADER_ELECTION_NAMESPACE      string
+	LEADER_ELECTION_LEASE_NAME     string
+	LEADER_ELECTION_LEASE_DURATION int // Lease duration in seconds (default: 15)
 
 	// Slack Alerts Configuration
-	SLACK_WEBHOOK_URL          string
-	SLACK_ALERTS_ENABLED       bool
-	SLACK_ALERTS_DEDUP_MINUTES int // Deduplication window in minutes (default: 10)
+	SLACK_WEBHOOK_URL    string
+	SLACK_ALERTS_ENABLED bool
+	SLACK_SIGNING_SECRET string // Verifies Slack interactions; enables the alert action buttons
+	APP_URL              string // Public URL of the app, linked from the alerts
+
+	// Alert Channels Configuration (a channel is enabled by setting its destination)
+	ALERT_WEBHOOK_URL       string
+	ALERT_WEBHOOK_SECRET    string // HMAC-SHA256 key signing the webhook body (optional)
+	TEAMS_WEBHOOK_URL       string
+	GOOGLE_CHAT_WEBHOOK_URL string
+	DISCORD_WEBHOOK_URL     string
+	TELEGRAM_BOT_TOKEN      string
+	TELEGRAM_CHAT_ID        string
+	SMTP_HOST               string
+	SMTP_PORT               int // SMTP port (default: 587)
+	SMTP_USERNAME           string
+	SMTP_PASSWORD           string
+	SMTP_FROM               string
+	SMTP_TO                 string // Comma-separated list of alert recipients
+	PAGERDUTY_ROUTING_KEY   string // PagerDuty Events v2 integration key
+	OPSGENIE_API_KEY        string // Opsgenie API integration key
+	OPSGENIE_API_URL        string // Opsgenie API (default: US region)
+
+	// Alert Grouping Configuration
+	ALERT_GROUP_BY        string // project, namespace or node; alerts are sent one by one when empty
+	ALERT_GROUP_WINDOW    int    // Seconds alerts of a group are held before they are sent together (default: 30)
+	ALERT_DIGEST_SCHEDULE string // Cron schedule of the digest of unhealthy metrics; no digest when empty
 
     // OAuth Configuration
     GOOGLE_CLIENT_ID       string
@@ -65,11 +104,24 @@ func GetEnv() error {
 		PORT = 8080
 	}
 
+	DB_DRIVER = strings.ToLower(strings.TrimSpace(os.Getenv("DB_DRIVER")))
+	if DB_DRIVER == "" {
+		DB_DRIVER = "sqlite"
+	}
+
 	DB_PATH = os.Getenv("DB_PATH")
 	if DB_PATH == "" {
 		DB_PATH = "./data/k8s_monitoring.db"
 	}
 
+	DB_URL = os.Getenv("DB_URL")
+	DB_MAX_OPEN_CONNS = 10
+	if v := os.Getenv("DB_MAX_OPEN_CONNS"); v != "" {
+		if conns, err := strconv.Atoi(v); err == nil && conns > 0 {
+			DB_MAX_OPEN_CONNS = conns
+		}
+	}
+
 	// Slack Alerts Configuration
 	SLACK_WEBHOOK_URL = os.Getenv("SLACK_WEBHOOK_URL")
 	if v := os.Getenv("SLACK_ALERTS_ENABLED"); v != "" {
@@ -78,21 +130,40 @@ func GetEnv() error {
 	} else {
 		SLACK_ALERTS_ENABLED = false
 	}
+	SLACK_SIGNING_SECRET = os.Getenv("SLACK_SIGNING_SECRET")
+	APP_URL = strings.TrimSuffix(os.Getenv("APP_URL"), "/")
 
-	// Slack Alerts Deduplication Window
-	if v := os.Getenv("SLACK_ALERTS_DEDUP_MINUTES"); v != "" {
-		if minutes, err := strconv.Atoi(v); err == nil {
-			if minutes < 1 {
-				SLACK_ALERTS_DEDUP_MINUTES = 1
-			} else {
-				SLACK_ALERTS_DEDUP_MINUTES = minutes
-			}
-		} else {
-			SLACK_ALERTS_DEDUP_MINUTES = 10
-		}
-	} else {
-		SLACK_ALERTS_DEDUP_MINUTES = 10
+	// Alert Channels Configuration
+	ALERT_WEBHOOK_URL = os.Getenv("ALERT_WEBHOOK_URL")
+	ALERT_WEBHOOK_SECRET = os.Getenv("ALERT_WEBHOOK_SECRET")
+	TEAMS_WEBHOOK_URL = os.Getenv("TEAMS_WEBHOOK_URL")
+	GOOGLE_CHAT_WEBHOOK_URL = os.Getenv("GOOGLE_CHAT_WEBHOOK_URL")
+	DISCORD_WEBHOOK_URL = os.Getenv("DISCORD_WEBHOOK_URL")
+	TELEGRAM_BOT_TOKEN = os.Getenv("TELEGRAM_BOT_TOKEN")
+	TELEGRAM_CHAT_ID = os.Getenv("TELEGRAM_CHAT_ID")
+	SMTP_HOST = os.Getenv("SMTP_HOST")
+	SMTP_PORT = 587
+	if port, err := strconv.Atoi(os.Getenv("SMTP_PORT")); err == nil && port > 0 {
+		SMTP_PORT = port
 	}
+	SMTP_USERNAME = os.Getenv("SMTP_USERNAME")
+	SMTP_PASSWORD = os.Getenv("SMTP_PASSWORD")
+	SMTP_FROM = os.Getenv("SMTP_FROM")
+	if SMTP_FROM == "" {
+		SMTP_FROM = SMTP_USERNAME
+	}
+	SMTP_TO = os.Getenv("SMTP_TO")
+	PAGERDUTY_ROUTING_KEY = os.Getenv("PAGERDUTY_ROUTING_KEY")
+	OPSGENIE_API_KEY = os.Getenv("OPSGENIE_API_KEY")
+	OPSGENIE_API_URL = os.Getenv("OPSGENIE_API_URL")
+
+	// Alert grouping configuration
+	ALERT_GROUP_BY = strings.ToLower(strings.TrimSpace(os.Getenv("ALERT_GROUP_BY")))
+	ALERT_GROUP_WINDOW = 30
+	if seconds, err := strconv.Atoi(os.Getenv("ALERT_GROUP_WINDOW")); err == nil && seconds > 0 {
+		ALERT_GROUP_WINDOW = seconds
+	}
+	ALERT_DIGEST_SCHEDULE = os.Getenv("ALERT_DIGEST_SCHEDULE")
 
     // OAuth Configuration
     GOOGLE_CLIENT_ID = os.Getenv("GOOGLE_CLIENT_ID")
@@ -113,6 +184,16 @@ func GetEnv() error {
 		}
 	}
 
+	// Rollup retention per resolution (default: 90 days hourly, 730 days daily)
+	METRICS_ROLLUP_HOURLY_RETENTION_DAYS = 90
+	if days, err := strconv.Atoi(os.Getenv("METRICS_ROLLUP_HOURLY_RETENTION_DAYS")); err == nil && days > 0 {
+		METRICS_ROLLUP_HOURLY_RETENTION_DAYS = days
+	}
+	METRICS_ROLLUP_DAILY_RETENTION_DAYS = 730
+	if days, err := strconv.Atoi(os.Getenv("METRICS_ROLLUP_DAILY_RETENTION_DAYS")); err == nil && days > 0 {
+		METRICS_ROLLUP_DAILY_RETENTION_DAYS = days
+	}
+
 	// Metrics cleanup interval (default: daily at 2 AM)
 	METRICS_CLEANUP_INTERVAL = os.Getenv("METRICS_CLEANUP_INTERVAL")
 	if METRICS_CLEANUP_INTERVAL == "" {
@@ -136,5 +217,79 @@ func GetEnv() error {
 		}
 	}
 
+	// Metrics collection worker pool size (default: 10)
+	collectionWorkers := os.Getenv("METRICS_COLLECTION_WORKERS")
+	if collectionWorkers == "" {
+		METRICS_COLLECTION_WORKERS = 10
+	} else {
+		if workers, err := strconv.Atoi(collectionWorkers); err == nil && workers > 0 {
+			METRICS_COLLECTION_WORKERS = workers
+		} else {
+			METRICS_COLLECTION_WORKERS = 10
+		}
+	}
+
+	// Per-metric collection deadline in seconds (default: 30)
+	collectionTimeout := os.Getenv("METRICS_COLLECTION_TIMEOUT")
+	if collectionTimeout == "" {
+		METRICS_COLLECTION_TIMEOUT = 30
+	} else {
+		if seconds, err := strconv.Atoi(collectionTimeout); err == nil && seconds > 0 {
+			METRICS_COLLECTION_TIMEOUT = seconds
+		} else {
+			METRICS_COLLECTION_TIMEOUT = 30
+		}
+	}
+
+	// Leader election: only the replica holding the Lease collects metrics
+	if v := os.Getenv("LEADER_ELECTION_ENABLED"); v != "" {
+		LEADER_ELECTION_ENABLED = v == "1" || v == "true" || v == "TRUE" || v == "True"
+	} else {
+		LEADER_ELECTION_ENABLED = false
+	}
+
+	LEADER_ELECTION_NAMESPACE = os.Getenv("LEADER_ELECTION_NAMESPACE")
+	if LEADER_ELECTION_NAMESPACE == "" {
+		// Default to the namespace of the pod's service account
+		if ns, err := os.ReadFile("/var/run/secrets/kubernetes.io/serviceaccount/namespace"); err == nil && len(ns) > 0 {
+			LEADER_ELECTION_NAMESPACE = string(ns)
+		} else {
+			LEADER_ELECTION_NAMESPACE = "default"
+		}
+	}
+
+	LEADER_ELECTION_LEASE_NAME = os.Getenv("LEADER_ELECTION_LEASE_NAME")
+	if LEADER_ELECTION_LEASE_NAME == "" {
+		LEADER_ELECTION_LEASE_NAME = "k8s-monitoring-app"
+	}
+
+	leaseDuration := os.Getenv("LEADER_ELECTION_LEASE_DURATION")
+	if leaseDuration == "" {
+		LEADER_ELECTION_LEASE_DURATION = 15
+	} else {
+		if seconds, err := strconv.Atoi(leaseDuration); err == nil {
+			if seconds < 5 {
+				// Minimum 5 seconds so the leader has time to renew
+				LEADER_ELECTION_LEASE_DURATION = 5
+			} else {
+				LEADER_ELECTION_LEASE_DURATION = seconds
+			}
+		} else {
+			LEADER_ELECTION_LEASE_DURATION = 15
+		}
+	}
+
+	// Collection intervals a due metric may wait before readiness fails (default: 3)
+	missedIntervals := os.Getenv("METRICS_READINESS_MISSED_INTERVALS")
+	if missedIntervals == "" {
+		METRICS_READINESS_MISSED_INTERVALS = 3
+	} else {
+		if intervals, err := strconv.Atoi(missedIntervals); err == nil && intervals > 0 {
+			METRICS_READINESS_MISSED_INTERVALS = intervals
+		} else {
+			METRICS_READINESS_MISSED_INTERVALS = 3
+		}
+	}
+
 	return nil
 }
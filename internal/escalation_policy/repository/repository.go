@@ -0,0 +1,181 @@
+package escalation_policy
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s-monitoring-app/internal/core"
+	escalationPolicyModel "k8s-monitoring-app/pkg/escalation_policy/model"
+)
+
+// generateUUID generates a simple UUID v4
+func generateUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+type Repository interface {
+	Get(ctx context.Context, id string) (escalationPolicyModel.EscalationPolicy, error)
+	List(ctx context.Context) ([]escalationPolicyModel.EscalationPolicy, error)
+	Add(ctx context.Context, policy *escalationPolicyModel.EscalationPolicy) error
+	Update(ctx context.Context, policy *escalationPolicyModel.EscalationPolicy) error
+	// Delete removes a policy and detaches it from its projects
+	Delete(ctx context.Context, id string) error
+	GetDB() *sql.DB
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepo(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (repo *repository) GetDB() *sql.DB {
+	return repo.db
+}
+
+const selectPolicies = `
+	SELECT
+		id, name, description, steps, created_at, updated_at
+	FROM
+		escalation_policies`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPolicy(row scanner) (escalationPolicyModel.EscalationPolicy, error) {
+	policy := escalationPolicyModel.EscalationPolicy{}
+	var description sql.NullString
+	var stepsJSON []byte
+	err := row.Scan(&policy.ID, &policy.Name, &description, &stepsJSON, &policy.CreatedAt, &policy.UpdatedAt)
+	if err != nil {
+		return policy, err
+	}
+	policy.Description = description.String
+	if err := json.Unmarshal(stepsJSON, &policy.Steps); err != nil {
+		return policy, fmt.Errorf("failed to unmarshal escalation steps: %w", err)
+	}
+
+	return policy, nil
+}
+
+func (repo *repository) Get(ctx context.Context, id string) (escalationPolicyModel.EscalationPolicy, error) {
+	sqlString := fmt.Sprintf("%s WHERE id = ?", selectPolicies)
+
+	return scanPolicy(repo.db.QueryRowContext(ctx, core.Rebind(sqlString), id))
+}
+
+func (repo *repository) List(ctx context.Context) ([]escalationPolicyModel.EscalationPolicy, error) {
+	policies := []escalationPolicyModel.EscalationPolicy{}
+
+	sqlString := fmt.Sprintf("%s ORDER BY name", selectPolicies)
+
+	rows, err := repo.db.QueryContext(ctx, core.Rebind(sqlString))
+	if err != nil {
+		return policies, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		policy, err := scanPolicy(rows)
+		if err != nil {
+			return policies, err
+		}
+
+		policies = append(policies, policy)
+	}
+
+	return policies, rows.Err()
+}
+
+func (repo *repository) Add(ctx context.Context, policy *escalationPolicyModel.EscalationPolicy) error {
+	stepsJSON, err := json.Marshal(policy.Steps)
+	if err != nil {
+		return fmt.Errorf("failed to marshal escalation steps: %w", err)
+	}
+
+	policy.ID = generateUUID()
+	now := time.Now()
+	policy.CreatedAt = now
+	policy.UpdatedAt = now
+
+	sqlString := `INSERT INTO escalation_policies(
+		id, name, description, steps, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?)`
+
+	_, err = repo.db.ExecContext(ctx, core.Rebind(sqlString),
+		policy.ID, policy.Name, nullString(policy.Description), string(stepsJSON),
+		policy.CreatedAt, policy.UpdatedAt,
+	)
+	return err
+}
+
+func (repo *repository) Update(ctx context.Context, policy *escalationPolicyModel.EscalationPolicy) error {
+	stepsJSON, err := json.Marshal(policy.Steps)
+	if err != nil {
+		return fmt.Errorf("failed to marshal escalation steps: %w", err)
+	}
+
+	sqlString := `UPDATE escalation_policies SET name = ?, description = ?, steps = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`
+
+	result, err := repo.db.ExecContext(ctx, core.Rebind(sqlString),
+		policy.Name, nullString(policy.Description), string(stepsJSON), policy.ID)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (repo *repository) Delete(ctx context.Context, id string) error {
+	tx, err := repo.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback() // Will be no-op if tx.Commit() is called
+
+	detachProjectsSQL := `UPDATE projects SET escalation_policy_id = NULL WHERE escalation_policy_id = ?`
+	if _, err := tx.ExecContext(ctx, core.Rebind(detachProjectsSQL), id); err != nil {
+		return fmt.Errorf("failed to detach projects: %w", err)
+	}
+
+	deletePolicySQL := `DELETE FROM escalation_policies WHERE id = ?`
+	result, err := tx.ExecContext(ctx, core.Rebind(deletePolicySQL), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return tx.Commit()
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,167 @@
+package escalation_policy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"k8s-monitoring-app/internal/core"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	model "k8s-monitoring-app/pkg/escalation_policy/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+type service struct{}
+
+func NewService() model.Service {
+	return &service{}
+}
+
+func (s *service) Get(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	id := sc.Param("id")
+
+	if len(id) == 0 {
+		log.Error().Err(errors.New("id is empty")).Msg("error getting escalation policy")
+		return sc.String(http.StatusBadRequest, "invalid request")
+	}
+
+	policy, err := serverModel.ServerRepos.EscalationPolicy.Get(ctx, id)
+	if err != nil {
+		log.Error().Msg("error getting escalation policy")
+		return sc.String(http.StatusNotFound, "escalation policy not found")
+	}
+
+	return sc.JSON(http.StatusOK, policy)
+}
+
+func (s *service) List(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	policies, err := serverModel.ServerRepos.EscalationPolicy.List(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("error listing escalation policies")
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	}
+
+	return sc.JSON(http.StatusOK, policies)
+}
+
+func (s *service) Add(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	policy := model.EscalationPolicy{}
+	if err := sc.Bind(&policy); err != nil {
+		log.Error().Msg("error binding escalation policy")
+		return sc.String(http.StatusBadRequest, "invalid request body")
+	}
+
+	if err := validate(ctx, policy); err != nil {
+		return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid escalation policy",
+			"message": err.Error(),
+		})
+	}
+
+	if err := serverModel.ServerRepos.EscalationPolicy.Add(ctx, &policy); err != nil {
+		log.Error().Err(err).Msg("error add escalation policy")
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	}
+
+	return sc.JSON(http.StatusCreated, policy)
+}
+
+// Update replaces the name, description and steps of a policy. Omitted fields
+// are left unchanged. Alerts that already reached a step keep it.
+func (s *service) Update(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+	id := sc.Param("id")
+
+	existing, err := serverModel.ServerRepos.EscalationPolicy.Get(ctx, id)
+	if err != nil {
+		log.Error().Msg("error getting escalation policy")
+		return sc.String(http.StatusNotFound, "escalation policy not found")
+	}
+
+	body := struct {
+		Name        *string       `json:"name"`
+		Description *string       `json:"description"`
+		Steps       *[]model.Step `json:"steps"`
+	}{}
+	if err := sc.Bind(&body); err != nil {
+		log.Error().Msg("error binding escalation policy")
+		return sc.String(http.StatusBadRequest, "Invalid Request")
+	}
+
+	policy := existing
+	if body.Name != nil {
+		policy.Name = *body.Name
+	}
+	if body.Description != nil {
+		policy.Description = *body.Description
+	}
+	if body.Steps != nil {
+		policy.Steps = *body.Steps
+	}
+	if err := validate(ctx, policy); err != nil {
+		return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid escalation policy",
+			"message": err.Error(),
+		})
+	}
+
+	if err := serverModel.ServerRepos.EscalationPolicy.Update(ctx, &policy); err != nil {
+		log.Error().Err(err).Msg("error updating escalation policy")
+		return sc.String(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	updated, err := serverModel.ServerRepos.EscalationPolicy.Get(ctx, id)
+	if err != nil {
+		log.Error().Err(err).Msg("error getting escalation policy")
+		return sc.String(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	return sc.JSON(http.StatusOK, updated)
+}
+
+// Delete removes a policy. The alerts of the projects it was attached to stop
+// escalating.
+func (s *service) Delete(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+	id := sc.Param("id")
+
+	if len(id) == 0 {
+		log.Error().Err(errors.New("id is empty")).Msg("error deleting escalation policy")
+		return sc.String(http.StatusBadRequest, "Invalid Request")
+	}
+
+	err := serverModel.ServerRepos.EscalationPolicy.Delete(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sc.String(http.StatusNotFound, "escalation policy not found")
+		}
+		log.Error().Err(err).Str("id", id).Msg("error deleting escalation policy")
+		return sc.String(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	return sc.JSON(http.StatusOK, map[string]bool{"success": true})
+}
+
+// validate checks the steps of a policy and that their channels exist
+func validate(ctx context.Context, policy model.EscalationPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	for i, step := range policy.Steps {
+		for _, channelID := range step.NotificationChannelIDs {
+			if _, err := serverModel.ServerRepos.NotificationChannel.Get(ctx, channelID); err != nil {
+				return fmt.Errorf("step %d: notification channel %s not found", i+1, channelID)
+			}
+		}
+	}
+	return nil
+}
@@ -58,6 +58,11 @@ func NewClient() (*Client, error) {
 	}, nil
 }
 
+// Ping checks that the Kubernetes API server is reachable
+func (c *Client) Ping(ctx context.Context) error {
+	return c.clientset.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Error()
+}
+
 // getKubeConfig returns the kubernetes config
 // Priority:
 // 1. KUBECONFIG environment variable
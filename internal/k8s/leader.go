@@ -0,0 +1,45 @@
+package k8s
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// LeaderElectionConfig describes the Lease used to elect a single active replica
+type LeaderElectionConfig struct {
+	Namespace     string
+	LeaseName     string
+	Identity      string
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+}
+
+// NewLeaderElector builds an elector campaigning for the Lease. The Lease is
+// released when the elector's context is cancelled, so another replica can
+// take over right away.
+func (c *Client) NewLeaderElector(cfg LeaderElectionConfig, callbacks leaderelection.LeaderCallbacks) (*leaderelection.LeaderElector, error) {
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      cfg.LeaseName,
+			Namespace: cfg.Namespace,
+		},
+		Client: c.clientset.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: cfg.Identity,
+		},
+	}
+
+	return leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		Name:            cfg.LeaseName,
+		LeaseDuration:   cfg.LeaseDuration,
+		RenewDeadline:   cfg.RenewDeadline,
+		RetryPeriod:     cfg.RetryPeriod,
+		ReleaseOnCancel: true,
+		Callbacks:       callbacks,
+	})
+}
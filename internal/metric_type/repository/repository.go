@@ -5,12 +5,14 @@ import (
 	"database/sql"
 	"fmt"
 
+	"k8s-monitoring-app/internal/core"
 	metricTypeModel "k8s-monitoring-app/pkg/metric_type/model"
 )
 
 type Repository interface {
 	Get(ctx context.Context, id string, customFieldName ...string) (metricTypeModel.MetricType, error)
 	List(ctx context.Context) ([]metricTypeModel.MetricType, error)
+	Upsert(ctx context.Context, metricType *metricTypeModel.MetricType) error
 	GetDB() *sql.DB
 }
 
@@ -44,7 +46,7 @@ func (repo *repository) Get(ctx context.Context, id string, customFieldName ...s
 		sqlString = fmt.Sprintf("%s mt.id = ?", sqlString)
 	}
 
-	err := repo.db.QueryRowContext(ctx, sqlString, id).Scan(
+	err := repo.db.QueryRowContext(ctx, core.Rebind(sqlString), id).Scan(
 		&metricType.ID, &metricType.Name, &metricType.Description,
 		&metricType.CreatedAt, &metricType.UpdatedAt)
 
@@ -85,3 +87,19 @@ func (repo *repository) List(ctx context.Context) ([]metricTypeModel.MetricType,
 
 	return metricTypes, nil
 }
+
+// Upsert inserts a metric type by name, refreshing the description when it changed
+func (repo *repository) Upsert(ctx context.Context, metricType *metricTypeModel.MetricType) error {
+	sqlString := `INSERT INTO metric_types(
+		name, description
+		) VALUES (?, ?)
+		ON CONFLICT(name) DO UPDATE SET description = excluded.description
+		WHERE metric_types.description <> excluded.description`
+
+	_, err := repo.db.ExecContext(ctx, core.Rebind(sqlString), metricType.Name, metricType.Description)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package monitoring
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"k8s-monitoring-app/internal/alerts"
+	"k8s-monitoring-app/internal/core"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	alertHistoryModel "k8s-monitoring-app/pkg/alert_history/model"
+	alertStateModel "k8s-monitoring-app/pkg/alert_state/model"
+	applicationModel "k8s-monitoring-app/pkg/application/model"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+	metricTypeModel "k8s-monitoring-app/pkg/metric_type/model"
+	silenceModel "k8s-monitoring-app/pkg/silence/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+// observeAlert moves the alert state of an application metric with the outcome
+// of a collection. The firing alert is sent once per problem, retried on the
+// next failures until a channel accepts it, and a resolved alert follows when
+// the metric has recovered for recover_after collections.
+func (m *MonitoringService) observeAlert(
+	ctx context.Context,
+	application *applicationModel.Application,
+	metricType *metricTypeModel.MetricType,
+	appMetric *applicationMetricModel.ApplicationMetric,
+	problem bool,
+	title string,
+	reason string,
+	severity string,
+	value *applicationMetricValueModel.MetricValue,
+) {
+	now := time.Now()
+	state, previous, err := m.evaluator.observe(ctx, appMetric, metricType.Name, problem, reason, severity, now)
+	if err != nil {
+		log.Warn().Err(err).Str("application_metric_id", appMetric.ID).Msg("failed to evaluate alert state")
+		return
+	}
+	switch {
+	case problem && state.State == alertStateModel.StatePending:
+		log.Debug().
+			Str("application", application.Name).
+			Str("metric_type", metricType.Name).
+			Int("consecutive_failures", state.ConsecutiveFailures).
+			Msg("alert pending, failure threshold not met yet")
+
+	case problem && state.State == alertStateModel.StateFiring && state.NotifiedAt == nil:
+		// The flapping alert stands for the problems of a flapping metric
+		if state.Flapping {
+			log.Debug().
+				Str("application", application.Name).
+				Str("metric_type", metricType.Name).
+				Float64("flap_score", state.FlapScore).
+				Msg("metric flapping, not sending its alert")
+			return
+		}
+
+		alertID := m.recordAlert(ctx, application, metricType, appMetric, state, title)
+
+		// A silenced alert stays recorded as firing and is delivered once the
+		// silence is over if the metric is still failing
+		if silence := activeSilence(ctx, application, appMetric, now); silence != nil {
+			m.silenced(application, metricType, silence, "firing")
+			if state.SilenceID != silence.ID {
+				err := m.evaluator.update(ctx, appMetric.ID, func(s *alertStateModel.AlertState) { s.SilenceID = silence.ID })
+				if err != nil {
+					log.Warn().Err(err).Str("application_metric_id", appMetric.ID).Msg("failed to record alert silence")
+				}
+				silenceAlertHistory(ctx, alertID, silence.ID)
+			}
+			return
+		}
+
+		// Someone is already on the problem: stop retrying until it resolves
+		if alertAcknowledged(ctx, alertID) {
+			log.Debug().
+				Str("application", application.Name).
+				Str("metric_type", metricType.Name).
+				Str("alert_id", alertID).
+				Msg("alert acknowledged, not sending it again")
+			return
+		}
+
+		// Best-effort: failed deliveries are logged but don't block collection
+		alert := newAlert(ctx, title, application, metricType, reason)
+		alert.ID = alertID
+		alert.ApplicationMetricID = appMetric.ID
+		alert.Severity = severity
+		alert.StartedAt = *state.StartedAt
+		m.deliver(ctx, application, appMetric, value, alert, func(ctx context.Context, deliveries []alertHistoryModel.Delivery) {
+			recordDeliveries(ctx, alertID, deliveries)
+			if !delivered(deliveries) {
+				return
+			}
+			err := m.evaluator.update(ctx, appMetric.ID, func(s *alertStateModel.AlertState) {
+				s.NotifiedAt = &now
+				s.SilenceID = ""
+			})
+			if err != nil {
+				log.Warn().Err(err).Str("application_metric_id", appMetric.ID).Msg("failed to mark alert notified")
+			}
+		})
+
+	case state.State == alertStateModel.StateResolved && previous == alertStateModel.StateFiring:
+		log.Info().
+			Str("application", application.Name).
+			Str("metric_type", metricType.Name).
+			Dur("duration", state.Duration(now)).
+			Msg("alert resolved")
+
+		resolveAlertHistory(ctx, state.AlertID, *state.ResolvedAt, state.Severity, state.Reason)
+
+		// A firing alert still held in its group is dropped along with the
+		// recovery: neither was announced
+		if m.dropHeld(state.AlertID, alerts.StatusFiring) {
+			log.Debug().
+				Str("application", application.Name).
+				Str("metric_type", metricType.Name).
+				Msg("metric recovered within the group window, dropping its held alert")
+			return
+		}
+
+		// Only announce the recovery of problems that were announced. Problems
+		// of a flapping metric never are.
+		if state.NotifiedAt == nil {
+			return
+		}
+		if silence := activeSilence(ctx, application, appMetric, now); silence != nil {
+			m.silenced(application, metricType, silence, "resolved")
+			return
+		}
+		alert := newAlert(ctx, "Metric recovered", application, metricType, state.Reason)
+		alert.Status = alerts.StatusResolved
+		alert.ID = state.AlertID
+		alert.ApplicationMetricID = appMetric.ID
+		alert.Severity = state.Severity
+		alert.StartedAt = *state.StartedAt
+		alert.Time = now
+		alertID := state.AlertID
+		m.deliver(ctx, application, appMetric, value, alert, func(ctx context.Context, deliveries []alertHistoryModel.Delivery) {
+			recordDeliveries(ctx, alertID, deliveries)
+		})
+		m.resolveEscalation(ctx, application, appMetric, value, alert)
+	}
+}
+
+// silenced logs and counts an alert held back by a silence
+func (m *MonitoringService) silenced(
+	application *applicationModel.Application,
+	metricType *metricTypeModel.MetricType,
+	silence *silenceModel.Silence,
+	status string,
+) {
+	m.engine.recordAlertSilenced()
+	log.Info().
+		Str("application", application.Name).
+		Str("metric_type", metricType.Name).
+		Str("status", status).
+		Str("silence_id", silence.ID).
+		Str("silence_reason", silence.Reason).
+		Msg("alert silenced")
+}
+
+// AlertState returns the alert state of an application metric. A metric that
+// never had a problem is reported as ok.
+func (m *MonitoringService) AlertState(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	id := sc.Param("id")
+	if len(id) == 0 {
+		log.Error().Msg("id is empty")
+		return sc.String(http.StatusBadRequest, "invalid request")
+	}
+
+	if _, err := serverModel.ServerRepos.ApplicationMetric.Get(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sc.String(http.StatusNotFound, "application metric not found")
+		}
+		log.Error().Err(err).Str("application_metric_id", id).Msg("error getting application metric")
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	}
+
+	state, err := serverModel.ServerRepos.AlertState.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Error().Err(err).Str("application_metric_id", id).Msg("error getting alert state")
+			return sc.String(http.StatusInternalServerError, "internal server error")
+		}
+		state = alertStateModel.AlertState{ApplicationMetricID: id, State: alertStateModel.StateOK}
+	}
+	state.DurationSeconds = int64(state.Duration(time.Now()).Seconds())
+
+	return sc.JSON(http.StatusOK, state)
+}
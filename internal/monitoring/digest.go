@@ -0,0 +1,152 @@
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s-monitoring-app/internal/alerts"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	alertStateModel "k8s-monitoring-app/pkg/alert_state/model"
+	applicationModel "k8s-monitoring-app/pkg/application/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+	metricTypeModel "k8s-monitoring-app/pkg/metric_type/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Certificate statuses reported in the digest
+const (
+	certificateExpiringSoon = "expiring_soon" // Expires within warning_days
+	certificateExpired      = "expired"
+)
+
+// digestTimeout bounds a digest run
+const digestTimeout = 2 * time.Minute
+
+// digest collects the entries of the channels of one route
+type digest struct {
+	notifiers []alerts.Notifier
+	entries   []alerts.Alert
+	firing    int
+	expiring  int
+}
+
+// sendDigest sends every channel a summary of what is unhealthy: the firing
+// alerts and the certificates expiring within their warning_days. Incident
+// channels are left out, and nothing is sent when all is healthy.
+func (m *MonitoringService) sendDigest() {
+	ctx, cancel := context.WithTimeout(context.Background(), digestTimeout)
+	defer cancel()
+
+	appMetrics, err := serverModel.ServerRepos.ApplicationMetric.List(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list application metrics for the alert digest")
+		return
+	}
+	states, err := serverModel.ServerRepos.AlertState.List(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list alert states for the alert digest")
+		return
+	}
+	latest, err := serverModel.ServerRepos.ApplicationMetricValue.ListLatest(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list latest metric values for the alert digest")
+		return
+	}
+
+	firing := map[string]alertStateModel.AlertState{}
+	for _, state := range states {
+		if state.State == alertStateModel.StateFiring {
+			firing[state.ApplicationMetricID] = state
+		}
+	}
+	values := map[string]applicationMetricValueModel.MetricValue{}
+	for _, v := range latest {
+		value := applicationMetricValueModel.MetricValue{}
+		if err := json.Unmarshal(v.Value, &value); err == nil {
+			values[v.ApplicationMetricID] = value
+		}
+	}
+
+	now := time.Now()
+	applications := map[string]*applicationModel.Application{}
+	metricTypes := map[string]*metricTypeModel.MetricType{}
+	digests := map[string]*digest{}
+	for _, appMetric := range appMetrics {
+		state, isFiring := firing[appMetric.ID]
+		value, hasValue := values[appMetric.ID]
+		isExpiring := hasValue && (value.CertificateStatus == certificateExpiringSoon || value.CertificateStatus == certificateExpired)
+		if !isFiring && !isExpiring {
+			continue
+		}
+
+		application, ok := applications[appMetric.ApplicationID]
+		if !ok {
+			a, err := serverModel.ServerRepos.Application.Get(ctx, appMetric.ApplicationID)
+			if err != nil {
+				log.Warn().Err(err).Str("application_id", appMetric.ApplicationID).Msg("failed to get application for the alert digest")
+				continue
+			}
+			application = &a
+			applications[appMetric.ApplicationID] = application
+		}
+		metricType, ok := metricTypes[appMetric.TypeID]
+		if !ok {
+			t, err := serverModel.ServerRepos.MetricType.Get(ctx, appMetric.TypeID)
+			if err != nil {
+				log.Warn().Err(err).Str("metric_type_id", appMetric.TypeID).Msg("failed to get metric type for the alert digest")
+				continue
+			}
+			metricType = &t
+			metricTypes[appMetric.TypeID] = metricType
+		}
+
+		notifiers, route := m.alertNotifiers(ctx, application)
+		d, ok := digests[route]
+		if !ok {
+			d = &digest{notifiers: notifiers}
+			digests[route] = d
+		}
+
+		entry := newAlert(ctx, "", application, metricType, "")
+		entry.ApplicationMetricID = appMetric.ID
+		entry.Time = now
+		if isFiring {
+			d.firing++
+			entry.Severity = state.Severity
+			entry.Reason = fmt.Sprintf("%s (for %s)", state.Reason, state.Duration(now))
+			if state.StartedAt != nil {
+				entry.StartedAt = *state.StartedAt
+			}
+		} else {
+			d.expiring++
+			entry.Severity = alerts.SeverityWarning
+			entry.Reason = fmt.Sprintf("certificate expires in %d days, on %s", value.CertificateDaysToExpire, value.CertificateExpiration.Format("2006-01-02"))
+			if value.CertificateStatus == certificateExpired {
+				entry.Severity = alerts.SeverityCritical
+				entry.Reason = fmt.Sprintf("certificate expired on %s", value.CertificateExpiration.Format("2006-01-02"))
+			}
+		}
+		d.entries = append(d.entries, entry)
+	}
+
+	for route, d := range digests {
+		channels := []alerts.Notifier{}
+		for _, notifier := range d.notifiers {
+			if !alerts.IsIncidentKind(notifier.Kind()) {
+				channels = append(channels, notifier)
+			}
+		}
+
+		title := fmt.Sprintf("Digest: %d firing alerts, %d certificates expiring", d.firing, d.expiring)
+		deliveries := m.notify(ctx, channels, alerts.Group(title, d.entries), nil)
+		log.Info().
+			Str("route", route).
+			Int("firing", d.firing).
+			Int("expiring_certificates", d.expiring).
+			Bool("delivered", delivered(deliveries)).
+			Msg("alert digest sent")
+	}
+}
@@ -0,0 +1,267 @@
+package monitoring
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s-monitoring-app/internal/alerts"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	alertHistoryModel "k8s-monitoring-app/pkg/alert_history/model"
+	alertStateModel "k8s-monitoring-app/pkg/alert_state/model"
+	applicationModel "k8s-monitoring-app/pkg/application/model"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+	escalationPolicyModel "k8s-monitoring-app/pkg/escalation_policy/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+// escalationSchedule checks the firing alerts every minute, the unit of the
+// step delays
+const escalationSchedule = "@every 1m"
+
+// escalationTimeout bounds an escalation run
+const escalationTimeout = 2 * time.Minute
+
+// escalateAlerts moves the firing alerts nobody acknowledged through the
+// escalation policy of their project. Steps missed while no replica was leading
+// are sent at once, in order.
+func (m *MonitoringService) escalateAlerts() {
+	ctx, cancel := context.WithTimeout(context.Background(), escalationTimeout)
+	defer cancel()
+
+	firing, err := serverModel.ServerRepos.AlertHistory.List(ctx, alertHistoryModel.Filter{Status: alertHistoryModel.StatusFiring})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list firing alerts for escalation")
+		return
+	}
+
+	now := time.Now()
+	policies := map[string]*escalationPolicyModel.EscalationPolicy{}
+	for _, alert := range firing {
+		if alert.Acknowledged() {
+			continue
+		}
+
+		policy, ok := policies[alert.ProjectID]
+		if !ok {
+			policy = projectEscalationPolicy(ctx, alert.ProjectID)
+			policies[alert.ProjectID] = policy
+		}
+		if policy == nil {
+			continue
+		}
+
+		if reached := policy.StepReached(now.Sub(alert.FiredAt)); reached > alert.EscalationStep {
+			m.escalate(ctx, alert, policy, reached, now)
+		}
+	}
+}
+
+// escalate records in the alert history the steps an alert reached since the
+// last escalation, then sends it to their channels
+func (m *MonitoringService) escalate(
+	ctx context.Context,
+	history alertHistoryModel.Alert,
+	policy *escalationPolicyModel.EscalationPolicy,
+	reached int,
+	now time.Time,
+) {
+	// Only the current problem of a metric escalates, and neither while the
+	// metric flaps nor while it is silenced
+	state, err := m.evaluator.get(ctx, history.ApplicationMetricID)
+	if err != nil {
+		log.Warn().Err(err).Str("alert_id", history.ID).Msg("failed to get alert state for escalation")
+		return
+	}
+	if state.AlertID != history.ID || state.State != alertStateModel.StateFiring || state.Flapping {
+		return
+	}
+
+	application, err := serverModel.ServerRepos.Application.Get(ctx, history.ApplicationID)
+	if err != nil {
+		log.Warn().Err(err).Str("application_id", history.ApplicationID).Msg("failed to get application for escalation")
+		return
+	}
+	appMetric, err := serverModel.ServerRepos.ApplicationMetric.Get(ctx, history.ApplicationMetricID)
+	if err != nil {
+		log.Warn().Err(err).Str("application_metric_id", history.ApplicationMetricID).Msg("failed to get application metric for escalation")
+		return
+	}
+	metricType, err := serverModel.ServerRepos.MetricType.Get(ctx, appMetric.TypeID)
+	if err != nil {
+		log.Warn().Err(err).Str("metric_type_id", appMetric.TypeID).Msg("failed to get metric type for escalation")
+		return
+	}
+	if silence := activeSilence(ctx, &application, &appMetric, now); silence != nil {
+		m.silenced(&application, &metricType, silence, "escalation")
+		return
+	}
+
+	// Claim the steps first: an acknowledgment or a resolution stored meanwhile,
+	// or another replica escalating the same alert, leaves nothing to send
+	err = serverModel.ServerRepos.AlertHistory.SetEscalation(ctx, history.ID, reached, now)
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Debug().Str("alert_id", history.ID).Msg("alert acknowledged, resolved or escalated meanwhile, not escalating")
+		return
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("alert_id", history.ID).Msg("failed to record alert escalation")
+		return
+	}
+
+	// The latest value is already stored, so newAlertMessage would also read it
+	// as the previous one: use the value stored before it instead
+	latest, previous := latestValues(ctx, appMetric.ID)
+	message := newAlertMessage(ctx, &application, &appMetric, latest)
+	if message != nil {
+		message.data.Previous = previous
+	}
+	seen := map[string]bool{}
+	for step := history.EscalationStep + 1; step <= reached; step++ {
+		delay := policy.Steps[step-1].DelayMinutes
+		alert := newAlert(ctx, "Alert escalated: "+history.Title, &application, &metricType,
+			fmt.Sprintf("%s (not acknowledged %d minutes after it fired)", history.Reason, delay))
+		alert.ID = history.ID
+		alert.ApplicationMetricID = history.ApplicationMetricID
+		alert.Severity = state.Severity
+		alert.StartedAt = history.StartedAt
+
+		deliveries := m.notify(ctx, stepNotifiers(ctx, policy.Steps[step-1], seen), alert, message)
+		for i := range deliveries {
+			deliveries[i].EscalationStep = step
+		}
+		recordDeliveries(ctx, history.ID, deliveries)
+
+		log.Info().
+			Str("application", application.Name).
+			Str("metric_type", metricType.Name).
+			Str("alert_id", history.ID).
+			Str("escalation_policy", policy.Name).
+			Int("step", step).
+			Int("channels", len(deliveries)).
+			Bool("delivered", delivered(deliveries)).
+			Msg("alert escalated")
+	}
+}
+
+// resolveEscalation sends the recovery of an escalated alert to the channels of
+// the steps it reached, so that e.g. the PagerDuty incident it opened resolves
+// too
+func (m *MonitoringService) resolveEscalation(
+	ctx context.Context,
+	application *applicationModel.Application,
+	appMetric *applicationMetricModel.ApplicationMetric,
+	value *applicationMetricValueModel.MetricValue,
+	alert alerts.Alert,
+) {
+	if alert.ID == "" {
+		return
+	}
+	history, err := serverModel.ServerRepos.AlertHistory.Get(ctx, alert.ID)
+	if err != nil {
+		log.Warn().Err(err).Str("alert_id", alert.ID).Msg("failed to get alert history")
+		return
+	}
+	if history.EscalationStep == 0 {
+		return
+	}
+	policy := projectEscalationPolicy(ctx, application.ProjectID)
+	if policy == nil {
+		log.Warn().Str("alert_id", alert.ID).Msg("escalation policy gone, not resolving the alert on its channels")
+		return
+	}
+
+	message := newAlertMessage(ctx, application, appMetric, value)
+	seen := map[string]bool{}
+	for step := 1; step <= history.EscalationStep && step <= len(policy.Steps); step++ {
+		deliveries := m.notify(ctx, stepNotifiers(ctx, policy.Steps[step-1], seen), alert, message)
+		for i := range deliveries {
+			deliveries[i].EscalationStep = step
+		}
+		recordDeliveries(ctx, alert.ID, deliveries)
+	}
+}
+
+// projectEscalationPolicy returns the escalation policy of a project, nil when
+// its alerts don't escalate
+func projectEscalationPolicy(ctx context.Context, projectID string) *escalationPolicyModel.EscalationPolicy {
+	project, err := serverModel.ServerRepos.Project.Get(ctx, projectID)
+	if err != nil {
+		log.Warn().Err(err).Str("project_id", projectID).Msg("failed to get project for escalation")
+		return nil
+	}
+	if project.EscalationPolicyID == nil || *project.EscalationPolicyID == "" {
+		return nil
+	}
+
+	policy, err := serverModel.ServerRepos.EscalationPolicy.Get(ctx, *project.EscalationPolicyID)
+	if err != nil {
+		log.Warn().Err(err).Str("escalation_policy_id", *project.EscalationPolicyID).Msg("failed to get escalation policy")
+		return nil
+	}
+	return &policy
+}
+
+// escalationChannelIDs returns the channels of the escalation steps of a
+// project
+func escalationChannelIDs(ctx context.Context, projectID string) map[string]bool {
+	ids := map[string]bool{}
+	if policy := projectEscalationPolicy(ctx, projectID); policy != nil {
+		for _, step := range policy.Steps {
+			for _, id := range step.NotificationChannelIDs {
+				ids[id] = true
+			}
+		}
+	}
+	return ids
+}
+
+// stepNotifiers returns the enabled channels of an escalation step, leaving out
+// the ones already seen
+func stepNotifiers(ctx context.Context, step escalationPolicyModel.Step, seen map[string]bool) []alerts.Notifier {
+	notifiers := []alerts.Notifier{}
+	for _, id := range step.NotificationChannelIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		channel, err := serverModel.ServerRepos.NotificationChannel.Get(ctx, id)
+		if err != nil {
+			log.Warn().Err(err).Str("notification_channel_id", id).Msg("skipping missing escalation channel")
+			continue
+		}
+		if !channel.IsEnabled() {
+			continue
+		}
+		notifier, err := channel.Notifier()
+		if err != nil {
+			log.Warn().Err(err).Str("notification_channel_id", id).Msg("skipping misconfigured notification channel")
+			continue
+		}
+		notifiers = append(notifiers, notifier)
+	}
+	return notifiers
+}
+
+// latestValues returns the latest stored value of a metric and the one stored
+// before it, nil when there is none
+func latestValues(ctx context.Context, applicationMetricID string) (latest, previous *applicationMetricValueModel.MetricValue) {
+	stored, err := serverModel.ServerRepos.ApplicationMetricValue.ListByApplicationMetric(ctx, applicationMetricID, 2)
+	if err != nil {
+		return nil, nil
+	}
+	values := make([]*applicationMetricValueModel.MetricValue, 2)
+	for i := range stored {
+		value := applicationMetricValueModel.MetricValue{}
+		if err := json.Unmarshal(stored[i].Value, &value); err == nil {
+			values[i] = &value
+		}
+	}
+	return values[0], values[1]
+}
@@ -0,0 +1,173 @@
+package monitoring
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s-monitoring-app/internal/collector"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	alertStateModel "k8s-monitoring-app/pkg/alert_state/model"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+)
+
+// defaultFailAfter returns the consecutive failures a metric needs before its
+// alert fires when it has no fail_after of its own. HealthCheck waits for 3 to
+// ride out transient network issues.
+func defaultFailAfter(metricTypeName string) int {
+	if metricTypeName == "HealthCheck" {
+		return 3
+	}
+	return 1
+}
+
+// alertThresholds returns the consecutive failures before the alert of a
+// metric fires and the consecutive healthy values before it resolves
+func alertThresholds(appMetric *applicationMetricModel.ApplicationMetric, metricTypeName string) (int, int) {
+	failAfter, recoverAfter := defaultFailAfter(metricTypeName), 1
+	if appMetric.FailAfter != nil && *appMetric.FailAfter > 0 {
+		failAfter = *appMetric.FailAfter
+	}
+	if appMetric.RecoverAfter != nil && *appMetric.RecoverAfter > 0 {
+		recoverAfter = *appMetric.RecoverAfter
+	}
+	return failAfter, recoverAfter
+}
+
+// failureAlerts reports whether the failures the collector finds in the values
+// of a metric fire an alert: the metric's failure_alerts, else the default of
+// the collector
+func failureAlerts(c collector.Collector, appMetric *applicationMetricModel.ApplicationMetric) bool {
+	if appMetric.FailureAlerts != nil {
+		return *appMetric.FailureAlerts
+	}
+	return c.FailureAlerts()
+}
+
+// evaluateFailure reports whether a collected value is a failure that fires an
+// alert, and why
+func evaluateFailure(c collector.Collector, appMetric *applicationMetricModel.ApplicationMetric, value applicationMetricValueModel.MetricValue) (bool, string) {
+	if !failureAlerts(c, appMetric) {
+		return false, ""
+	}
+	return c.EvaluateAlert(value)
+}
+
+// alertEvaluator moves the alert state of every metric type with the outcome
+// of each collection. The states are kept in memory, loaded from the database
+// the first time a metric is seen and written back on every change, so the
+// consecutive counts survive restarts without reading past values again.
+type alertEvaluator struct {
+	mu     sync.Mutex
+	states map[string]*alertStateModel.AlertState
+}
+
+func newAlertEvaluator() *alertEvaluator {
+	return &alertEvaluator{states: map[string]*alertStateModel.AlertState{}}
+}
+
+// reset drops the states held in memory, so they are read again from the
+// database. Another replica may have moved them while this one wasn't collecting.
+func (e *alertEvaluator) reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.states = map[string]*alertStateModel.AlertState{}
+}
+
+// forget drops the state of a deleted metric
+func (e *alertEvaluator) forget(applicationMetricID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.states, applicationMetricID)
+}
+
+// load returns the state of a metric, reading it from the database when it
+// isn't in memory yet. Callers hold e.mu.
+func (e *alertEvaluator) load(ctx context.Context, applicationMetricID string) (*alertStateModel.AlertState, error) {
+	if state, ok := e.states[applicationMetricID]; ok {
+		return state, nil
+	}
+
+	state, err := serverModel.ServerRepos.AlertState.Get(ctx, applicationMetricID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get alert state: %w", err)
+		}
+		state = alertStateModel.AlertState{ApplicationMetricID: applicationMetricID, State: alertStateModel.StateOK}
+	}
+	e.states[applicationMetricID] = &state
+	return &state, nil
+}
+
+// observe applies the outcome of a collection to the state of a metric,
+// following its fail_after and recover_after thresholds. It returns the new
+// state and the previous one.
+func (e *alertEvaluator) observe(
+	ctx context.Context,
+	appMetric *applicationMetricModel.ApplicationMetric,
+	metricTypeName string,
+	problem bool,
+	reason string,
+	severity string,
+	now time.Time,
+) (alertStateModel.AlertState, string, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	state, err := e.load(ctx, appMetric.ID)
+	if err != nil {
+		return alertStateModel.AlertState{}, "", err
+	}
+
+	// Nothing changes while a healthy metric stays healthy
+	if !problem && !state.Active() {
+		return *state, state.State, nil
+	}
+
+	failAfter, recoverAfter := alertThresholds(appMetric, metricTypeName)
+	next := *state
+	previous := next.Observe(problem, reason, severity, failAfter, recoverAfter, now)
+	if err := serverModel.ServerRepos.AlertState.Save(ctx, &next); err != nil {
+		return *state, state.State, fmt.Errorf("failed to save alert state: %w", err)
+	}
+	*state = next
+
+	return next, previous, nil
+}
+
+// get returns the state of a metric
+func (e *alertEvaluator) get(ctx context.Context, applicationMetricID string) (alertStateModel.AlertState, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	state, err := e.load(ctx, applicationMetricID)
+	if err != nil {
+		return alertStateModel.AlertState{}, err
+	}
+	return *state, nil
+}
+
+// update applies a change to the state of a metric outside of a collection
+// outcome, e.g. recording that its firing alert was delivered
+func (e *alertEvaluator) update(ctx context.Context, applicationMetricID string, change func(*alertStateModel.AlertState)) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	state, err := e.load(ctx, applicationMetricID)
+	if err != nil {
+		return err
+	}
+
+	next := *state
+	change(&next)
+	if err := serverModel.ServerRepos.AlertState.Save(ctx, &next); err != nil {
+		return fmt.Errorf("failed to save alert state: %w", err)
+	}
+	*state = next
+
+	return nil
+}
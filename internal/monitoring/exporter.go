@@ -0,0 +1,133 @@
+package monitoring
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"k8s-monitoring-app/internal/collector"
+	"k8s-monitoring-app/internal/core"
+	"k8s-monitoring-app/internal/prometheus"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	applicationModel "k8s-monitoring-app/pkg/application/model"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Metrics exposes the health of the monitoring engine and the latest value of
+// every application metric in the Prometheus text format
+func (m *MonitoringService) Metrics(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	samples, err := latestValueSamples(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("error building prometheus metrics")
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	}
+
+	var buf bytes.Buffer
+	if err := prometheus.Write(&buf, append(m.engineSamples(ctx), samples...)); err != nil {
+		log.Error().Err(err).Msg("error writing prometheus metrics")
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	}
+
+	return sc.Blob(http.StatusOK, prometheus.ContentType, buf.Bytes())
+}
+
+// latestValueSamples converts the latest value of every application metric into
+// gauges labeled with the project, application, namespace and metric type
+func latestValueSamples(ctx context.Context) ([]prometheus.Sample, error) {
+	projects, err := serverModel.ServerRepos.Project.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	projectNames := make(map[string]string, len(projects))
+	for _, project := range projects {
+		projectNames[project.ID] = project.Name
+	}
+
+	applications, err := serverModel.ServerRepos.Application.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	applicationsByID := make(map[string]applicationModel.Application, len(applications))
+	for _, application := range applications {
+		applicationsByID[application.ID] = application
+	}
+
+	metricTypes, err := serverModel.ServerRepos.MetricType.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list metric types: %w", err)
+	}
+	metricTypeNames := make(map[string]string, len(metricTypes))
+	for _, metricType := range metricTypes {
+		metricTypeNames[metricType.ID] = metricType.Name
+	}
+
+	applicationMetrics, err := serverModel.ServerRepos.ApplicationMetric.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list application metrics: %w", err)
+	}
+	appMetricsByID := make(map[string]applicationMetricModel.ApplicationMetric, len(applicationMetrics))
+	for _, appMetric := range applicationMetrics {
+		appMetricsByID[appMetric.ID] = appMetric
+	}
+
+	latestValues, err := serverModel.ServerRepos.ApplicationMetricValue.ListLatest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list latest metric values: %w", err)
+	}
+
+	var samples []prometheus.Sample
+	for _, latest := range latestValues {
+		appMetric, ok := appMetricsByID[latest.ApplicationMetricID]
+		if !ok {
+			continue
+		}
+		application, ok := applicationsByID[appMetric.ApplicationID]
+		if !ok {
+			continue
+		}
+		metricTypeName := metricTypeNames[appMetric.TypeID]
+		c, ok := collector.Get(metricTypeName)
+		if !ok {
+			continue
+		}
+
+		var value applicationMetricValueModel.MetricValue
+		if err := json.Unmarshal(latest.Value, &value); err != nil {
+			log.Warn().Err(err).Str("application_metric_id", appMetric.ID).Msg("skipping unreadable metric value")
+			continue
+		}
+		var cfg applicationMetricModel.Configuration
+		if err := json.Unmarshal(appMetric.Configuration, &cfg); err != nil {
+			log.Warn().Err(err).Str("application_metric_id", appMetric.ID).Msg("skipping metric with unreadable configuration")
+			continue
+		}
+
+		common := []prometheus.Label{
+			{Name: "project", Value: projectNames[application.ProjectID]},
+			{Name: "application", Value: application.Name},
+			{Name: "namespace", Value: application.Namespace},
+			{Name: "metric_type", Value: metricTypeName},
+		}
+
+		samples = append(samples, prometheus.Sample{
+			Name:   collector.MetricPrefix + "last_collected_timestamp_seconds",
+			Help:   "Unix timestamp of the latest collected value",
+			Labels: append(append([]prometheus.Label{}, common...), prometheus.Label{Name: "application_metric_id", Value: appMetric.ID}),
+			Value:  float64(latest.CreatedAt.Unix()),
+		})
+
+		for _, s := range c.Export(cfg, value) {
+			s.Labels = append(append([]prometheus.Label{}, common...), s.Labels...)
+			samples = append(samples, s)
+		}
+	}
+
+	return samples, nil
+}
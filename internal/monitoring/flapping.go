@@ -0,0 +1,274 @@
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s-monitoring-app/internal/alerts"
+	"k8s-monitoring-app/internal/collector"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	alertHistoryModel "k8s-monitoring-app/pkg/alert_history/model"
+	alertStateModel "k8s-monitoring-app/pkg/alert_state/model"
+	applicationModel "k8s-monitoring-app/pkg/application/model"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+	metricTypeModel "k8s-monitoring-app/pkg/metric_type/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Flapping detection looks at the health of the last flapWindow checks. A
+// metric starts flapping when its flap score reaches flapStartScore and stops
+// when it drops under flapStopScore, so that it doesn't toggle at the edge.
+const (
+	flapWindow     = 21
+	flapStartScore = 50.0
+	flapStopScore  = 25.0
+)
+
+// Titles of the flapping alerts
+const (
+	flappingTitle        = "Metric flapping"
+	flappingStoppedTitle = "Metric stopped flapping"
+)
+
+// flapScore returns the weighted share of health changes between consecutive
+// outcomes, oldest first, in percent, and the number of changes. Recent changes
+// weigh more: from 0.8 for the oldest to 1.2 for the newest.
+func flapScore(outcomes []bool) (float64, int) {
+	if len(outcomes) < 2 {
+		return 0, 0
+	}
+
+	var weighted, total float64
+	changes := 0
+	steps := len(outcomes) - 1
+	for i := 1; i < len(outcomes); i++ {
+		weight := 0.8
+		if steps > 1 {
+			weight += 0.4 * float64(i-1) / float64(steps-1)
+		}
+		total += weight
+		if outcomes[i] != outcomes[i-1] {
+			weighted += weight
+			changes++
+		}
+	}
+	return 100 * weighted / total, changes
+}
+
+// flapDetector keeps the health outcomes of the last checks of each metric.
+// They are loaded from the stored values the first time a metric is seen and
+// kept in memory afterwards.
+type flapDetector struct {
+	mu       sync.Mutex
+	outcomes map[string][]bool
+}
+
+func newFlapDetector() *flapDetector {
+	return &flapDetector{outcomes: map[string][]bool{}}
+}
+
+// reset drops the outcomes held in memory. Another replica may have collected
+// while this one wasn't.
+func (d *flapDetector) reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.outcomes = map[string][]bool{}
+}
+
+// forget drops the outcomes of a deleted metric
+func (d *flapDetector) forget(applicationMetricID string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.outcomes, applicationMetricID)
+}
+
+// observe adds the outcome of a collection, whose value isn't stored yet, and
+// returns the flap score of the metric and its health changes. ok is false
+// until the metric has a full window of checks.
+func (d *flapDetector) observe(
+	ctx context.Context,
+	applicationMetricID string,
+	c collector.Collector,
+	problem bool,
+) (score float64, changes int, ok bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	outcomes, loaded := d.outcomes[applicationMetricID]
+	if !loaded {
+		values, err := serverModel.ServerRepos.ApplicationMetricValue.ListByApplicationMetric(ctx, applicationMetricID, flapWindow-1)
+		if err != nil {
+			log.Warn().Err(err).Str("application_metric_id", applicationMetricID).Msg("failed to load past values for flapping detection")
+		}
+		// The values come newest first
+		for i := len(values) - 1; i >= 0; i-- {
+			value := applicationMetricValueModel.MetricValue{}
+			if err := json.Unmarshal(values[i].Value, &value); err != nil {
+				continue
+			}
+			failed, _ := c.EvaluateAlert(value)
+			outcomes = append(outcomes, failed)
+		}
+	}
+
+	outcomes = append(outcomes, problem)
+	if len(outcomes) > flapWindow {
+		outcomes = outcomes[len(outcomes)-flapWindow:]
+	}
+	d.outcomes[applicationMetricID] = outcomes
+
+	if len(outcomes) < flapWindow {
+		return 0, 0, false
+	}
+	score, changes = flapScore(outcomes)
+	return score, changes, true
+}
+
+// observeFlapping updates the flapping state of a metric with the health of a
+// collected value. While a metric flaps its alerts are held back: a single
+// flapping alert is sent when it starts flapping, and a resolved one when it
+// stops. Alert rules are left out: flapping is about the health the collector
+// reports, e.g. a health check going up and down.
+func (m *MonitoringService) observeFlapping(
+	ctx context.Context,
+	application *applicationModel.Application,
+	metricType *metricTypeModel.MetricType,
+	appMetric *applicationMetricModel.ApplicationMetric,
+	c collector.Collector,
+	value applicationMetricValueModel.MetricValue,
+) {
+	// With failure alerts off the metric counts as steady, so that a flapping
+	// state it was in winds down
+	problem, _ := evaluateFailure(c, appMetric, value)
+	score, changes, ok := m.flaps.observe(ctx, appMetric.ID, c, problem)
+	if !ok {
+		return
+	}
+
+	state, err := m.evaluator.get(ctx, appMetric.ID)
+	if err != nil {
+		log.Warn().Err(err).Str("application_metric_id", appMetric.ID).Msg("failed to get alert state for flapping detection")
+		return
+	}
+	// Nothing to save while a steady metric stays steady
+	if !state.Flapping && score < flapStartScore {
+		return
+	}
+
+	now := time.Now()
+	var started, stopped bool
+	err = m.evaluator.update(ctx, appMetric.ID, func(s *alertStateModel.AlertState) {
+		switch {
+		case !s.Flapping && score >= flapStartScore:
+			s.Flapping = true
+			s.FlappingSince = &now
+			s.FlapAlertID = ""
+			// The flapping alert stands for the problem announced so far: once
+			// the metric settles, a problem that remains is announced again
+			s.NotifiedAt = nil
+			started = true
+		case s.Flapping && score < flapStopScore:
+			s.Flapping = false
+			stopped = true
+		}
+		s.FlapScore = score
+		state = *s
+	})
+	if err != nil {
+		log.Warn().Err(err).Str("application_metric_id", appMetric.ID).Msg("failed to save flapping state")
+		return
+	}
+
+	switch {
+	case started:
+		log.Info().
+			Str("application", application.Name).
+			Str("metric_type", metricType.Name).
+			Float64("flap_score", score).
+			Int("changes", changes).
+			Msg("metric started flapping")
+
+		reason := fmt.Sprintf("Health changed %d times in the last %d checks (flap score %.0f%%)", changes, flapWindow, score)
+		alertID := m.recordFlapping(ctx, application, metricType, appMetric, reason, now)
+
+		if silence := activeSilence(ctx, application, appMetric, now); silence != nil {
+			m.silenced(application, metricType, silence, "flapping")
+			return
+		}
+
+		alert := newAlert(ctx, flappingTitle, application, metricType, reason)
+		alert.ID = alertID
+		alert.ApplicationMetricID = appMetric.ID
+		alert.Severity = alerts.SeverityWarning
+		alert.StartedAt = now
+		m.deliver(ctx, application, appMetric, &value, alert, func(ctx context.Context, deliveries []alertHistoryModel.Delivery) {
+			recordDeliveries(ctx, alertID, deliveries)
+		})
+
+	case stopped:
+		log.Info().
+			Str("application", application.Name).
+			Str("metric_type", metricType.Name).
+			Float64("flap_score", score).
+			Msg("metric stopped flapping")
+
+		alertID := state.FlapAlertID
+		resolveAlertHistory(ctx, alertID, now, "", "")
+
+		if silence := activeSilence(ctx, application, appMetric, now); silence != nil {
+			m.silenced(application, metricType, silence, "flapping resolved")
+			return
+		}
+
+		alert := newAlert(ctx, flappingStoppedTitle, application, metricType, fmt.Sprintf("Flap score down to %.0f%%", score))
+		alert.Status = alerts.StatusResolved
+		alert.ID = alertID
+		alert.ApplicationMetricID = appMetric.ID
+		alert.Severity = alerts.SeverityWarning
+		if state.FlappingSince != nil {
+			alert.StartedAt = *state.FlappingSince
+		}
+		alert.Time = now
+		m.deliver(ctx, application, appMetric, &value, alert, func(ctx context.Context, deliveries []alertHistoryModel.Delivery) {
+			recordDeliveries(ctx, alertID, deliveries)
+		})
+	}
+}
+
+// recordFlapping adds the history entry of a flapping alert and keeps its ID in
+// the alert state, returning it. It returns "" when the entry can't be stored.
+func (m *MonitoringService) recordFlapping(
+	ctx context.Context,
+	application *applicationModel.Application,
+	metricType *metricTypeModel.MetricType,
+	appMetric *applicationMetricModel.ApplicationMetric,
+	reason string,
+	now time.Time,
+) string {
+	alert := alertHistoryModel.Alert{
+		ApplicationMetricID: appMetric.ID,
+		ApplicationID:       application.ID,
+		ProjectID:           application.ProjectID,
+		MetricType:          metricType.Name,
+		Severity:            alerts.SeverityWarning,
+		Title:               flappingTitle,
+		Reason:              reason,
+		StartedAt:           now,
+		FiredAt:             now,
+	}
+	if err := serverModel.ServerRepos.AlertHistory.Add(ctx, &alert); err != nil {
+		log.Warn().Err(err).Str("application_metric_id", appMetric.ID).Msg("failed to record flapping alert history")
+		return ""
+	}
+
+	err := m.evaluator.update(ctx, appMetric.ID, func(s *alertStateModel.AlertState) { s.FlapAlertID = alert.ID })
+	if err != nil {
+		log.Warn().Err(err).Str("application_metric_id", appMetric.ID).Msg("failed to link flapping state to its history")
+	}
+	return alert.ID
+}
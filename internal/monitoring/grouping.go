@@ -0,0 +1,292 @@
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s-monitoring-app/internal/alerts"
+	"k8s-monitoring-app/internal/env"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	alertHistoryModel "k8s-monitoring-app/pkg/alert_history/model"
+	applicationModel "k8s-monitoring-app/pkg/application/model"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Alert grouping modes
+const (
+	groupByProject   = "project"
+	groupByNamespace = "namespace"
+	groupByNode      = "node"
+)
+
+// checkGroupBy fails on an unknown alert grouping mode
+func checkGroupBy() error {
+	switch env.ALERT_GROUP_BY {
+	case "", groupByProject, groupByNamespace, groupByNode:
+		return nil
+	}
+	return fmt.Errorf("invalid ALERT_GROUP_BY %q: use project, namespace or node", env.ALERT_GROUP_BY)
+}
+
+// groupWindow returns how long the alerts of a group are held
+func groupWindow() time.Duration {
+	return time.Duration(env.ALERT_GROUP_WINDOW) * time.Second
+}
+
+// pendingAlert is an alert held in its group until the group is sent
+type pendingAlert struct {
+	alert     alerts.Alert
+	notifiers []alerts.Notifier
+	message   *alertMessage
+	// sent gets the outcome of each channel once the alert went out
+	sent func(ctx context.Context, deliveries []alertHistoryModel.Delivery)
+}
+
+// alertGroup collects the alerts with something in common, e.g. the node their
+// application runs on, that go to the same channels with the same status
+type alertGroup struct {
+	label  string // e.g. "node worker-1"
+	alerts []pendingAlert
+}
+
+// deliver sends an alert to the channels of its application. When alerts are
+// grouped, the alert is held in its group for the group window and sent along
+// with the other alerts of the group.
+func (m *MonitoringService) deliver(
+	ctx context.Context,
+	application *applicationModel.Application,
+	appMetric *applicationMetricModel.ApplicationMetric,
+	value *applicationMetricValueModel.MetricValue,
+	alert alerts.Alert,
+	sent func(ctx context.Context, deliveries []alertHistoryModel.Delivery),
+) {
+	notifiers, route := m.alertNotifiers(ctx, application)
+	message := newAlertMessage(ctx, application, appMetric, value)
+	if env.ALERT_GROUP_BY == "" {
+		sent(ctx, m.notify(ctx, notifiers, alert, message))
+		return
+	}
+
+	key, label := alertGroupKey(ctx, application, alert, value)
+	m.hold(route+"|"+alert.Status+"|"+key, label, pendingAlert{
+		alert:     alert,
+		notifiers: notifiers,
+		message:   message,
+		sent:      sent,
+	})
+}
+
+// hold adds an alert to its group, starting the group window when the group is
+// new. A metric collected again within the window replaces its held alert.
+func (m *MonitoringService) hold(key, label string, pending pendingAlert) {
+	m.groupsMu.Lock()
+	defer m.groupsMu.Unlock()
+
+	group, ok := m.groups[key]
+	if !ok {
+		group = &alertGroup{label: label}
+		m.groups[key] = group
+		time.AfterFunc(groupWindow(), func() { m.flushGroup(key) })
+	}
+
+	for i, held := range group.alerts {
+		if held.alert.ApplicationMetricID == pending.alert.ApplicationMetricID {
+			group.alerts[i] = pending
+			return
+		}
+	}
+	group.alerts = append(group.alerts, pending)
+}
+
+// dropHeld removes a held alert with the given status from its group and
+// reports whether there was one. A problem that recovers within the group
+// window drops its firing alert: it was never announced, so neither is its
+// recovery.
+func (m *MonitoringService) dropHeld(alertID, status string) bool {
+	if alertID == "" {
+		return false
+	}
+
+	m.groupsMu.Lock()
+	defer m.groupsMu.Unlock()
+
+	for _, group := range m.groups {
+		for i, held := range group.alerts {
+			if held.alert.ID == alertID && held.alert.Status == status {
+				// The emptied group stays until its window ends, then sends nothing
+				group.alerts = append(group.alerts[:i], group.alerts[i+1:]...)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// flushGroup sends a group at the end of its window
+func (m *MonitoringService) flushGroup(key string) {
+	m.groupsMu.Lock()
+	group, ok := m.groups[key]
+	delete(m.groups, key)
+	m.groupsMu.Unlock()
+
+	if ok {
+		m.sendGroup(context.Background(), group)
+	}
+}
+
+// flushGroups sends every held group at once, so that no alert is lost when
+// this replica stops collecting
+func (m *MonitoringService) flushGroups() {
+	m.groupsMu.Lock()
+	groups := m.groups
+	m.groups = map[string]*alertGroup{}
+	m.groupsMu.Unlock()
+
+	for _, group := range groups {
+		m.sendGroup(context.Background(), group)
+	}
+}
+
+// sendGroup delivers the alerts of a group as a single message. A group of one
+// alert is sent as is. Incident channels still get one alert per metric, so
+// that each recovery resolves its own incident.
+func (m *MonitoringService) sendGroup(ctx context.Context, group *alertGroup) {
+	switch len(group.alerts) {
+	case 0:
+		return
+	case 1:
+		held := group.alerts[0]
+		held.sent(ctx, m.notify(ctx, held.notifiers, held.alert, held.message))
+		return
+	}
+
+	list := make([]alerts.Alert, 0, len(group.alerts))
+	deliveries := make([][]alertHistoryModel.Delivery, len(group.alerts))
+	for i, held := range group.alerts {
+		list = append(list, held.alert)
+
+		incidents := []alerts.Notifier{}
+		for _, notifier := range held.notifiers {
+			if alerts.IsIncidentKind(notifier.Kind()) {
+				incidents = append(incidents, notifier)
+			}
+		}
+		if len(incidents) > 0 {
+			deliveries[i] = m.notify(ctx, incidents, held.alert, held.message)
+		}
+	}
+
+	// The alerts of a group share their route, hence their channels
+	shared := []alerts.Notifier{}
+	for _, notifier := range group.alerts[0].notifiers {
+		if !alerts.IsIncidentKind(notifier.Kind()) {
+			shared = append(shared, notifier)
+		}
+	}
+
+	title := fmt.Sprintf("%d metrics failing on %s", len(list), group.label)
+	if list[0].Resolved() {
+		title = fmt.Sprintf("%d metrics recovered on %s", len(list), group.label)
+	}
+	log.Info().
+		Str("group", group.label).
+		Str("status", list[0].Status).
+		Int("alerts", len(list)).
+		Msg("sending grouped alerts")
+
+	grouped := m.notify(ctx, shared, alerts.Group(title, list), nil)
+	for i, held := range group.alerts {
+		held.sent(ctx, append(deliveries[i], grouped...))
+	}
+}
+
+// alertGroupKey returns the group of an alert under the grouping mode and a
+// label naming it in the grouped message
+func alertGroupKey(
+	ctx context.Context,
+	application *applicationModel.Application,
+	alert alerts.Alert,
+	value *applicationMetricValueModel.MetricValue,
+) (string, string) {
+	switch env.ALERT_GROUP_BY {
+	case groupByProject:
+		return "project:" + application.ProjectID, "project " + alert.Project
+	case groupByNamespace:
+		return "namespace:" + application.Namespace, "namespace " + application.Namespace
+	case groupByNode:
+		if node := applicationNode(ctx, application, value); node != "" {
+			return "node:" + node, "node " + node
+		}
+	}
+	// Without a known node, the alerts of an application are grouped together
+	return "application:" + application.ID, "application " + application.Name
+}
+
+// applicationNode returns the node an application runs on, "" when unknown.
+// Metrics without pod details, like health checks, take the node from the
+// latest pod details stored for the application.
+func applicationNode(
+	ctx context.Context,
+	application *applicationModel.Application,
+	value *applicationMetricValueModel.MetricValue,
+) string {
+	if node := valueNode(value); node != "" {
+		return node
+	}
+
+	appMetrics, err := serverModel.ServerRepos.ApplicationMetric.ListByApplication(ctx, application.ID)
+	if err != nil {
+		log.Warn().Err(err).Str("application_id", application.ID).Msg("failed to list application metrics for alert grouping")
+		return ""
+	}
+	for _, appMetric := range appMetrics {
+		latest, err := serverModel.ServerRepos.ApplicationMetricValue.ListByApplicationMetric(ctx, appMetric.ID, 1)
+		if err != nil || len(latest) == 0 {
+			continue
+		}
+		stored := applicationMetricValueModel.MetricValue{}
+		if err := json.Unmarshal(latest[0].Value, &stored); err != nil {
+			continue
+		}
+		if node := valueNode(&stored); node != "" {
+			return node
+		}
+	}
+	return ""
+}
+
+// valueNode returns the node of the pods in a metric value. A node that isn't
+// ready, or that runs a pod that isn't ready, comes first since it is the
+// likely cause of the alert.
+func valueNode(value *applicationMetricValueModel.MetricValue) string {
+	if value == nil {
+		return ""
+	}
+
+	for _, node := range value.Nodes {
+		if !node.Ready {
+			return node.Name
+		}
+	}
+	node := ""
+	for _, pod := range value.Pods {
+		if pod.NodeName == "" {
+			continue
+		}
+		if !pod.Ready {
+			return pod.NodeName
+		}
+		if node == "" {
+			node = pod.NodeName
+		}
+	}
+	if node == "" && len(value.NodeNames) > 0 {
+		node = value.NodeNames[0]
+	}
+	return node
+}
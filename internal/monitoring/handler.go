@@ -0,0 +1,130 @@
+package monitoring
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"k8s-monitoring-app/internal/collector"
+	"k8s-monitoring-app/internal/core"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Collect runs the collection of a single application metric right away,
+// through the same path as the scheduler, and returns the stored value. Alerts
+// are only evaluated on the replica that collects; the others store the value
+// and leave the alerts to it.
+func (m *MonitoringService) Collect(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	id := sc.Param("id")
+	if len(id) == 0 {
+		log.Error().Msg("id is empty")
+		return sc.String(http.StatusBadRequest, "invalid request")
+	}
+
+	appMetric, err := serverModel.ServerRepos.ApplicationMetric.Get(ctx, id)
+	if err != nil {
+		log.Error().Msg("error getting application metric")
+		return sc.String(http.StatusNotFound, "application metric not found")
+	}
+
+	value, result, err := m.collectApplicationMetric(ctx, appMetric, m.isCollecting())
+	switch {
+	case errors.Is(err, errMetricBusy):
+		return sc.JSON(http.StatusConflict, map[string]interface{}{
+			"error":   "collection in progress",
+			"message": err.Error(),
+		})
+	case result == resultSkipped:
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	case err != nil:
+		return sc.JSON(http.StatusBadGateway, map[string]interface{}{
+			"error":   "collection failed",
+			"message": err.Error(),
+		})
+	}
+
+	return sc.JSON(http.StatusOK, value)
+}
+
+// Test runs the collector once for an unsaved metric configuration, taking the
+// same payload as the application metric Add. Nothing is stored and no alert
+// is sent; the collected value or the collection error is returned.
+func (m *MonitoringService) Test(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	appMetric := applicationMetricModel.ApplicationMetric{}
+	if err := sc.Bind(&appMetric); err != nil {
+		log.Error().Msg("error binding application metric")
+		return sc.String(http.StatusBadRequest, "invalid request body")
+	}
+
+	// Validate that the application exists
+	application, err := serverModel.ServerRepos.Application.Get(ctx, appMetric.ApplicationID)
+	if err != nil {
+		log.Error().Msg("error getting application")
+		return sc.String(http.StatusBadRequest, "application not found")
+	}
+
+	// Validate that the metric type exists
+	metricType, err := serverModel.ServerRepos.MetricType.Get(ctx, appMetric.TypeID)
+	if err != nil {
+		log.Error().Msg("error getting metric type")
+		return sc.String(http.StatusBadRequest, "metric type not found")
+	}
+
+	var cfg applicationMetricModel.Configuration
+	if err := json.Unmarshal(appMetric.Configuration, &cfg); err != nil {
+		log.Warn().Err(err).
+			Str("application_id", appMetric.ApplicationID).
+			Str("metric_type", metricType.Name).
+			Msg("invalid metric configuration payload on test")
+		return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid configuration",
+			"message": "Configuration JSON does not match expected schema for '" + metricType.Name + "'",
+			"details": err.Error(),
+		})
+	}
+
+	if err := collector.Validate(metricType.Name, cfg); err != nil {
+		log.Warn().Err(err).
+			Str("application_id", appMetric.ApplicationID).
+			Str("metric_type", metricType.Name).
+			Msg("invalid metric configuration on test")
+		return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid configuration",
+			"message": err.Error(),
+		})
+	}
+
+	if appMetric.Schedule != nil {
+		if err := appMetric.Schedule.Validate(); err != nil {
+			log.Warn().Err(err).
+				Str("application_id", appMetric.ApplicationID).
+				Str("metric_type", metricType.Name).
+				Msg("invalid metric schedule on test")
+			return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+				"error":   "invalid schedule",
+				"message": err.Error(),
+			})
+		}
+	}
+
+	metricValue, err := m.testCollect(ctx, &application, metricType.Name, &cfg)
+	if err != nil {
+		log.Warn().Err(err).
+			Str("application", application.Name).
+			Str("metric_type", metricType.Name).
+			Msg("metric test collection failed")
+		return sc.JSON(http.StatusBadGateway, map[string]interface{}{
+			"error":   "collection failed",
+			"message": err.Error(),
+		})
+	}
+
+	return sc.JSON(http.StatusOK, metricValue)
+}
@@ -0,0 +1,281 @@
+package monitoring
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s-monitoring-app/internal/collector"
+	"k8s-monitoring-app/internal/core"
+	"k8s-monitoring-app/internal/env"
+	"k8s-monitoring-app/internal/prometheus"
+	monitoringModel "k8s-monitoring-app/pkg/monitoring/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+// kubernetesCheckTTL is how long a Kubernetes API probe result is reused
+const kubernetesCheckTTL = 15 * time.Second
+
+// engineMetrics tracks the health of the monitoring engine itself
+type engineMetrics struct {
+	mu sync.Mutex
+
+	lastSuccessAt    time.Time        // Last time a metric was collected successfully
+	collections      map[string]int64 // Collections per metric type
+	collectionErrors map[string]int64 // Failed collections per metric type
+
+	dbWrites        int64
+	dbWriteSeconds  float64
+	dbLastWriteSecs float64
+	alertDeliveries map[string]int64 // Alert deliveries per channel
+	alertFailures   map[string]int64 // Failed alert deliveries per channel
+	alertsSilenced  int64            // Alerts held back by a silence
+	k8sReachable    bool
+	k8sCheckedAt    time.Time
+	k8sError        string
+}
+
+func newEngineMetrics() *engineMetrics {
+	return &engineMetrics{
+		collections:      map[string]int64{},
+		collectionErrors: map[string]int64{},
+		alertDeliveries:  map[string]int64{},
+		alertFailures:    map[string]int64{},
+	}
+}
+
+// recordCollection counts a collection attempt of the given metric type
+func (e *engineMetrics) recordCollection(metricTypeName string, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.collections[metricTypeName]++
+	if err != nil {
+		e.collectionErrors[metricTypeName]++
+		return
+	}
+	e.lastSuccessAt = time.Now()
+}
+
+// recordDBWrite records how long storing a metric value took
+func (e *engineMetrics) recordDBWrite(d time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.dbWrites++
+	e.dbWriteSeconds += d.Seconds()
+	e.dbLastWriteSecs = d.Seconds()
+}
+
+// recordAlertDelivery counts an alert sent through the given channel
+func (e *engineMetrics) recordAlertDelivery(channel string, err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.alertDeliveries[channel]++
+	if err != nil {
+		e.alertFailures[channel]++
+	}
+}
+
+// recordAlertSilenced counts an alert held back by a silence
+func (e *engineMetrics) recordAlertSilenced() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.alertsSilenced++
+}
+
+// checkKubernetes probes the Kubernetes API, reusing recent results
+func (m *MonitoringService) checkKubernetes(ctx context.Context) (bool, string) {
+	m.engine.mu.Lock()
+	if time.Since(m.engine.k8sCheckedAt) < kubernetesCheckTTL {
+		reachable, msg := m.engine.k8sReachable, m.engine.k8sError
+		m.engine.mu.Unlock()
+		return reachable, msg
+	}
+	m.engine.mu.Unlock()
+
+	pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	err := m.k8sClient.Ping(pingCtx)
+
+	m.engine.mu.Lock()
+	defer m.engine.mu.Unlock()
+	m.engine.k8sCheckedAt = time.Now()
+	m.engine.k8sReachable = err == nil
+	m.engine.k8sError = ""
+	if err != nil {
+		m.engine.k8sError = err.Error()
+		log.Warn().Err(err).Msg("kubernetes API unreachable")
+	}
+	return m.engine.k8sReachable, m.engine.k8sError
+}
+
+// maxCollectionLag returns how long a due metric may wait before readiness fails
+func maxCollectionLag() time.Duration {
+	intervals := env.METRICS_READINESS_MISSED_INTERVALS
+	if intervals <= 0 {
+		intervals = 3
+	}
+	return time.Duration(intervals) * defaultInterval()
+}
+
+// collectionLag returns how long the most overdue metric has been waiting to
+// be collected. It keeps growing when the scheduler stalls or the worker pool
+// can't keep up, while metrics on long schedules are never counted as late.
+func (m *MonitoringService) collectionLag(now time.Time) time.Duration {
+	m.scheduleMu.Lock()
+	defer m.scheduleMu.Unlock()
+
+	var lag time.Duration
+	for _, entry := range m.schedule {
+		if overdue := now.Sub(entry.nextRun); overdue > lag {
+			lag = overdue
+		}
+	}
+	return lag
+}
+
+// Ready reports whether the database is usable and collection keeps up with
+// the schedule. Kubernetes API reachability is reported but doesn't fail
+// readiness, since the UI and API keep working without it.
+func (m *MonitoringService) Ready(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+	now := time.Now()
+
+	readiness := monitoringModel.Readiness{
+		Status: "ready",
+		Checks: map[string]monitoringModel.Check{},
+	}
+
+	if err := m.db.PingContext(ctx); err != nil {
+		readiness.Status = "not_ready"
+		readiness.Checks["database"] = monitoringModel.Check{Status: "fail", Message: err.Error()}
+	} else {
+		readiness.Checks["database"] = monitoringModel.Check{Status: "ok"}
+	}
+
+	lag, maxLag := m.collectionLag(now), maxCollectionLag()
+	readiness.CollectionLagSecs = int64(lag.Seconds())
+	readiness.MaxLagSecs = int64(maxLag.Seconds())
+	if lag > maxLag {
+		readiness.Status = "not_ready"
+		readiness.Checks["collection"] = monitoringModel.Check{
+			Status:  "fail",
+			Message: "collection has not completed within " + maxLag.String() + " of being due",
+		}
+	} else {
+		readiness.Checks["collection"] = monitoringModel.Check{Status: "ok"}
+	}
+
+	if reachable, msg := m.checkKubernetes(ctx); reachable {
+		readiness.Checks["kubernetes"] = monitoringModel.Check{Status: "ok"}
+	} else {
+		readiness.Checks["kubernetes"] = monitoringModel.Check{Status: "fail", Message: msg}
+	}
+
+	m.statsMu.RLock()
+	readiness.LastRun = m.lastRun
+	m.statsMu.RUnlock()
+
+	m.engine.mu.Lock()
+	if !m.engine.lastSuccessAt.IsZero() {
+		lastSuccessAt := m.engine.lastSuccessAt
+		readiness.LastSuccessAt = &lastSuccessAt
+	}
+	m.engine.mu.Unlock()
+
+	if readiness.Status != "ready" {
+		return sc.JSON(http.StatusServiceUnavailable, readiness)
+	}
+	return sc.JSON(http.StatusOK, readiness)
+}
+
+// engineSamples exposes the health of the monitoring engine as Prometheus metrics
+func (m *MonitoringService) engineSamples(ctx context.Context) []prometheus.Sample {
+	now := time.Now()
+	name := func(n string) string { return collector.MetricPrefix + n }
+
+	reachable, _ := m.checkKubernetes(ctx)
+	inFlight := 0
+	m.inFlight.Range(func(_, _ any) bool {
+		inFlight++
+		return true
+	})
+	m.scheduleMu.Lock()
+	scheduled := len(m.schedule)
+	m.scheduleMu.Unlock()
+
+	samples := []prometheus.Sample{
+		{Name: name("kubernetes_api_up"), Help: "Whether the Kubernetes API is reachable (1) or not (0)", Value: prometheus.Bool(reachable)},
+		{Name: name("collection_leader"), Help: "Whether this replica runs metric collection (1) or not (0)", Value: prometheus.Bool(m.isCollecting())},
+		{Name: name("collection_scheduled"), Help: "Application metrics tracked by the scheduler", Value: float64(scheduled)},
+		{Name: name("collection_in_flight"), Help: "Application metrics being collected right now", Value: float64(inFlight)},
+		{Name: name("collection_lag_seconds"), Help: "How long the most overdue metric has been waiting to be collected", Value: m.collectionLag(now).Seconds()},
+	}
+
+	m.statsMu.RLock()
+	lastRun, skippedRuns := m.lastRun, m.skippedRuns
+	m.statsMu.RUnlock()
+	samples = append(samples, prometheus.Sample{
+		Name: name("collection_skipped_runs_total"), Help: "Scheduler ticks skipped because the previous tick was still dispatching",
+		Type: prometheus.Counter, Value: float64(skippedRuns),
+	})
+	if lastRun != nil {
+		samples = append(samples,
+			prometheus.Sample{Name: name("collection_last_run_timestamp_seconds"), Help: "Unix timestamp of the last completed collection run", Value: float64(lastRun.FinishedAt.Unix())},
+			prometheus.Sample{Name: name("collection_last_run_duration_seconds"), Help: "Duration of the last completed collection run", Value: float64(lastRun.DurationMs) / 1000},
+		)
+	}
+
+	m.engine.mu.Lock()
+	defer m.engine.mu.Unlock()
+
+	if !m.engine.lastSuccessAt.IsZero() {
+		samples = append(samples, prometheus.Sample{
+			Name: name("collection_last_success_timestamp_seconds"), Help: "Unix timestamp of the last successful metric collection",
+			Value: float64(m.engine.lastSuccessAt.Unix()),
+		})
+	}
+
+	for _, metricTypeName := range sortedKeys(m.engine.collections) {
+		labels := []prometheus.Label{{Name: "metric_type", Value: metricTypeName}}
+		samples = append(samples,
+			prometheus.Sample{Name: name("collector_collections_total"), Help: "Collections attempted per metric type", Type: prometheus.Counter, Labels: labels, Value: float64(m.engine.collections[metricTypeName])},
+			prometheus.Sample{Name: name("collector_errors_total"), Help: "Failed collections per metric type", Type: prometheus.Counter, Labels: labels, Value: float64(m.engine.collectionErrors[metricTypeName])},
+		)
+	}
+
+	samples = append(samples,
+		prometheus.Sample{Name: name("db_write_duration_seconds_sum"), Help: "Total time spent storing metric values", Type: prometheus.Counter, Value: m.engine.dbWriteSeconds},
+		prometheus.Sample{Name: name("db_write_duration_seconds_count"), Help: "Number of metric values stored", Type: prometheus.Counter, Value: float64(m.engine.dbWrites)},
+		prometheus.Sample{Name: name("db_write_last_duration_seconds"), Help: "Time spent storing the latest metric value", Value: m.engine.dbLastWriteSecs},
+	)
+
+	for _, channel := range sortedKeys(m.engine.alertDeliveries) {
+		labels := []prometheus.Label{{Name: "channel", Value: channel}}
+		samples = append(samples,
+			prometheus.Sample{Name: name("alert_deliveries_total"), Help: "Alert deliveries attempted per channel", Type: prometheus.Counter, Labels: labels, Value: float64(m.engine.alertDeliveries[channel])},
+			prometheus.Sample{Name: name("alert_delivery_failures_total"), Help: "Failed alert deliveries per channel", Type: prometheus.Counter, Labels: labels, Value: float64(m.engine.alertFailures[channel])},
+		)
+	}
+	samples = append(samples, prometheus.Sample{
+		Name: name("alerts_silenced_total"), Help: "Alerts held back by a silence or maintenance window", Type: prometheus.Counter,
+		Value: float64(m.engine.alertsSilenced),
+	})
+
+	return samples
+}
+
+func sortedKeys(m map[string]int64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,130 @@
+package monitoring
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/url"
+	"time"
+
+	serverModel "k8s-monitoring-app/internal/server/model"
+	alertHistoryModel "k8s-monitoring-app/pkg/alert_history/model"
+	alertStateModel "k8s-monitoring-app/pkg/alert_state/model"
+	applicationModel "k8s-monitoring-app/pkg/application/model"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+	metricTypeModel "k8s-monitoring-app/pkg/metric_type/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+// recordAlert adds the history entry of a firing alert and keeps its ID in
+// the alert state, returning it. An alert already recorded keeps its entry.
+// The history is best-effort: it returns "" when the entry can't be stored.
+func (m *MonitoringService) recordAlert(
+	ctx context.Context,
+	application *applicationModel.Application,
+	metricType *metricTypeModel.MetricType,
+	appMetric *applicationMetricModel.ApplicationMetric,
+	state alertStateModel.AlertState,
+	title string,
+) string {
+	if state.AlertID != "" {
+		return state.AlertID
+	}
+
+	alert := alertHistoryModel.Alert{
+		ApplicationMetricID: appMetric.ID,
+		ApplicationID:       application.ID,
+		ProjectID:           application.ProjectID,
+		MetricType:          metricType.Name,
+		Severity:            state.Severity,
+		Title:               title,
+		Reason:              state.Reason,
+		StartedAt:           *state.StartedAt,
+		FiredAt:             *state.FiredAt,
+	}
+	if err := serverModel.ServerRepos.AlertHistory.Add(ctx, &alert); err != nil {
+		log.Warn().Err(err).Str("application_metric_id", appMetric.ID).Msg("failed to record alert history")
+		return ""
+	}
+
+	err := m.evaluator.update(ctx, appMetric.ID, func(s *alertStateModel.AlertState) { s.AlertID = alert.ID })
+	if err != nil {
+		log.Warn().Err(err).Str("application_metric_id", appMetric.ID).Msg("failed to link alert state to its history")
+	}
+	return alert.ID
+}
+
+// resolveAlertHistory marks the history entry of an alert resolved. Empty
+// severity and reason keep the recorded ones.
+func resolveAlertHistory(ctx context.Context, alertID string, resolvedAt time.Time, severity, reason string) {
+	if alertID == "" {
+		return
+	}
+
+	err := serverModel.ServerRepos.AlertHistory.Resolve(ctx, alertID, resolvedAt, severity, reason)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Warn().Err(err).Str("alert_id", alertID).Msg("failed to resolve alert history")
+	}
+}
+
+// silenceAlertHistory records the silence holding back an alert
+func silenceAlertHistory(ctx context.Context, alertID, silenceID string) {
+	if alertID == "" {
+		return
+	}
+
+	err := serverModel.ServerRepos.AlertHistory.SetSilence(ctx, alertID, silenceID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		log.Warn().Err(err).Str("alert_id", alertID).Msg("failed to record alert silence in history")
+	}
+}
+
+// alertAcknowledged reports whether someone acknowledged the alert. The ack is
+// read from the history so that it holds whichever replica collects.
+func alertAcknowledged(ctx context.Context, alertID string) bool {
+	if alertID == "" {
+		return false
+	}
+
+	alert, err := serverModel.ServerRepos.AlertHistory.Get(ctx, alertID)
+	if err != nil {
+		log.Warn().Err(err).Str("alert_id", alertID).Msg("failed to get alert history")
+		return false
+	}
+	return alert.Acknowledged()
+}
+
+// recordDeliveries stores the outcome of every channel an alert was sent to
+func recordDeliveries(ctx context.Context, alertID string, deliveries []alertHistoryModel.Delivery) {
+	if alertID == "" {
+		return
+	}
+
+	for _, delivery := range deliveries {
+		delivery.AlertID = alertID
+		if err := serverModel.ServerRepos.AlertHistory.AddDelivery(ctx, &delivery); err != nil {
+			log.Warn().Err(err).Str("alert_id", alertID).Str("channel", delivery.Channel).Msg("failed to record alert delivery")
+		}
+	}
+}
+
+// delivered reports whether at least one channel accepted an alert
+func delivered(deliveries []alertHistoryModel.Delivery) bool {
+	for _, delivery := range deliveries {
+		if delivery.Success {
+			return true
+		}
+	}
+	return false
+}
+
+// deliveryError describes a failed delivery for the history. Request errors
+// leave out the URL, which may carry a webhook secret or a bot token.
+func deliveryError(err error) string {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return "request failed: " + urlErr.Err.Error()
+	}
+	return err.Error()
+}
@@ -0,0 +1,132 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"k8s-monitoring-app/internal/env"
+	"k8s-monitoring-app/internal/k8s"
+
+	"github.com/rs/zerolog/log"
+	"k8s.io/client-go/tools/leaderelection"
+)
+
+// leaderElection tracks the Lease campaign of this replica
+type leaderElection struct {
+	config k8s.LeaderElectionConfig
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mu     sync.RWMutex
+	leader string // Identity of the current leader, as last observed
+}
+
+// leaderElectionConfig derives the Lease timings from the configured duration
+// using the same ratios as the client-go defaults (15s/10s/2s)
+func leaderElectionConfig() (k8s.LeaderElectionConfig, error) {
+	identity, err := os.Hostname()
+	if err != nil {
+		return k8s.LeaderElectionConfig{}, fmt.Errorf("failed to get hostname: %w", err)
+	}
+
+	leaseDuration := time.Duration(env.LEADER_ELECTION_LEASE_DURATION) * time.Second
+	if leaseDuration <= 0 {
+		leaseDuration = 15 * time.Second
+	}
+	retryPeriod := leaseDuration * 2 / 15
+	if retryPeriod < time.Second {
+		retryPeriod = time.Second
+	}
+
+	return k8s.LeaderElectionConfig{
+		Namespace:     env.LEADER_ELECTION_NAMESPACE,
+		LeaseName:     env.LEADER_ELECTION_LEASE_NAME,
+		Identity:      identity,
+		LeaseDuration: leaseDuration,
+		RenewDeadline: leaseDuration * 2 / 3,
+		RetryPeriod:   retryPeriod,
+	}, nil
+}
+
+// startLeaderElection campaigns for the Lease in the background. Collection
+// runs only while this replica holds it; the UI and API are served regardless.
+func (m *MonitoringService) startLeaderElection() error {
+	config, err := leaderElectionConfig()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	elector, err := m.k8sClient.NewLeaderElector(config, leaderelection.LeaderCallbacks{
+		OnStartedLeading: func(ctx context.Context) {
+			log.Info().Str("identity", config.Identity).Msg("Acquired leadership, starting metric collection")
+			if err := m.startCollection(); err != nil {
+				log.Error().Err(err).Msg("failed to start metric collection")
+			}
+		},
+		OnStoppedLeading: func() {
+			if m.isCollecting() && ctx.Err() == nil {
+				log.Warn().Str("identity", config.Identity).Msg("Lost leadership, stopping metric collection")
+			}
+			m.stopCollection()
+		},
+		OnNewLeader: func(identity string) {
+			m.election.mu.Lock()
+			m.election.leader = identity
+			m.election.mu.Unlock()
+			log.Info().Str("leader", identity).Msg("Monitoring leader elected")
+		},
+	})
+	if err != nil {
+		cancel()
+		return fmt.Errorf("failed to create leader elector: %w", err)
+	}
+
+	m.election = &leaderElection{
+		config: config,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		defer close(m.election.done)
+		// Run returns when leadership is lost; keep campaigning until stopped
+		for ctx.Err() == nil {
+			elector.Run(ctx)
+
+			select {
+			case <-ctx.Done():
+			case <-time.After(config.RetryPeriod):
+			}
+		}
+	}()
+
+	log.Info().
+		Str("namespace", config.Namespace).
+		Str("lease", config.LeaseName).
+		Str("identity", config.Identity).
+		Dur("lease_duration", config.LeaseDuration).
+		Msg("Leader election started")
+
+	return nil
+}
+
+// stopLeaderElection stops campaigning and releases the Lease if held
+func (m *MonitoringService) stopLeaderElection() {
+	m.election.cancel()
+	<-m.election.done
+}
+
+// leaderIdentity returns the identity of the current leader, if known
+func (m *MonitoringService) leaderIdentity() string {
+	if m.election == nil {
+		return ""
+	}
+	m.election.mu.RLock()
+	defer m.election.mu.RUnlock()
+	return m.election.leader
+}
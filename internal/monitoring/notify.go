@@ -0,0 +1,107 @@
+package monitoring
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s-monitoring-app/internal/alerts"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	alertHistoryModel "k8s-monitoring-app/pkg/alert_history/model"
+	applicationModel "k8s-monitoring-app/pkg/application/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+// routeDefault is the route of the alerts sent to the channels configured
+// through environment variables
+const routeDefault = "default"
+
+// alertNotifiers returns the channels the alerts of an application go to: its
+// own enabled channels when it has any, otherwise the enabled channels of its
+// project, otherwise the channels configured through environment variables.
+// The channels of the escalation steps of the project only get the alerts that
+// escalate. The route names that choice, so that alerts sent to the same
+// channels can be grouped.
+func (m *MonitoringService) alertNotifiers(ctx context.Context, application *applicationModel.Application) ([]alerts.Notifier, string) {
+	channels, err := serverModel.ServerRepos.NotificationChannel.ListByProject(ctx, application.ProjectID)
+	if err != nil {
+		log.Warn().Err(err).Str("project_id", application.ProjectID).Msg("failed to list notification channels, using the default channels")
+		return m.notifiers, routeDefault
+	}
+
+	escalation := escalationChannelIDs(ctx, application.ProjectID)
+	var own, project []alerts.Notifier
+	for _, channel := range channels {
+		if !channel.IsEnabled() || escalation[channel.ID] || (channel.ApplicationID != "" && channel.ApplicationID != application.ID) {
+			continue
+		}
+		notifier, err := channel.Notifier()
+		if err != nil {
+			log.Warn().Err(err).Str("notification_channel_id", channel.ID).Msg("skipping misconfigured notification channel")
+			continue
+		}
+		if channel.ApplicationID != "" {
+			own = append(own, notifier)
+		} else {
+			project = append(project, notifier)
+		}
+	}
+
+	switch {
+	case len(own) > 0:
+		return own, "application:" + application.ID
+	case len(project) > 0:
+		return project, "project:" + application.ProjectID
+	default:
+		return m.notifiers, routeDefault
+	}
+}
+
+// notify delivers an alert to the given channels at once and returns the
+// outcome of each, rendering the alert template of each channel when there is
+// one. A failing channel doesn't keep the others from being notified.
+func (m *MonitoringService) notify(ctx context.Context, notifiers []alerts.Notifier, alert alerts.Alert, message *alertMessage) []alertHistoryModel.Delivery {
+	if alert.Time.IsZero() {
+		alert.Time = time.Now()
+	}
+
+	// Skip the channels that don't take the alert, e.g. incident channels
+	// without a routing key for its severity
+	accepted := []alerts.Notifier{}
+	for _, notifier := range notifiers {
+		if alerts.Accepts(notifier, alert) {
+			accepted = append(accepted, notifier)
+		}
+	}
+	notifiers = accepted
+
+	var wg sync.WaitGroup
+	deliveries := make([]alertHistoryModel.Delivery, len(notifiers))
+	for i, notifier := range notifiers {
+		wg.Add(1)
+		go func(i int, notifier alerts.Notifier) {
+			defer wg.Done()
+
+			err := notifier.Notify(ctx, message.apply(alert, notifier.Kind()))
+			m.engine.recordAlertDelivery(notifier.Kind(), err)
+			deliveries[i] = alertHistoryModel.Delivery{
+				Status:  alert.Status,
+				Channel: notifier.Kind(),
+				Success: err == nil,
+				SentAt:  time.Now(),
+			}
+			if err != nil {
+				deliveries[i].Error = deliveryError(err)
+				log.Warn().Err(err).
+					Str("channel", notifier.Kind()).
+					Str("application", alert.Application).
+					Str("metric_type", alert.Metric).
+					Msg("failed to deliver alert")
+			}
+		}(i, notifier)
+	}
+	wg.Wait()
+
+	return deliveries
+}
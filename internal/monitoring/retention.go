@@ -0,0 +1,46 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	serverModel "k8s-monitoring-app/internal/server/model"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+)
+
+// rawRetentions returns how long the raw values of each application metric are
+// kept, by application metric ID, following the metric and project overrides
+func rawRetentions(ctx context.Context, applicationMetrics []applicationMetricModel.ApplicationMetric) (map[string]time.Duration, error) {
+	applications, err := serverModel.ServerRepos.Application.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	projects, err := serverModel.ServerRepos.Project.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	projectDays := make(map[string]int, len(projects))
+	for _, project := range projects {
+		if project.RetentionDays != nil {
+			projectDays[project.ID] = *project.RetentionDays
+		}
+	}
+	applicationProjects := make(map[string]string, len(applications))
+	for _, application := range applications {
+		applicationProjects[application.ID] = application.ProjectID
+	}
+
+	retentions := make(map[string]time.Duration, len(applicationMetrics))
+	for _, appMetric := range applicationMetrics {
+		metricDays := 0
+		if appMetric.RetentionDays != nil {
+			metricDays = *appMetric.RetentionDays
+		}
+		retentions[appMetric.ID] = applicationMetricValueModel.RawRetention(metricDays, projectDays[applicationProjects[appMetric.ApplicationID]])
+	}
+
+	return retentions, nil
+}
@@ -0,0 +1,272 @@
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s-monitoring-app/internal/collector"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+// rollupSchedule runs the rollup job shortly after every hour
+const rollupSchedule = "5 * * * *"
+
+// rollupMetrics compacts the raw values of every application metric into
+// hourly rollups, and the hourly rollups into daily ones. Only completed
+// buckets are rolled up, so each bucket is written once.
+func (m *MonitoringService) rollupMetrics() {
+	m.rollupMu.Lock()
+	defer m.rollupMu.Unlock()
+
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	applicationMetrics, err := serverModel.ServerRepos.ApplicationMetric.List(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list application metrics for rollup")
+		return
+	}
+
+	metricTypes, err := serverModel.ServerRepos.MetricType.List(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to list metric types for rollup")
+		return
+	}
+	metricTypeNames := make(map[string]string, len(metricTypes))
+	for _, metricType := range metricTypes {
+		metricTypeNames[metricType.ID] = metricType.Name
+	}
+
+	var hourly, daily int
+	for _, appMetric := range applicationMetrics {
+		c, _ := collector.Get(metricTypeNames[appMetric.TypeID])
+
+		n, err := rollupHours(ctx, appMetric, c, now)
+		hourly += n
+		if err != nil {
+			log.Error().Err(err).Str("application_metric_id", appMetric.ID).Msg("failed to build hourly rollups")
+			continue
+		}
+
+		n, err = rollupDays(ctx, appMetric, now)
+		daily += n
+		if err != nil {
+			log.Error().Err(err).Str("application_metric_id", appMetric.ID).Msg("failed to build daily rollups")
+		}
+	}
+
+	log.Info().
+		Int("hourly_rollups", hourly).
+		Int("daily_rollups", daily).
+		Dur("duration", time.Since(now)).
+		Msg("Metric rollup completed")
+}
+
+// rollupStart returns the first bucket to roll up: the one after the latest
+// rollup or, for a metric not rolled up yet, the one holding its oldest source
+// data. oldest returns the zero time when there is no source data, and then
+// the returned start is zero too.
+func rollupStart(ctx context.Context, applicationMetricID, resolution string, size time.Duration, oldest func(ctx context.Context, applicationMetricID string) (time.Time, error)) (time.Time, error) {
+	last, err := serverModel.ServerRepos.ApplicationMetricValue.LastRollup(ctx, applicationMetricID, resolution)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get last %s rollup: %w", resolution, err)
+	}
+	if !last.IsZero() {
+		return last.Add(size), nil
+	}
+
+	first, err := oldest(ctx, applicationMetricID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get oldest %s rollup source: %w", resolution, err)
+	}
+	return first.Truncate(size), nil
+}
+
+// rollupHours builds the hourly rollups of the completed hours not rolled up
+// yet
+func rollupHours(ctx context.Context, appMetric applicationMetricModel.ApplicationMetric, c collector.Collector, now time.Time) (int, error) {
+	start, err := rollupStart(ctx, appMetric.ID, applicationMetricValueModel.ResolutionHour, time.Hour,
+		serverModel.ServerRepos.ApplicationMetricValue.FirstValue)
+	if err != nil || start.IsZero() {
+		return 0, err
+	}
+	end := now.Truncate(time.Hour)
+
+	written := 0
+	// Read the raw values a day at a time to bound memory on the first run
+	for chunkStart := start; chunkStart.Before(end); {
+		chunkEnd := chunkStart.Add(24 * time.Hour)
+		if chunkEnd.After(end) {
+			chunkEnd = end
+		}
+
+		values, err := serverModel.ServerRepos.ApplicationMetricValue.ListRange(ctx, appMetric.ID, chunkStart, chunkEnd)
+		if err != nil {
+			return written, fmt.Errorf("failed to list metric values: %w", err)
+		}
+
+		for len(values) > 0 {
+			hour := values[0].CreatedAt.UTC().Truncate(time.Hour)
+			n := 1
+			for n < len(values) && values[n].CreatedAt.UTC().Truncate(time.Hour).Equal(hour) {
+				n++
+			}
+
+			rollup := hourlyRollup(appMetric.ID, hour, values[:n], c)
+			if err := serverModel.ServerRepos.ApplicationMetricValue.AddRollup(ctx, &rollup); err != nil {
+				return written, fmt.Errorf("failed to store hourly rollup: %w", err)
+			}
+			written++
+			values = values[n:]
+		}
+
+		chunkStart = chunkEnd
+	}
+
+	return written, nil
+}
+
+// rollupDays builds the daily rollups of the completed days not rolled up yet
+// from the hourly rollups
+func rollupDays(ctx context.Context, appMetric applicationMetricModel.ApplicationMetric, now time.Time) (int, error) {
+	const day = 24 * time.Hour
+
+	start, err := rollupStart(ctx, appMetric.ID, applicationMetricValueModel.ResolutionDay, day,
+		func(ctx context.Context, applicationMetricID string) (time.Time, error) {
+			return serverModel.ServerRepos.ApplicationMetricValue.FirstRollup(ctx, applicationMetricID, applicationMetricValueModel.ResolutionHour)
+		})
+	if err != nil || start.IsZero() {
+		return 0, err
+	}
+	end := now.Truncate(day)
+	if !start.Before(end) {
+		return 0, nil
+	}
+
+	hourly, err := serverModel.ServerRepos.ApplicationMetricValue.ListRollups(ctx, appMetric.ID, applicationMetricValueModel.ResolutionHour, start, end)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list hourly rollups: %w", err)
+	}
+
+	written := 0
+	for len(hourly) > 0 {
+		dayStart := hourly[0].BucketStart.Truncate(day)
+		n := 1
+		for n < len(hourly) && hourly[n].BucketStart.Truncate(day).Equal(dayStart) {
+			n++
+		}
+
+		rollup := mergeRollups(appMetric.ID, applicationMetricValueModel.ResolutionDay, dayStart, hourly[:n])
+		if err := serverModel.ServerRepos.ApplicationMetricValue.AddRollup(ctx, &rollup); err != nil {
+			return written, fmt.Errorf("failed to store daily rollup: %w", err)
+		}
+		written++
+		hourly = hourly[n:]
+	}
+
+	return written, nil
+}
+
+// hourlyRollup aggregates the raw values of one hour. A value is up when the
+// collector doesn't consider it a failure.
+func hourlyRollup(applicationMetricID string, hour time.Time, values []applicationMetricValueModel.ApplicationMetricValue, c collector.Collector) applicationMetricValueModel.Rollup {
+	rollup := applicationMetricValueModel.Rollup{
+		ApplicationMetricID: applicationMetricID,
+		Resolution:          applicationMetricValueModel.ResolutionHour,
+		BucketStart:         hour,
+		Samples:             len(values),
+		Fields:              map[string]applicationMetricValueModel.FieldStats{},
+	}
+
+	decoded := make([]map[string]interface{}, len(values))
+	present := map[string]bool{}
+	for i, value := range values {
+		if err := json.Unmarshal(value.Value, &decoded[i]); err != nil {
+			log.Warn().Err(err).Str("metric_value_id", value.ID).Msg("skipping unreadable metric value in rollup")
+		}
+		for _, field := range applicationMetricValueModel.SeriesFields {
+			if _, ok := decoded[i][field].(float64); ok {
+				present[field] = true
+			}
+		}
+
+		up := true
+		if c != nil {
+			var metricValue applicationMetricValueModel.MetricValue
+			if err := json.Unmarshal(value.Value, &metricValue); err == nil {
+				alert, _ := c.EvaluateAlert(metricValue)
+				up = !alert
+			}
+		}
+		if up {
+			rollup.UpSamples++
+		}
+	}
+
+	// Like in the raw values, a value without the field counts as zero
+	for field := range present {
+		fieldValues := make([]float64, len(values))
+		for i := range decoded {
+			fieldValues[i], _ = decoded[i][field].(float64)
+		}
+
+		stats := applicationMetricValueModel.FieldStats{
+			Min: applicationMetricValueModel.Aggregate(applicationMetricValueModel.AggregationMin, fieldValues),
+			Max: applicationMetricValueModel.Aggregate(applicationMetricValueModel.AggregationMax, fieldValues),
+			P95: applicationMetricValueModel.Aggregate(applicationMetricValueModel.AggregationP95, fieldValues),
+		}
+		for _, v := range fieldValues {
+			stats.Sum += v
+		}
+		rollup.Fields[field] = stats
+	}
+
+	return rollup
+}
+
+// mergeRollups combines finer rollups into one bucket. The p95 of the merged
+// bucket is the highest p95 of its parts.
+func mergeRollups(applicationMetricID, resolution string, bucketStart time.Time, parts []applicationMetricValueModel.Rollup) applicationMetricValueModel.Rollup {
+	rollup := applicationMetricValueModel.Rollup{
+		ApplicationMetricID: applicationMetricID,
+		Resolution:          resolution,
+		BucketStart:         bucketStart,
+		Fields:              map[string]applicationMetricValueModel.FieldStats{},
+	}
+
+	present := map[string]bool{}
+	for _, part := range parts {
+		rollup.Samples += part.Samples
+		rollup.UpSamples += part.UpSamples
+		for field := range part.Fields {
+			present[field] = true
+		}
+	}
+
+	for field := range present {
+		var merged applicationMetricValueModel.FieldStats
+		for i, part := range parts {
+			// A part without the field only had zeros
+			stats := part.Fields[field]
+			if i == 0 || stats.Min < merged.Min {
+				merged.Min = stats.Min
+			}
+			if i == 0 || stats.Max > merged.Max {
+				merged.Max = stats.Max
+			}
+			if i == 0 || stats.P95 > merged.P95 {
+				merged.P95 = stats.P95
+			}
+			merged.Sum += stats.Sum
+		}
+		rollup.Fields[field] = merged
+	}
+
+	return rollup
+}
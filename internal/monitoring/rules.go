@@ -0,0 +1,60 @@
+package monitoring
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"k8s-monitoring-app/internal/alerts"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+// evaluateRules checks a collected value against the enabled alert rules of
+// its application metric. It reports whether any rule is breached, why, and
+// the highest severity among the breached rules. A rule with a for-duration is
+// only breached once its condition has held that long; the time it started to
+// hold is kept in memory, so a restart starts the wait over.
+func (m *MonitoringService) evaluateRules(ctx context.Context, applicationMetricID string, value applicationMetricValueModel.MetricValue, now time.Time) (bool, string, string) {
+	rules, err := serverModel.ServerRepos.AlertRule.ListByApplicationMetric(ctx, applicationMetricID)
+	if err != nil {
+		log.Warn().Err(err).Str("application_metric_id", applicationMetricID).Msg("failed to list alert rules")
+		return false, "", ""
+	}
+
+	m.rulesMu.Lock()
+	defer m.rulesMu.Unlock()
+
+	var reasons []string
+	severity := ""
+	for _, rule := range rules {
+		if !rule.IsEnabled() {
+			delete(m.ruleSince, rule.ID)
+			continue
+		}
+
+		v := value.Field(rule.Field)
+		if !rule.Matches(v) {
+			delete(m.ruleSince, rule.ID)
+			continue
+		}
+
+		since, ok := m.ruleSince[rule.ID]
+		if !ok {
+			since = now
+			m.ruleSince[rule.ID] = now
+		}
+		if now.Sub(since) < rule.For() {
+			continue
+		}
+
+		reasons = append(reasons, rule.Describe(v))
+		if alerts.SeverityRank(rule.Severity) > alerts.SeverityRank(severity) {
+			severity = rule.Severity
+		}
+	}
+
+	return len(reasons) > 0, strings.Join(reasons, "; "), severity
+}
@@ -0,0 +1,274 @@
+package monitoring
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s-monitoring-app/internal/env"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+	monitoringModel "k8s-monitoring-app/pkg/monitoring/model"
+
+	"github.com/robfig/cron/v3"
+	"github.com/rs/zerolog/log"
+)
+
+// schedulerTick is how often the scheduler checks for due metrics
+const schedulerTick = time.Second
+
+// scheduleRefresh is how often the scheduler reloads the application metrics
+// from the database; between reloads it dispatches from its schedule
+const scheduleRefresh = 30 * time.Second
+
+// scheduleEntry tracks when an application metric is next due
+type scheduleEntry struct {
+	appMetric applicationMetricModel.ApplicationMetric
+	schedule  applicationMetricModel.Schedule
+	spec      cron.Schedule
+	nextRun   time.Time
+}
+
+// collectionJob is a due application metric handed to the worker pool
+type collectionJob struct {
+	appMetric applicationMetricModel.ApplicationMetric
+	run       *collectionRun
+}
+
+// collectionRun aggregates the results of the metrics dispatched in one scheduler tick
+type collectionRun struct {
+	mu    sync.Mutex
+	wg    sync.WaitGroup
+	stats monitoringModel.RunStats
+}
+
+func (r *collectionRun) record(appMetricID string, result collectionResult) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch result {
+	case resultSucceeded:
+		r.stats.Succeeded++
+	case resultFailed:
+		r.stats.Failed++
+	case resultSkipped:
+		r.stats.Skipped++
+		r.stats.SkippedMetrics = append(r.stats.SkippedMetrics, appMetricID)
+	}
+}
+
+// defaultInterval returns the interval used by metrics without their own schedule
+func defaultInterval() time.Duration {
+	seconds := env.METRICS_COLLECTION_INTERVAL
+	if seconds <= 0 {
+		seconds = 60
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// parseSchedule resolves the cron schedule of a metric, falling back to the
+// global collection interval
+func parseSchedule(schedule applicationMetricModel.Schedule) (cron.Schedule, error) {
+	if schedule.CronExpression != "" {
+		return cron.ParseStandard(schedule.CronExpression)
+	}
+	if schedule.IntervalSeconds > 0 {
+		return cron.Every(time.Duration(schedule.IntervalSeconds) * time.Second), nil
+	}
+	return cron.Every(defaultInterval()), nil
+}
+
+// jitter returns a random delay of up to the configured jitter
+func jitter(schedule applicationMetricModel.Schedule) time.Duration {
+	if schedule.JitterSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(schedule.JitterSeconds)*int64(time.Second) + 1))
+}
+
+// syncSchedule reconciles the schedule with the configured application metrics
+func (m *MonitoringService) syncSchedule(applicationMetrics []applicationMetricModel.ApplicationMetric, now time.Time) {
+	m.scheduleMu.Lock()
+	defer m.scheduleMu.Unlock()
+
+	seen := make(map[string]struct{}, len(applicationMetrics))
+
+	for _, appMetric := range applicationMetrics {
+		seen[appMetric.ID] = struct{}{}
+
+		schedule := applicationMetricModel.Schedule{}
+		if appMetric.Schedule != nil {
+			schedule = *appMetric.Schedule
+		}
+
+		entry, ok := m.schedule[appMetric.ID]
+		if !ok || entry.schedule != schedule {
+			spec, err := parseSchedule(schedule)
+			if err != nil {
+				log.Warn().Err(err).
+					Str("application_metric_id", appMetric.ID).
+					Msg("invalid metric schedule, using default interval")
+				spec = cron.Every(defaultInterval())
+			}
+
+			nextRun := now.Add(jitter(schedule)) // New metrics are collected right away
+			if ok {
+				nextRun = spec.Next(now).Add(jitter(schedule))
+			}
+			entry = &scheduleEntry{schedule: schedule, spec: spec, nextRun: nextRun}
+			m.schedule[appMetric.ID] = entry
+		}
+		entry.appMetric = appMetric
+	}
+
+	// Forget metrics that were deleted
+	for id := range m.schedule {
+		if _, ok := seen[id]; !ok {
+			delete(m.schedule, id)
+			m.evaluator.forget(id)
+			m.flaps.forget(id)
+		}
+	}
+
+	m.scheduleSyncedAt = now
+}
+
+// scheduleStale reports whether the schedule is due for a reload
+func (m *MonitoringService) scheduleStale(now time.Time) bool {
+	m.scheduleMu.Lock()
+	defer m.scheduleMu.Unlock()
+
+	return now.Sub(m.scheduleSyncedAt) >= scheduleRefresh
+}
+
+// dueMetrics returns the scheduled metrics that are due, the longest waiting
+// first
+func (m *MonitoringService) dueMetrics(now time.Time) []applicationMetricModel.ApplicationMetric {
+	m.scheduleMu.Lock()
+	defer m.scheduleMu.Unlock()
+
+	var entries []*scheduleEntry
+	for _, entry := range m.schedule {
+		if !entry.nextRun.After(now) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].nextRun.Before(entries[j].nextRun)
+	})
+
+	due := make([]applicationMetricModel.ApplicationMetric, 0, len(entries))
+	for _, entry := range entries {
+		due = append(due, entry.appMetric)
+	}
+	return due
+}
+
+// advanceSchedule moves a dispatched metric to its next due time
+func (m *MonitoringService) advanceSchedule(appMetricID string, now time.Time) {
+	m.scheduleMu.Lock()
+	defer m.scheduleMu.Unlock()
+
+	if entry, ok := m.schedule[appMetricID]; ok {
+		entry.nextRun = entry.spec.Next(now).Add(jitter(entry.schedule))
+	}
+}
+
+// dispatchDueMetrics hands every due metric to the worker pool. Metrics that
+// cannot be queued because all workers are busy stay due for the next tick.
+func (m *MonitoringService) dispatchDueMetrics() {
+	// Never start a tick while the previous one is still dispatching; metrics
+	// that are still collecting are guarded per metric by inFlight
+	if !m.running.CompareAndSwap(false, true) {
+		m.statsMu.Lock()
+		m.skippedRuns++
+		m.statsMu.Unlock()
+		log.Debug().Msg("Previous scheduler tick still running, skipping")
+		return
+	}
+	defer m.running.Store(false)
+
+	now := time.Now()
+
+	// Reload the application metrics every scheduleRefresh; when that fails the
+	// current schedule keeps running and the reload is retried next tick
+	if m.scheduleStale(now) {
+		applicationMetrics, err := serverModel.ServerRepos.ApplicationMetric.List(context.Background())
+		if err != nil {
+			log.Error().Err(err).Msg("failed to list application metrics")
+		} else {
+			m.syncSchedule(applicationMetrics, now)
+		}
+	}
+
+	due := m.dueMetrics(now)
+	if len(due) == 0 {
+		return
+	}
+
+	log.Debug().Int("due", len(due)).Msg("Starting metric collection")
+
+	run := &collectionRun{stats: monitoringModel.RunStats{StartedAt: now}}
+	for _, appMetric := range due {
+		// A collector that ignored its deadline last time may still be running
+		if _, busy := m.inFlight.Load(appMetric.ID); busy {
+			log.Warn().
+				Str("application_metric_id", appMetric.ID).
+				Msg("previous collection of this metric still running, skipping")
+			run.record(appMetric.ID, resultSkipped)
+			m.advanceSchedule(appMetric.ID, now)
+			continue
+		}
+
+		run.wg.Add(1)
+		select {
+		case m.jobs <- collectionJob{appMetric: appMetric, run: run}:
+			m.advanceSchedule(appMetric.ID, now)
+		default:
+			// Pool saturated: leave the metric due so the next tick picks it up
+			run.wg.Done()
+		}
+	}
+
+	go m.finishRun(run, len(due))
+}
+
+// finishRun waits for the dispatched metrics and records the run stats
+func (m *MonitoringService) finishRun(run *collectionRun, due int) {
+	run.wg.Wait()
+
+	run.mu.Lock()
+	stats := run.stats
+	run.mu.Unlock()
+
+	stats.Total = stats.Succeeded + stats.Failed + stats.Skipped
+	if stats.Total == 0 {
+		return
+	}
+	stats.FinishedAt = time.Now()
+	stats.DurationMs = stats.FinishedAt.Sub(stats.StartedAt).Milliseconds()
+	m.recordRun(stats)
+
+	log.Info().
+		Int64("duration_ms", stats.DurationMs).
+		Int("due", due).
+		Int("total", stats.Total).
+		Int("succeeded", stats.Succeeded).
+		Int("failed", stats.Failed).
+		Int("skipped", stats.Skipped).
+		Strs("skipped_metrics", stats.SkippedMetrics).
+		Msg("Metric collection completed")
+}
+
+// worker collects the metrics handed over by the scheduler
+func (m *MonitoringService) worker(jobs <-chan collectionJob) {
+	defer m.workers.Done()
+
+	for job := range jobs {
+		_, result, _ := m.collectApplicationMetric(context.Background(), job.appMetric, true)
+		job.run.record(job.appMetric.ID, result)
+		job.run.wg.Done()
+	}
+}
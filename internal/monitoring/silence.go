@@ -0,0 +1,37 @@
+package monitoring
+
+import (
+	"context"
+	"time"
+
+	serverModel "k8s-monitoring-app/internal/server/model"
+	applicationModel "k8s-monitoring-app/pkg/application/model"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+	silenceModel "k8s-monitoring-app/pkg/silence/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+// activeSilence returns the silence holding back the alerts of a metric at
+// the given time, or nil when its alerts are delivered. The silences are only
+// read when an alert is about to be sent.
+func activeSilence(
+	ctx context.Context,
+	application *applicationModel.Application,
+	appMetric *applicationMetricModel.ApplicationMetric,
+	now time.Time,
+) *silenceModel.Silence {
+	silences, err := serverModel.ServerRepos.Silence.ListCurrent(ctx, now)
+	if err != nil {
+		// Deliver rather than lose the alert
+		log.Warn().Err(err).Msg("failed to list silences")
+		return nil
+	}
+
+	for _, silence := range silences {
+		if silence.Matches(application.ProjectID, application.ID, appMetric.ID, appMetric.TypeID) && silence.ActiveAt(now) {
+			return &silence
+		}
+	}
+	return nil
+}
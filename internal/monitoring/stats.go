@@ -0,0 +1,62 @@
+package monitoring
+
+import (
+	"net/http"
+	"time"
+
+	"k8s-monitoring-app/internal/core"
+	"k8s-monitoring-app/internal/env"
+	monitoringModel "k8s-monitoring-app/pkg/monitoring/model"
+)
+
+// collectionWorkers returns the size of the collection worker pool
+func collectionWorkers() int {
+	if env.METRICS_COLLECTION_WORKERS <= 0 {
+		return 10
+	}
+	return env.METRICS_COLLECTION_WORKERS
+}
+
+// metricTimeout returns the deadline applied to each metric collection
+func metricTimeout() time.Duration {
+	seconds := env.METRICS_COLLECTION_TIMEOUT
+	if seconds <= 0 {
+		seconds = 30
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+func (m *MonitoringService) recordRun(stats monitoringModel.RunStats) {
+	m.statsMu.Lock()
+	defer m.statsMu.Unlock()
+	m.lastRun = &stats
+}
+
+// Status returns the scheduler state and the stats of the last completed run
+func (m *MonitoringService) Status(sc *core.HTTPServerContext) error {
+	inFlight := 0
+	m.inFlight.Range(func(_, _ any) bool {
+		inFlight++
+		return true
+	})
+
+	m.scheduleMu.Lock()
+	scheduled := len(m.schedule)
+	m.scheduleMu.Unlock()
+
+	m.statsMu.RLock()
+	status := monitoringModel.Status{
+		Collecting:           m.isCollecting(),
+		LeaderElection:       m.election != nil,
+		Leader:               m.leaderIdentity(),
+		Scheduled:            scheduled,
+		InFlight:             inFlight,
+		Workers:              collectionWorkers(),
+		MetricTimeoutSeconds: int(metricTimeout().Seconds()),
+		SkippedRuns:          m.skippedRuns,
+		LastRun:              m.lastRun,
+	}
+	m.statsMu.RUnlock()
+
+	return sc.JSON(http.StatusOK, status)
+}
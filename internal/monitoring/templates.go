@@ -0,0 +1,91 @@
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+
+	"k8s-monitoring-app/internal/alerts"
+	"k8s-monitoring-app/internal/security"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	alertTemplateModel "k8s-monitoring-app/pkg/alert_template/model"
+	applicationModel "k8s-monitoring-app/pkg/application/model"
+	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+// alertMessage renders the alert templates of a metric for each channel
+type alertMessage struct {
+	metricTypeID string
+	templates    []alertTemplateModel.AlertTemplate
+	data         alertTemplateModel.Data
+}
+
+// newAlertMessage loads the alert templates and what they are rendered
+// against. It returns nil when there is no template, so the alerts keep the
+// default message.
+func newAlertMessage(
+	ctx context.Context,
+	application *applicationModel.Application,
+	appMetric *applicationMetricModel.ApplicationMetric,
+	value *applicationMetricValueModel.MetricValue,
+) *alertMessage {
+	templates, err := serverModel.ServerRepos.AlertTemplate.List(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to list alert templates, using the default message")
+		return nil
+	}
+	if len(templates) == 0 {
+		return nil
+	}
+
+	message := &alertMessage{
+		metricTypeID: appMetric.TypeID,
+		templates:    templates,
+		data: alertTemplateModel.Data{
+			Application: *application,
+			Value:       value,
+		},
+	}
+	if project, err := serverModel.ServerRepos.Project.Get(ctx, application.ProjectID); err == nil {
+		message.data.Project = project
+	}
+	if err := json.Unmarshal(security.RedactSensitiveFieldsRaw(appMetric.Configuration), &message.data.Config); err != nil {
+		log.Warn().Err(err).Str("application_metric_id", appMetric.ID).Msg("failed to parse metric configuration for alert templates")
+	}
+
+	// The value of this collection isn't stored yet: the latest one is the previous
+	latest, err := serverModel.ServerRepos.ApplicationMetricValue.ListByApplicationMetric(ctx, appMetric.ID, 1)
+	if err == nil && len(latest) > 0 {
+		previous := applicationMetricValueModel.MetricValue{}
+		if err := json.Unmarshal(latest[0].Value, &previous); err == nil {
+			message.data.Previous = &previous
+		}
+	}
+
+	return message
+}
+
+// apply renders the template of a channel kind into an alert. A template that
+// fails to render is logged and the alert keeps the default message.
+func (a *alertMessage) apply(alert alerts.Alert, kind string) alerts.Alert {
+	if a == nil {
+		return alert
+	}
+	t := alertTemplateModel.Select(a.templates, a.metricTypeID, kind)
+	if t == nil {
+		return alert
+	}
+
+	data := a.data
+	data.Alert = alert
+	title, message, err := t.Render(data)
+	if err != nil {
+		log.Warn().Err(err).Str("alert_template_id", t.ID).Str("channel", kind).Msg("failed to render alert template, using the default message")
+		return alert
+	}
+	alert.Title = title
+	alert.Message = message
+	return alert
+}
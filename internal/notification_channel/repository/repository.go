@@ -0,0 +1,206 @@
+package notification_channel
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"k8s-monitoring-app/internal/core"
+	notificationChannelModel "k8s-monitoring-app/pkg/notification_channel/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+// generateUUID generates a simple UUID v4
+func generateUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+type Repository interface {
+	Get(ctx context.Context, id string) (notificationChannelModel.NotificationChannel, error)
+	List(ctx context.Context) ([]notificationChannelModel.NotificationChannel, error)
+	// ListByProject returns the channels of a project, including the ones of its applications
+	ListByProject(ctx context.Context, projectID string) ([]notificationChannelModel.NotificationChannel, error)
+	Add(ctx context.Context, channel *notificationChannelModel.NotificationChannel) error
+	Update(ctx context.Context, channel *notificationChannelModel.NotificationChannel) error
+	Delete(ctx context.Context, id string) error
+	GetDB() *sql.DB
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepo(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (repo *repository) GetDB() *sql.DB {
+	return repo.db
+}
+
+const selectChannels = `
+	SELECT
+		id, project_id, application_id, name, kind, settings, enabled, created_at, updated_at
+	FROM
+		notification_channels`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanChannel(row scanner) (notificationChannelModel.NotificationChannel, error) {
+	channel := notificationChannelModel.NotificationChannel{}
+	var applicationID sql.NullString
+	var enabled bool
+	err := row.Scan(
+		&channel.ID, &channel.ProjectID, &applicationID, &channel.Name, &channel.Kind,
+		&channel.Settings, &enabled, &channel.CreatedAt, &channel.UpdatedAt)
+	if err != nil {
+		return channel, err
+	}
+	channel.ApplicationID = applicationID.String
+	channel.Enabled = &enabled
+
+	return channel, nil
+}
+
+func (repo *repository) Get(ctx context.Context, id string) (notificationChannelModel.NotificationChannel, error) {
+	sqlString := fmt.Sprintf("%s WHERE id = ?", selectChannels)
+
+	return scanChannel(repo.db.QueryRowContext(ctx, core.Rebind(sqlString), id))
+}
+
+func (repo *repository) List(ctx context.Context) ([]notificationChannelModel.NotificationChannel, error) {
+	sqlString := fmt.Sprintf("%s ORDER BY name", selectChannels)
+
+	return repo.list(ctx, sqlString)
+}
+
+func (repo *repository) ListByProject(ctx context.Context, projectID string) ([]notificationChannelModel.NotificationChannel, error) {
+	sqlString := fmt.Sprintf("%s WHERE project_id = ? ORDER BY name", selectChannels)
+
+	return repo.list(ctx, sqlString, projectID)
+}
+
+func (repo *repository) list(ctx context.Context, sqlString string, args ...interface{}) ([]notificationChannelModel.NotificationChannel, error) {
+	channels := []notificationChannelModel.NotificationChannel{}
+
+	rows, err := repo.db.QueryContext(ctx, core.Rebind(sqlString), args...)
+	if err != nil {
+		return channels, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		channel, err := scanChannel(rows)
+		if err != nil {
+			return channels, err
+		}
+
+		channels = append(channels, channel)
+	}
+
+	return channels, rows.Err()
+}
+
+func (repo *repository) Add(ctx context.Context, channel *notificationChannelModel.NotificationChannel) error {
+	channel.ID = generateUUID()
+	now := time.Now()
+	channel.CreatedAt = now
+	channel.UpdatedAt = now
+
+	if channel.Enabled == nil {
+		enabled := true
+		channel.Enabled = &enabled
+	}
+
+	sqlString := `INSERT INTO notification_channels(
+		id, project_id, application_id, name, kind, settings, enabled, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := repo.db.ExecContext(ctx, core.Rebind(sqlString),
+		channel.ID, channel.ProjectID, nullString(channel.ApplicationID), channel.Name, channel.Kind,
+		channel.Settings, *channel.Enabled, channel.CreatedAt, channel.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Update changes the name, settings and enabled flag of a channel. The kind
+// and the project or application it belongs to are fixed.
+func (repo *repository) Update(ctx context.Context, channel *notificationChannelModel.NotificationChannel) error {
+	var params []interface{}
+
+	sqlString := `UPDATE notification_channels SET `
+
+	if channel.Name != "" {
+		sqlString = fmt.Sprintf("%s name = ?, ", sqlString)
+		params = append(params, channel.Name)
+	}
+	if len(channel.Settings) > 0 {
+		sqlString = fmt.Sprintf("%s settings = ?, ", sqlString)
+		params = append(params, channel.Settings)
+	}
+	if channel.Enabled != nil {
+		sqlString = fmt.Sprintf("%s enabled = ?, ", sqlString)
+		params = append(params, *channel.Enabled)
+	}
+	if len(params) == 0 {
+		log.Warn().Msg("no fields to update")
+		return nil
+	}
+
+	sqlString = fmt.Sprintf("%s updated_at = CURRENT_TIMESTAMP WHERE id = ?", sqlString)
+	params = append(params, channel.ID)
+
+	result, err := repo.db.ExecContext(ctx, core.Rebind(sqlString), params...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (repo *repository) Delete(ctx context.Context, id string) error {
+	sqlString := `DELETE FROM notification_channels WHERE id = ?`
+
+	result, err := repo.db.ExecContext(ctx, core.Rebind(sqlString), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// nullString stores an empty string as NULL
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,265 @@
+package notification_channel
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s-monitoring-app/internal/alerts"
+	"k8s-monitoring-app/internal/core"
+	"k8s-monitoring-app/internal/security"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	model "k8s-monitoring-app/pkg/notification_channel/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+type service struct{}
+
+func NewService() model.Service {
+	return &service{}
+}
+
+func (s *service) Get(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	id := sc.Param("id")
+
+	if len(id) == 0 {
+		log.Error().Err(errors.New("id is empty")).Msg("error getting notification channel")
+		return sc.String(http.StatusBadRequest, "invalid request")
+	}
+
+	channel, err := serverModel.ServerRepos.NotificationChannel.Get(ctx, id)
+	if err != nil {
+		log.Error().Msg("error getting notification channel")
+		return sc.String(http.StatusNotFound, "notification channel not found")
+	}
+
+	return sc.JSON(http.StatusOK, redact(channel))
+}
+
+func (s *service) List(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	channels, err := serverModel.ServerRepos.NotificationChannel.List(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("error listing notification channels")
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	}
+
+	for i := range channels {
+		channels[i] = redact(channels[i])
+	}
+	return sc.JSON(http.StatusOK, channels)
+}
+
+func (s *service) ListByProject(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	projectID := sc.Param("project_id")
+
+	if len(projectID) == 0 {
+		log.Error().Msg("project_id is empty")
+		return sc.String(http.StatusBadRequest, "invalid request")
+	}
+
+	channels, err := serverModel.ServerRepos.NotificationChannel.ListByProject(ctx, projectID)
+	if err != nil {
+		log.Error().Err(err).Msg("error listing notification channels by project")
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	}
+
+	for i := range channels {
+		channels[i] = redact(channels[i])
+	}
+	return sc.JSON(http.StatusOK, channels)
+}
+
+func (s *service) Add(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	channel := model.NotificationChannel{}
+	if err := sc.Bind(&channel); err != nil {
+		log.Error().Msg("error binding notification channel")
+		return sc.String(http.StatusBadRequest, "invalid request body")
+	}
+	channel.Name = strings.TrimSpace(channel.Name)
+	if channel.Name == "" {
+		return sc.String(http.StatusBadRequest, "name is required")
+	}
+
+	// Validate that the project exists, and that the application belongs to it
+	if _, err := serverModel.ServerRepos.Project.Get(ctx, channel.ProjectID); err != nil {
+		log.Error().Msg("error getting project")
+		return sc.String(http.StatusBadRequest, "project not found")
+	}
+	if channel.ApplicationID != "" {
+		application, err := serverModel.ServerRepos.Application.Get(ctx, channel.ApplicationID)
+		if err != nil {
+			log.Error().Msg("error getting application")
+			return sc.String(http.StatusBadRequest, "application not found")
+		}
+		if application.ProjectID != channel.ProjectID {
+			return sc.String(http.StatusBadRequest, "application does not belong to the project")
+		}
+	}
+
+	if _, err := channel.Notifier(); err != nil {
+		log.Warn().Err(err).Str("project_id", channel.ProjectID).Str("kind", channel.Kind).Msg("invalid notification channel")
+		return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid settings",
+			"message": err.Error(),
+		})
+	}
+
+	if err := serverModel.ServerRepos.NotificationChannel.Add(ctx, &channel); err != nil {
+		log.Error().Err(err).Msg("error add notification channel")
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	}
+
+	return sc.JSON(http.StatusCreated, redact(channel))
+}
+
+func (s *service) Update(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+	id := sc.Param("id")
+
+	existing, err := serverModel.ServerRepos.NotificationChannel.Get(ctx, id)
+	if err != nil {
+		log.Error().Msg("error getting notification channel")
+		return sc.String(http.StatusNotFound, "notification channel not found")
+	}
+
+	channel := model.NotificationChannel{}
+	if err := sc.Bind(&channel); err != nil {
+		log.Error().Msg("error binding notification channel")
+		return sc.String(http.StatusBadRequest, "Invalid Request")
+	}
+	channel.Name = strings.TrimSpace(channel.Name)
+
+	// The kind and the owner of a channel are fixed
+	channel.ID = id
+	channel.ProjectID = existing.ProjectID
+	channel.ApplicationID = existing.ApplicationID
+	channel.Kind = existing.Kind
+
+	if len(channel.Settings) > 0 {
+		// Settings read through the API come back with their credentials redacted
+		channel.Settings = security.RestoreRedactedFieldsRaw(channel.Settings, existing.Settings)
+		if _, err := channel.Notifier(); err != nil {
+			log.Warn().Err(err).Str("notification_channel_id", id).Msg("invalid notification channel on update")
+			return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+				"error":   "invalid settings",
+				"message": err.Error(),
+			})
+		}
+	}
+
+	if err := serverModel.ServerRepos.NotificationChannel.Update(ctx, &channel); err != nil {
+		log.Error().Err(err).Msg("error updating notification channel")
+		return sc.String(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	updated, err := serverModel.ServerRepos.NotificationChannel.Get(ctx, id)
+	if err != nil {
+		log.Error().Err(err).Msg("error getting notification channel")
+		return sc.String(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	return sc.JSON(http.StatusOK, redact(updated))
+}
+
+func (s *service) Delete(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+	id := sc.Param("id")
+
+	if len(id) == 0 {
+		log.Error().Err(errors.New("id is empty")).Msg("error deleting notification channel")
+		return sc.String(http.StatusBadRequest, "Invalid Request")
+	}
+
+	err := serverModel.ServerRepos.NotificationChannel.Delete(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sc.String(http.StatusNotFound, "notification channel not found")
+		}
+		log.Error().Err(err).Str("id", id).Msg("error deleting notification channel")
+		return sc.String(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	return sc.JSON(http.StatusOK, map[string]bool{"success": true})
+}
+
+// Test sends a sample alert through a channel, so its settings can be checked
+// without waiting for a failure
+func (s *service) Test(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+	id := sc.Param("id")
+
+	channel, err := serverModel.ServerRepos.NotificationChannel.Get(ctx, id)
+	if err != nil {
+		log.Error().Msg("error getting notification channel")
+		return sc.String(http.StatusNotFound, "notification channel not found")
+	}
+
+	notifier, err := channel.Notifier()
+	if err != nil {
+		return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid settings",
+			"message": err.Error(),
+		})
+	}
+
+	alert := alerts.Alert{
+		Status:   alerts.StatusFiring,
+		Severity: alerts.SeverityInfo,
+		Title:    "Test notification",
+		Metric:   "-",
+		Reason:   "Test alert sent to the notification channel " + channel.Name,
+		Time:     time.Now(),
+	}
+	if project, err := serverModel.ServerRepos.Project.Get(ctx, channel.ProjectID); err == nil {
+		alert.Project = project.Name
+	}
+	if channel.ApplicationID != "" {
+		if application, err := serverModel.ServerRepos.Application.Get(ctx, channel.ApplicationID); err == nil {
+			alert.Application = application.Name
+			alert.Namespace = application.Namespace
+		}
+	}
+
+	// Incident channels may only page some severities: test the least urgent one they take
+	for _, severity := range alerts.Severities {
+		alert.Severity = severity
+		if alerts.Accepts(notifier, alert) {
+			break
+		}
+	}
+
+	if err := notifier.Notify(ctx, alert); err != nil {
+		log.Warn().Err(err).Str("notification_channel_id", id).Str("kind", channel.Kind).Msg("test notification failed")
+		return sc.JSON(http.StatusBadGateway, map[string]interface{}{
+			"error":   "delivery failed",
+			"message": err.Error(),
+		})
+	}
+
+	// The test incident is resolved right away so it doesn't page anyone for long
+	if alerts.IsIncidentKind(channel.Kind) {
+		alert.Status = alerts.StatusResolved
+		if err := notifier.Notify(ctx, alert); err != nil {
+			log.Warn().Err(err).Str("notification_channel_id", id).Str("kind", channel.Kind).Msg("failed to resolve test incident")
+		}
+	}
+
+	return sc.JSON(http.StatusOK, map[string]bool{"success": true})
+}
+
+// redact hides the credentials of a channel's settings
+func redact(channel model.NotificationChannel) model.NotificationChannel {
+	channel.Settings = security.RedactSensitiveFieldsRaw(channel.Settings)
+	return channel
+}
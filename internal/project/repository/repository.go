@@ -6,6 +6,7 @@ import (
 	"database/sql"
 	"fmt"
 
+	"k8s-monitoring-app/internal/core"
 	projectModel "k8s-monitoring-app/pkg/project/model"
 
 	"github.com/rs/zerolog/log"
@@ -46,7 +47,7 @@ func (repo *repository) Get(ctx context.Context, id string, customFieldName ...s
 
 	sqlString := `
 	SELECT
-		p.id, p.name, p.description
+		p.id, p.name, p.description, p.retention_days, p.escalation_policy_id
 	FROM 
 		projects p
 	WHERE`
@@ -57,12 +58,18 @@ func (repo *repository) Get(ctx context.Context, id string, customFieldName ...s
 		sqlString = fmt.Sprintf("%s p.id = ?", sqlString)
 	}
 
-	err := repo.db.QueryRowContext(ctx, sqlString, id).Scan(
-		&project.ID, &project.Name, &project.Description)
+	var retentionDays int
+	var escalationPolicyID sql.NullString
+	err := repo.db.QueryRowContext(ctx, core.Rebind(sqlString), id).Scan(
+		&project.ID, &project.Name, &project.Description, &retentionDays, &escalationPolicyID)
 
 	if err != nil {
 		return project, err
 	}
+	project.RetentionDays = &retentionDays
+	if escalationPolicyID.Valid {
+		project.EscalationPolicyID = &escalationPolicyID.String
+	}
 
 	return project, nil
 }
@@ -72,7 +79,7 @@ func (repo *repository) List(ctx context.Context) ([]projectModel.Project, error
 
 	sqlString := `
 	SELECT
-		id, name, description
+		id, name, description, retention_days, escalation_policy_id
 	FROM
 		projects
 	ORDER BY name`
@@ -85,11 +92,17 @@ func (repo *repository) List(ctx context.Context) ([]projectModel.Project, error
 
 	for rows.Next() {
 		project := projectModel.Project{}
+		var retentionDays int
+		var escalationPolicyID sql.NullString
 		err := rows.Scan(
-			&project.ID, &project.Name, &project.Description)
+			&project.ID, &project.Name, &project.Description, &retentionDays, &escalationPolicyID)
 		if err != nil {
 			return projects, err
 		}
+		project.RetentionDays = &retentionDays
+		if escalationPolicyID.Valid {
+			project.EscalationPolicyID = &escalationPolicyID.String
+		}
 
 		projects = append(projects, project)
 	}
@@ -101,12 +114,21 @@ func (repo *repository) Add(ctx context.Context, project *projectModel.Project)
 	// Generate UUID for SQLite
 	project.ID = generateUUID()
 
+	if project.RetentionDays == nil {
+		project.RetentionDays = new(int)
+	}
+
+	var escalationPolicyID sql.NullString
+	if project.EscalationPolicyID != nil {
+		escalationPolicyID = sql.NullString{String: *project.EscalationPolicyID, Valid: *project.EscalationPolicyID != ""}
+	}
+
 	sqlString := `INSERT INTO projects(
-		id, name, description
-		) VALUES (?, ?, ?)`
+		id, name, description, retention_days, escalation_policy_id
+		) VALUES (?, ?, ?, ?, ?)`
 
-	_, err := repo.db.ExecContext(ctx, sqlString,
-		project.ID, project.Name, project.Description,
+	_, err := repo.db.ExecContext(ctx, core.Rebind(sqlString),
+		project.ID, project.Name, project.Description, *project.RetentionDays, escalationPolicyID,
 	)
 	if err != nil {
 		return err
@@ -128,6 +150,14 @@ func (repo *repository) Update(ctx context.Context, project *projectModel.Projec
 		sqlString = fmt.Sprintf("%s description = ?, ", sqlString)
 		params = append(params, project.Description)
 	}
+	if project.RetentionDays != nil {
+		sqlString = fmt.Sprintf("%s retention_days = ?, ", sqlString)
+		params = append(params, *project.RetentionDays)
+	}
+	if project.EscalationPolicyID != nil {
+		sqlString = fmt.Sprintf("%s escalation_policy_id = ?, ", sqlString)
+		params = append(params, sql.NullString{String: *project.EscalationPolicyID, Valid: *project.EscalationPolicyID != ""})
+	}
 	if len(params) == 0 {
 		log.Warn().Msg("no fields to update")
 		return nil
@@ -136,7 +166,7 @@ func (repo *repository) Update(ctx context.Context, project *projectModel.Projec
 	sqlString = fmt.Sprintf("%s WHERE id = ?", sqlString[:len(sqlString)-2])
 	params = append(params, project.ID)
 
-	result, err := repo.db.ExecContext(ctx, sqlString, params...)
+	result, err := repo.db.ExecContext(ctx, core.Rebind(sqlString), params...)
 	if err != nil {
 		return err
 	}
@@ -156,7 +186,7 @@ func (repo *repository) Update(ctx context.Context, project *projectModel.Projec
 func (repo *repository) Delete(ctx context.Context, id string) error {
 	sqlString := `DELETE FROM projects WHERE id = ?`
 
-	result, err := repo.db.ExecContext(ctx, sqlString, id)
+	result, err := repo.db.ExecContext(ctx, core.Rebind(sqlString), id)
 	if err != nil {
 		return err
 	}
@@ -1,11 +1,13 @@
 package project
 
 import (
+	"context"
 	"errors"
 	"net/http"
 
 	"k8s-monitoring-app/internal/core"
 	serverModel "k8s-monitoring-app/internal/server/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
 	model "k8s-monitoring-app/pkg/project/model"
 
 	"github.com/rs/zerolog/log"
@@ -56,6 +58,18 @@ func (s *service) Add(sc *core.HTTPServerContext) error {
 		log.Error().Msg("error binding project")
 		return sc.String(http.StatusBadRequest, "invalid request body")
 	}
+	if project.RetentionDays != nil {
+		if err := applicationMetricValueModel.ValidateRetentionDays(*project.RetentionDays); err != nil {
+			log.Warn().Err(err).Str("project", project.Name).Msg("invalid project retention")
+			return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+				"error":   "invalid retention",
+				"message": err.Error(),
+			})
+		}
+	}
+	if !escalationPolicyExists(ctx, project.EscalationPolicyID) {
+		return sc.String(http.StatusBadRequest, "escalation policy not found")
+	}
 	if err := serverModel.ServerRepos.Project.Add(ctx, &project); err != nil {
 		log.Error().Msg("error add project")
 		return sc.String(http.StatusInternalServerError, "internal server error")
@@ -82,6 +96,19 @@ func (s *service) Update(sc *core.HTTPServerContext) error {
 	}
 	project.ID = id
 
+	if project.RetentionDays != nil {
+		if err := applicationMetricValueModel.ValidateRetentionDays(*project.RetentionDays); err != nil {
+			log.Warn().Err(err).Str("project_id", id).Msg("invalid project retention on update")
+			return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+				"error":   "invalid retention",
+				"message": err.Error(),
+			})
+		}
+	}
+	if !escalationPolicyExists(ctx, project.EscalationPolicyID) {
+		return sc.String(http.StatusBadRequest, "escalation policy not found")
+	}
+
 	if err := serverModel.ServerRepos.Project.Update(ctx, &project); err != nil {
 		log.Error().Msg("error updating project")
 		return sc.String(http.StatusInternalServerError, "Internal Server Error")
@@ -107,3 +134,16 @@ func (s *service) Delete(sc *core.HTTPServerContext) error {
 
 	return sc.JSON(http.StatusOK, map[string]bool{"success": true})
 }
+
+// escalationPolicyExists reports whether the escalation policy given for a
+// project exists. No policy, or "" to detach it, is fine.
+func escalationPolicyExists(ctx context.Context, id *string) bool {
+	if id == nil || *id == "" {
+		return true
+	}
+	if _, err := serverModel.ServerRepos.EscalationPolicy.Get(ctx, *id); err != nil {
+		log.Warn().Err(err).Str("escalation_policy_id", *id).Msg("error getting escalation policy")
+		return false
+	}
+	return true
+}
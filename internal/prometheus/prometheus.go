@@ -0,0 +1,116 @@
+package prometheus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ContentType is the Prometheus text exposition format content type
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Metric types supported by the exposition format
+const (
+	Gauge   = "gauge"
+	Counter = "counter"
+)
+
+// Label is a single name/value pair attached to a sample
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Sample is a single value of a metric family
+type Sample struct {
+	Name   string
+	Help   string
+	Type   string // Gauge when empty
+	Labels []Label
+	Value  float64
+}
+
+// Write renders the samples in the Prometheus text exposition format. Samples
+// of the same metric are grouped under a single HELP/TYPE header, in the order
+// the metric was first seen.
+func Write(w io.Writer, samples []Sample) error {
+	var order []string
+	families := map[string][]Sample{}
+	for _, s := range samples {
+		if _, ok := families[s.Name]; !ok {
+			order = append(order, s.Name)
+		}
+		families[s.Name] = append(families[s.Name], s)
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, name := range order {
+		family := families[name]
+
+		metricType := family[0].Type
+		if metricType == "" {
+			metricType = Gauge
+		}
+		if family[0].Help != "" {
+			fmt.Fprintf(bw, "# HELP %s %s\n", name, escapeHelp(family[0].Help))
+		}
+		fmt.Fprintf(bw, "# TYPE %s %s\n", name, metricType)
+
+		for _, s := range family {
+			bw.WriteString(name)
+			if len(s.Labels) > 0 {
+				bw.WriteByte('{')
+				for i, l := range s.Labels {
+					if i > 0 {
+						bw.WriteByte(',')
+					}
+					fmt.Fprintf(bw, "%s=\"%s\"", l.Name, escapeLabelValue(l.Value))
+				}
+				bw.WriteByte('}')
+			}
+			bw.WriteByte(' ')
+			bw.WriteString(formatValue(s.Value))
+			bw.WriteByte('\n')
+		}
+	}
+
+	return bw.Flush()
+}
+
+// Bool converts a boolean into a gauge value
+func Bool(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeLabelValue(s string) string {
+	return labelEscaper.Replace(s)
+}
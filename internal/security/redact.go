@@ -93,3 +93,35 @@ func RedactSensitiveFieldsRaw(raw json.RawMessage) json.RawMessage {
 	}
 	return json.RawMessage(b)
 }
+
+// RestoreRedactedFieldsRaw puts back the values of existing into the fields of
+// payload still holding a redacted placeholder, so a configuration read through
+// the API can be sent back on update without losing its credentials.
+func RestoreRedactedFieldsRaw(payload, existing json.RawMessage) json.RawMessage {
+	var data, previous map[string]interface{}
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return payload
+	}
+	if err := json.Unmarshal(existing, &previous); err != nil {
+		return payload
+	}
+
+	restored := false
+	for k, v := range data {
+		if str, ok := v.(string); ok && strings.Contains(str, "[REDACTED]") {
+			if old, ok := previous[k]; ok {
+				data[k] = old
+				restored = true
+			}
+		}
+	}
+	if !restored {
+		return payload
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return payload
+	}
+	return json.RawMessage(b)
+}
@@ -1,16 +1,30 @@
 package server
 
 import (
+	alertHistoryRepo "k8s-monitoring-app/internal/alert_history/repository"
+	alertRuleRepo "k8s-monitoring-app/internal/alert_rule/repository"
+	alertStateRepo "k8s-monitoring-app/internal/alert_state/repository"
+	alertTemplateRepo "k8s-monitoring-app/internal/alert_template/repository"
 	applicationRepo "k8s-monitoring-app/internal/application/repository"
 	applicationMetricRepo "k8s-monitoring-app/internal/application_metric/repository"
 	applicationMetricValueRepo "k8s-monitoring-app/internal/application_metric_value/repository"
+	escalationPolicyRepo "k8s-monitoring-app/internal/escalation_policy/repository"
 	metricTypeRepo "k8s-monitoring-app/internal/metric_type/repository"
+	notificationChannelRepo "k8s-monitoring-app/internal/notification_channel/repository"
 	projectRepo "k8s-monitoring-app/internal/project/repository"
+	silenceRepo "k8s-monitoring-app/internal/silence/repository"
+	alertHistoryModel "k8s-monitoring-app/pkg/alert_history/model"
+	alertRuleModel "k8s-monitoring-app/pkg/alert_rule/model"
+	alertTemplateModel "k8s-monitoring-app/pkg/alert_template/model"
 	applicationModel "k8s-monitoring-app/pkg/application/model"
 	applicationMetricModel "k8s-monitoring-app/pkg/application_metric/model"
 	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+	escalationPolicyModel "k8s-monitoring-app/pkg/escalation_policy/model"
 	metricTypeModel "k8s-monitoring-app/pkg/metric_type/model"
+	monitoringModel "k8s-monitoring-app/pkg/monitoring/model"
+	notificationChannelModel "k8s-monitoring-app/pkg/notification_channel/model"
 	projectModel "k8s-monitoring-app/pkg/project/model"
+	silenceModel "k8s-monitoring-app/pkg/silence/model"
 )
 
 var ServerSvc *ServerServices
@@ -22,6 +36,13 @@ type ServerServices struct {
 	MetricType             metricTypeModel.Service
 	ApplicationMetric      applicationMetricModel.Service
 	ApplicationMetricValue applicationMetricValueModel.Service
+	Monitoring             monitoringModel.Service
+	NotificationChannel    notificationChannelModel.Service
+	AlertRule              alertRuleModel.Service
+	Silence                silenceModel.Service
+	AlertHistory           alertHistoryModel.Service
+	AlertTemplate          alertTemplateModel.Service
+	EscalationPolicy       escalationPolicyModel.Service
 }
 
 type ServerRepositories struct {
@@ -30,4 +51,11 @@ type ServerRepositories struct {
 	Application            applicationRepo.Repository
 	ApplicationMetric      applicationMetricRepo.Repository
 	ApplicationMetricValue applicationMetricValueRepo.Repository
+	NotificationChannel    notificationChannelRepo.Repository
+	AlertState             alertStateRepo.Repository
+	AlertRule              alertRuleRepo.Repository
+	Silence                silenceRepo.Repository
+	AlertHistory           alertHistoryRepo.Repository
+	AlertTemplate          alertTemplateRepo.Repository
+	EscalationPolicy       escalationPolicyRepo.Repository
 }
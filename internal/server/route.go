@@ -22,6 +22,13 @@ func bindRoutes(s *core.HTTPServer) {
 
 	// Health check (no auth required)
 	s.Api.GET("/health", s.WrapHandler(s.Health))
+	s.Api.GET("/health/ready", s.WrapHandler(model.ServerSvc.Monitoring.Ready))
+
+	// Prometheus scrape endpoint (no auth required)
+	s.Api.GET("/metrics", s.WrapHandler(model.ServerSvc.Monitoring.Metrics))
+
+	// Slack interactive buttons (no auth required, signed by Slack)
+	s.Api.POST("/slack/interactions", s.WrapHandler(model.ServerSvc.AlertHistory.SlackInteraction))
 
 	// Auth routes (no auth required)
 	authGroup := s.Api.Group("/auth")
@@ -37,12 +44,15 @@ func bindRoutes(s *core.HTTPServer) {
 	if webHandler != nil {
 		log.Info().Msg("Binding web UI routes")
 		s.Api.GET("/", s.WrapHandler(webHandler.Dashboard))
+		s.Api.GET("/alertas", s.WrapHandler(webHandler.RenderAlerts))
 		s.Api.Static("/static", "web/static")
 
 		// Registration pages
 		s.Api.GET("/cadastros/projetos", s.WrapHandler(webHandler.RenderCadastroProjects))
 		s.Api.GET("/cadastros/aplicacoes", s.WrapHandler(webHandler.RenderCadastroApplications))
 		s.Api.GET("/cadastros/metricas", s.WrapHandler(webHandler.RenderCadastroMetrics))
+		s.Api.GET("/cadastros/canais", s.WrapHandler(webHandler.RenderCadastroChannels))
+		s.Api.GET("/cadastros/silencios", s.WrapHandler(webHandler.RenderCadastroSilences))
 		// YAML Import page
 		s.Api.GET("/cadastros/importacao", s.WrapHandler(webHandler.RenderCadastroImportacao))
 
@@ -58,6 +68,10 @@ func bindRoutes(s *core.HTTPServer) {
 		apiUI.GET("/metric-types-options", s.WrapHandler(webHandler.GetMetricTypesOptions))
 		apiUI.GET("/dashboard-results", s.WrapHandler(webHandler.GetDashboardResults))
 		apiUI.GET("/metric-configuration-fields/:id", s.WrapHandler(webHandler.GetMetricConfigurationFields))
+		apiUI.GET("/channels-list", s.WrapHandler(webHandler.GetChannelsList))
+		apiUI.GET("/channel-settings-fields", s.WrapHandler(webHandler.GetChannelSettingsFields))
+		apiUI.GET("/silences-list", s.WrapHandler(webHandler.GetSilencesList))
+		apiUI.GET("/alerts-list", s.WrapHandler(webHandler.GetAlertsList))
 		// YAML Import processing endpoint
 		apiUI.POST("/import-yaml", s.WrapHandler(webHandler.ImportYAML))
 
@@ -65,6 +79,8 @@ func bindRoutes(s *core.HTTPServer) {
 		apiUI.DELETE("/metrics/:id", s.WrapHandler(webHandler.DeleteMetric))
 		apiUI.DELETE("/applications/:id", s.WrapHandler(webHandler.DeleteApplication))
 		apiUI.DELETE("/projects/:id", s.WrapHandler(webHandler.DeleteProject))
+		apiUI.DELETE("/notification-channels/:id", s.WrapHandler(webHandler.DeleteChannel))
+		apiUI.DELETE("/silences/:id", s.WrapHandler(webHandler.DeleteSilence))
 	} else {
 		log.Warn().Msg("Web handler is nil, skipping web UI routes")
 	}
@@ -96,11 +112,61 @@ func bindRoutes(s *core.HTTPServer) {
 	apiV1.GET("/application-metrics/:id", s.WrapHandler(model.ServerSvc.ApplicationMetric.Get))
 	apiV1.GET("/applications/:application_id/metrics", s.WrapHandler(model.ServerSvc.ApplicationMetric.ListByApplication))
 	apiV1.POST("/application-metrics", s.WrapHandler(model.ServerSvc.ApplicationMetric.Add))
+	apiV1.POST("/application-metrics/test", s.WrapHandler(model.ServerSvc.Monitoring.Test))
 	apiV1.PUT("/application-metrics/:id", s.WrapHandler(model.ServerSvc.ApplicationMetric.Update))
 	apiV1.DELETE("/application-metrics/:id", s.WrapHandler(model.ServerSvc.ApplicationMetric.Delete))
+	apiV1.POST("/application-metrics/:id/collect", s.WrapHandler(model.ServerSvc.Monitoring.Collect))
+	apiV1.GET("/application-metrics/:id/alert-state", s.WrapHandler(model.ServerSvc.Monitoring.AlertState))
 
 	// Application Metric Value routes (read-only - values are collected by cron)
 	apiV1.GET("/metric-values/:id", s.WrapHandler(model.ServerSvc.ApplicationMetricValue.Get))
 	apiV1.GET("/application-metrics/:application_metric_id/values", s.WrapHandler(model.ServerSvc.ApplicationMetricValue.ListByApplicationMetric))
+	apiV1.GET("/application-metrics/:id/series", s.WrapHandler(model.ServerSvc.ApplicationMetricValue.Series))
 	apiV1.GET("/applications/:application_id/latest-metrics", s.WrapHandler(model.ServerSvc.ApplicationMetricValue.GetLatestByApplication))
+
+	// Notification channel routes
+	apiV1.GET("/notification-channels", s.WrapHandler(model.ServerSvc.NotificationChannel.List))
+	apiV1.GET("/notification-channels/:id", s.WrapHandler(model.ServerSvc.NotificationChannel.Get))
+	apiV1.GET("/projects/:project_id/notification-channels", s.WrapHandler(model.ServerSvc.NotificationChannel.ListByProject))
+	apiV1.POST("/notification-channels", s.WrapHandler(model.ServerSvc.NotificationChannel.Add))
+	apiV1.PUT("/notification-channels/:id", s.WrapHandler(model.ServerSvc.NotificationChannel.Update))
+	apiV1.DELETE("/notification-channels/:id", s.WrapHandler(model.ServerSvc.NotificationChannel.Delete))
+	apiV1.POST("/notification-channels/:id/test", s.WrapHandler(model.ServerSvc.NotificationChannel.Test))
+
+	// Alert rule routes
+	apiV1.GET("/alert-rules", s.WrapHandler(model.ServerSvc.AlertRule.List))
+	apiV1.GET("/alert-rules/:id", s.WrapHandler(model.ServerSvc.AlertRule.Get))
+	apiV1.GET("/application-metrics/:id/alert-rules", s.WrapHandler(model.ServerSvc.AlertRule.ListByApplicationMetric))
+	apiV1.POST("/alert-rules", s.WrapHandler(model.ServerSvc.AlertRule.Add))
+	apiV1.PUT("/alert-rules/:id", s.WrapHandler(model.ServerSvc.AlertRule.Update))
+	apiV1.DELETE("/alert-rules/:id", s.WrapHandler(model.ServerSvc.AlertRule.Delete))
+
+	// Alert template routes
+	apiV1.GET("/alert-templates", s.WrapHandler(model.ServerSvc.AlertTemplate.List))
+	apiV1.GET("/alert-templates/:id", s.WrapHandler(model.ServerSvc.AlertTemplate.Get))
+	apiV1.POST("/alert-templates", s.WrapHandler(model.ServerSvc.AlertTemplate.Add))
+	apiV1.PUT("/alert-templates/:id", s.WrapHandler(model.ServerSvc.AlertTemplate.Update))
+	apiV1.DELETE("/alert-templates/:id", s.WrapHandler(model.ServerSvc.AlertTemplate.Delete))
+
+	// Escalation policy routes
+	apiV1.GET("/escalation-policies", s.WrapHandler(model.ServerSvc.EscalationPolicy.List))
+	apiV1.GET("/escalation-policies/:id", s.WrapHandler(model.ServerSvc.EscalationPolicy.Get))
+	apiV1.POST("/escalation-policies", s.WrapHandler(model.ServerSvc.EscalationPolicy.Add))
+	apiV1.PUT("/escalation-policies/:id", s.WrapHandler(model.ServerSvc.EscalationPolicy.Update))
+	apiV1.DELETE("/escalation-policies/:id", s.WrapHandler(model.ServerSvc.EscalationPolicy.Delete))
+
+	// Silence and maintenance window routes
+	apiV1.GET("/silences", s.WrapHandler(model.ServerSvc.Silence.List))
+	apiV1.GET("/silences/:id", s.WrapHandler(model.ServerSvc.Silence.Get))
+	apiV1.POST("/silences", s.WrapHandler(model.ServerSvc.Silence.Add))
+	apiV1.PUT("/silences/:id", s.WrapHandler(model.ServerSvc.Silence.Update))
+	apiV1.DELETE("/silences/:id", s.WrapHandler(model.ServerSvc.Silence.Delete))
+
+	// Alert history routes
+	apiV1.GET("/alerts", s.WrapHandler(model.ServerSvc.AlertHistory.List))
+	apiV1.GET("/alerts/:id", s.WrapHandler(model.ServerSvc.AlertHistory.Get))
+	apiV1.POST("/alerts/:id/acknowledge", s.WrapHandler(model.ServerSvc.AlertHistory.Acknowledge))
+
+	// Monitoring routes
+	apiV1.GET("/monitoring/status", s.WrapHandler(model.ServerSvc.Monitoring.Status))
 }
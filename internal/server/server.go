@@ -1,43 +1,64 @@
 package server
 
 import (
+	"context"
+	"database/sql"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 
 	"k8s-monitoring-app/internal/auth"
+	"k8s-monitoring-app/internal/collector"
 	"k8s-monitoring-app/internal/core"
 	model "k8s-monitoring-app/internal/server/model"
-
+	metricTypeModel "k8s-monitoring-app/pkg/metric_type/model"
+
+	alertHistoryService "k8s-monitoring-app/internal/alert_history"
+	alertHistoryRepositories "k8s-monitoring-app/internal/alert_history/repository"
+	alertRuleService "k8s-monitoring-app/internal/alert_rule"
+	alertRuleRepositories "k8s-monitoring-app/internal/alert_rule/repository"
+	alertStateRepositories "k8s-monitoring-app/internal/alert_state/repository"
+	alertTemplateService "k8s-monitoring-app/internal/alert_template"
+	alertTemplateRepositories "k8s-monitoring-app/internal/alert_template/repository"
 	applicationService "k8s-monitoring-app/internal/application"
 	applicationRepositories "k8s-monitoring-app/internal/application/repository"
 	applicationMetricService "k8s-monitoring-app/internal/application_metric"
 	applicationMetricRepositories "k8s-monitoring-app/internal/application_metric/repository"
 	applicationMetricValueService "k8s-monitoring-app/internal/application_metric_value"
 	applicationMetricValueRepositories "k8s-monitoring-app/internal/application_metric_value/repository"
+	escalationPolicyService "k8s-monitoring-app/internal/escalation_policy"
+	escalationPolicyRepositories "k8s-monitoring-app/internal/escalation_policy/repository"
 	metricTypeService "k8s-monitoring-app/internal/metric_type"
 	metricTypeRepositories "k8s-monitoring-app/internal/metric_type/repository"
+	"k8s-monitoring-app/internal/monitoring"
+	notificationChannelService "k8s-monitoring-app/internal/notification_channel"
+	notificationChannelRepositories "k8s-monitoring-app/internal/notification_channel/repository"
 	projectService "k8s-monitoring-app/internal/project"
 	projectRepositories "k8s-monitoring-app/internal/project/repository"
+	silenceService "k8s-monitoring-app/internal/silence"
+	silenceRepositories "k8s-monitoring-app/internal/silence/repository"
 
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
 	"github.com/golang-migrate/migrate/v4/database/sqlite"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	_ "github.com/golang-migrate/migrate/v4/source/github"
 	"github.com/labstack/echo/v4"
 	"github.com/rs/zerolog/log"
-	_ "go.elastic.co/apm/module/apmsql/sqlite3"
 )
 
-// findMigrationsPath tries to find the migrations directory from different working directories
-func findMigrationsPath() string {
+// findMigrationsPath tries to find the migrations directory of the given
+// dialect (sqlite or postgresql) from different working directories
+func findMigrationsPath(dialect string) string {
 	possiblePaths := []string{
-		"database/migrations/sqlite",       // Prefer SQLite migrations when present
-		"../database/migrations/sqlite",    // Running from cmd directory
-		"../../database/migrations/sqlite", // Running from deeper directory
-		"database/migrations",              // Fallback to root migrations
-		"../database/migrations",           // Fallback from cmd
-		"../../database/migrations",        // Fallback from deeper directory
+		"database/migrations/" + dialect,       // Prefer the dialect migrations when present
+		"../database/migrations/" + dialect,    // Running from cmd directory
+		"../../database/migrations/" + dialect, // Running from deeper directory
+		"database/migrations",                  // Fallback to root migrations
+		"../database/migrations",               // Fallback from cmd
+		"../../database/migrations",            // Fallback from deeper directory
 	}
 
 	for _, path := range possiblePaths {
@@ -48,33 +69,66 @@ func findMigrationsPath() string {
 	}
 
 	// Default fallback (running from cmd)
-	return "file://../database/migrations/sqlite"
+	return "file://../database/migrations/" + dialect
 }
 
-func NewHTTPServer(config *core.ApiServiceConfiguration) (*core.HTTPServer, error) {
-	// Initialize OAuth
-	if err := auth.InitOAuth(); err != nil {
-		log.Warn().Err(err).Msg("OAuth not configured - authentication will be disabled")
+// migrateDatabase applies the migrations of the configured database backend
+func migrateDatabase(d *sql.DB) error {
+	var (
+		driver       database.Driver
+		databaseName string
+		dialect      string
+		err          error
+	)
+	switch core.DatabaseDriver() {
+	case core.DriverPostgres:
+		driver, err = postgres.WithInstance(d, &postgres.Config{})
+		databaseName, dialect = "postgres", "postgresql"
+	default:
+		driver, err = sqlite.WithInstance(d, &sqlite.Config{})
+		databaseName, dialect = "sqlite3", "sqlite"
 	}
-
-	d, err := core.ConnectDatabase()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	driver, err := sqlite.WithInstance(d, &sqlite.Config{})
+	m, err := migrate.NewWithDatabaseInstance(findMigrationsPath(dialect), databaseName, driver)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	migrationsPath := findMigrationsPath()
-	m, err := migrate.NewWithDatabaseInstance(migrationsPath, "sqlite3", driver)
+	err = m.Up()
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// seedMetricTypes makes sure every registered collector has a metric_types row,
+// so new metric types only need to be added to the collector registry
+func seedMetricTypes(ctx context.Context) error {
+	for _, c := range collector.All() {
+		metricType := metricTypeModel.MetricType{Name: c.Name(), Description: c.Description()}
+		if err := model.ServerRepos.MetricType.Upsert(ctx, &metricType); err != nil {
+			return fmt.Errorf("failed to seed metric type %s: %w", c.Name(), err)
+		}
+	}
+	log.Info().Int("metric_types", len(collector.All())).Msg("Metric types seeded from collector registry")
+	return nil
+}
+
+func NewHTTPServer(config *core.ApiServiceConfiguration) (*core.HTTPServer, error) {
+	// Initialize OAuth
+	if err := auth.InitOAuth(); err != nil {
+		log.Warn().Err(err).Msg("OAuth not configured - authentication will be disabled")
+	}
+
+	d, err := core.ConnectDatabase()
 	if err != nil {
 		return nil, err
 	}
 
-	err = m.Up()
-	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+	if err := migrateDatabase(d); err != nil {
 		return nil, err
 	}
 
@@ -82,7 +136,7 @@ func NewHTTPServer(config *core.ApiServiceConfiguration) (*core.HTTPServer, erro
 	s := &core.HTTPServer{
 		Config: config,
 		Api:    e,
-		SQLite: d,
+		DB:     d,
 	}
 
 	model.ServerSvc = &model.ServerServices{
@@ -91,6 +145,12 @@ func NewHTTPServer(config *core.ApiServiceConfiguration) (*core.HTTPServer, erro
 		MetricType:             metricTypeService.NewService(),
 		ApplicationMetric:      applicationMetricService.NewService(),
 		ApplicationMetricValue: applicationMetricValueService.NewService(),
+		NotificationChannel:    notificationChannelService.NewService(),
+		AlertRule:              alertRuleService.NewService(),
+		Silence:                silenceService.NewService(),
+		AlertHistory:           alertHistoryService.NewService(),
+		AlertTemplate:          alertTemplateService.NewService(),
+		EscalationPolicy:       escalationPolicyService.NewService(),
 	}
 
 	model.ServerRepos = &model.ServerRepositories{
@@ -99,7 +159,24 @@ func NewHTTPServer(config *core.ApiServiceConfiguration) (*core.HTTPServer, erro
 		MetricType:             metricTypeRepositories.NewRepo(d),
 		ApplicationMetric:      applicationMetricRepositories.NewRepo(d),
 		ApplicationMetricValue: applicationMetricValueRepositories.NewRepo(d),
+		NotificationChannel:    notificationChannelRepositories.NewRepo(d),
+		AlertState:             alertStateRepositories.NewRepo(d),
+		AlertRule:              alertRuleRepositories.NewRepo(d),
+		Silence:                silenceRepositories.NewRepo(d),
+		AlertHistory:           alertHistoryRepositories.NewRepo(d),
+		AlertTemplate:          alertTemplateRepositories.NewRepo(d),
+		EscalationPolicy:       escalationPolicyRepositories.NewRepo(d),
+	}
+
+	if err := seedMetricTypes(context.Background()); err != nil {
+		return nil, err
+	}
+
+	monitoringSvc, err := monitoring.NewMonitoringService(d)
+	if err != nil {
+		return nil, err
 	}
+	model.ServerSvc.Monitoring = monitoringSvc
 
 	e.Use(
 		auth.AuthMiddleware(),
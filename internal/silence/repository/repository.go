@@ -0,0 +1,226 @@
+package silence
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s-monitoring-app/internal/core"
+	silenceModel "k8s-monitoring-app/pkg/silence/model"
+)
+
+// generateUUID generates a simple UUID v4
+func generateUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+type Repository interface {
+	Get(ctx context.Context, id string) (silenceModel.Silence, error)
+	List(ctx context.Context) ([]silenceModel.Silence, error)
+	// ListCurrent returns the silences started and not ended at the given
+	// time. Maintenance windows among them may still be outside their window.
+	ListCurrent(ctx context.Context, now time.Time) ([]silenceModel.Silence, error)
+	Add(ctx context.Context, silence *silenceModel.Silence) error
+	Update(ctx context.Context, silence *silenceModel.Silence) error
+	Delete(ctx context.Context, id string) error
+	GetDB() *sql.DB
+}
+
+type repository struct {
+	db *sql.DB
+}
+
+func NewRepo(db *sql.DB) Repository {
+	return &repository{
+		db: db,
+	}
+}
+
+func (repo *repository) GetDB() *sql.DB {
+	return repo.db
+}
+
+const selectSilences = `
+	SELECT
+		id, project_id, application_id, application_metric_id, metric_type_id, starts_at, ends_at,
+		weekdays, window_start, window_end, timezone, created_by, reason, created_at, updated_at
+	FROM
+		silences`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSilence(row scanner) (silenceModel.Silence, error) {
+	silence := silenceModel.Silence{}
+	var projectID, applicationID, applicationMetricID, metricTypeID sql.NullString
+	var weekdays, windowStart, windowEnd, timezone, createdBy sql.NullString
+	var endsAt sql.NullTime
+	err := row.Scan(
+		&silence.ID, &projectID, &applicationID, &applicationMetricID, &metricTypeID, &silence.StartsAt, &endsAt,
+		&weekdays, &windowStart, &windowEnd, &timezone, &createdBy, &silence.Reason, &silence.CreatedAt, &silence.UpdatedAt)
+	if err != nil {
+		return silence, err
+	}
+	silence.ProjectID = projectID.String
+	silence.ApplicationID = applicationID.String
+	silence.ApplicationMetricID = applicationMetricID.String
+	silence.MetricTypeID = metricTypeID.String
+	silence.CreatedBy = createdBy.String
+	if endsAt.Valid {
+		silence.EndsAt = &endsAt.Time
+	}
+	if windowStart.Valid {
+		silence.Window = &silenceModel.Window{
+			Start:    windowStart.String,
+			End:      windowEnd.String,
+			Timezone: timezone.String,
+		}
+		if weekdays.String != "" {
+			silence.Window.Weekdays = strings.Split(weekdays.String, ",")
+		}
+	}
+
+	return silence, nil
+}
+
+func (repo *repository) Get(ctx context.Context, id string) (silenceModel.Silence, error) {
+	sqlString := fmt.Sprintf("%s WHERE id = ?", selectSilences)
+
+	return scanSilence(repo.db.QueryRowContext(ctx, core.Rebind(sqlString), id))
+}
+
+func (repo *repository) List(ctx context.Context) ([]silenceModel.Silence, error) {
+	sqlString := fmt.Sprintf("%s ORDER BY starts_at DESC", selectSilences)
+
+	return repo.list(ctx, sqlString)
+}
+
+func (repo *repository) ListCurrent(ctx context.Context, now time.Time) ([]silenceModel.Silence, error) {
+	sqlString := fmt.Sprintf("%s WHERE starts_at <= ? AND (ends_at IS NULL OR ends_at > ?) ORDER BY starts_at", selectSilences)
+
+	return repo.list(ctx, sqlString, now.UTC(), now.UTC())
+}
+
+func (repo *repository) list(ctx context.Context, sqlString string, args ...interface{}) ([]silenceModel.Silence, error) {
+	silences := []silenceModel.Silence{}
+
+	rows, err := repo.db.QueryContext(ctx, core.Rebind(sqlString), args...)
+	if err != nil {
+		return silences, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		silence, err := scanSilence(rows)
+		if err != nil {
+			return silences, err
+		}
+
+		silences = append(silences, silence)
+	}
+
+	return silences, rows.Err()
+}
+
+func (repo *repository) Add(ctx context.Context, silence *silenceModel.Silence) error {
+	silence.ID = generateUUID()
+	now := time.Now()
+	silence.CreatedAt = now
+	silence.UpdatedAt = now
+
+	weekdays, windowStart, windowEnd, timezone := windowColumns(silence.Window)
+
+	sqlString := `INSERT INTO silences(
+		id, project_id, application_id, application_metric_id, metric_type_id, starts_at, ends_at,
+		weekdays, window_start, window_end, timezone, created_by, reason, created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := repo.db.ExecContext(ctx, core.Rebind(sqlString),
+		silence.ID, nullString(silence.ProjectID), nullString(silence.ApplicationID),
+		nullString(silence.ApplicationMetricID), nullString(silence.MetricTypeID), silence.StartsAt.UTC(), nullTime(silence.EndsAt),
+		weekdays, windowStart, windowEnd, timezone, nullString(silence.CreatedBy), silence.Reason,
+		silence.CreatedAt, silence.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Update stores the period, window and reason of a silence. Its scope and
+// author are fixed.
+func (repo *repository) Update(ctx context.Context, silence *silenceModel.Silence) error {
+	weekdays, windowStart, windowEnd, timezone := windowColumns(silence.Window)
+
+	sqlString := `UPDATE silences SET
+		starts_at = ?, ends_at = ?, weekdays = ?, window_start = ?, window_end = ?, timezone = ?,
+		reason = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?`
+
+	result, err := repo.db.ExecContext(ctx, core.Rebind(sqlString),
+		silence.StartsAt.UTC(), nullTime(silence.EndsAt), weekdays, windowStart, windowEnd, timezone,
+		silence.Reason, silence.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (repo *repository) Delete(ctx context.Context, id string) error {
+	sqlString := `DELETE FROM silences WHERE id = ?`
+
+	result, err := repo.db.ExecContext(ctx, core.Rebind(sqlString), id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// windowColumns splits a maintenance window into its columns, all NULL for
+// one-off silences
+func windowColumns(window *silenceModel.Window) (weekdays, start, end, timezone sql.NullString) {
+	if window == nil {
+		return
+	}
+	return nullString(strings.Join(window.Weekdays, ",")), nullString(window.Start),
+		nullString(window.End), nullString(window.Timezone)
+}
+
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
@@ -0,0 +1,224 @@
+package silence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s-monitoring-app/internal/auth"
+	"k8s-monitoring-app/internal/core"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	model "k8s-monitoring-app/pkg/silence/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+type service struct{}
+
+func NewService() model.Service {
+	return &service{}
+}
+
+func (s *service) Get(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	id := sc.Param("id")
+
+	if len(id) == 0 {
+		log.Error().Err(errors.New("id is empty")).Msg("error getting silence")
+		return sc.String(http.StatusBadRequest, "invalid request")
+	}
+
+	silence, err := serverModel.ServerRepos.Silence.Get(ctx, id)
+	if err != nil {
+		log.Error().Msg("error getting silence")
+		return sc.String(http.StatusNotFound, "silence not found")
+	}
+	silence.Active = silence.ActiveAt(time.Now())
+
+	return sc.JSON(http.StatusOK, silence)
+}
+
+// List returns every silence, or with ?active=true only the ones holding
+// back alerts right now
+func (s *service) List(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	silences, err := serverModel.ServerRepos.Silence.List(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("error listing silences")
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	}
+
+	now := time.Now()
+	onlyActive := sc.QueryParam("active") == "true"
+	result := make([]model.Silence, 0, len(silences))
+	for _, silence := range silences {
+		silence.Active = silence.ActiveAt(now)
+		if onlyActive && !silence.Active {
+			continue
+		}
+		result = append(result, silence)
+	}
+
+	return sc.JSON(http.StatusOK, result)
+}
+
+func (s *service) Add(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	silence := model.Silence{}
+	if err := sc.Bind(&silence); err != nil {
+		log.Error().Msg("error binding silence")
+		return sc.String(http.StatusBadRequest, "invalid request body")
+	}
+	silence.Reason = strings.TrimSpace(silence.Reason)
+	if silence.StartsAt.IsZero() {
+		silence.StartsAt = time.Now()
+	}
+	normalizeWindow(silence.Window)
+
+	// The author is the signed-in user; without authentication the given one is kept
+	if email, _, _, ok := auth.GetUserFromContext(sc); ok && email != "" {
+		silence.CreatedBy = email
+	}
+
+	if err := silence.Validate(); err != nil {
+		return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid silence",
+			"message": err.Error(),
+		})
+	}
+	if msg := validateScope(ctx, silence); msg != "" {
+		return sc.String(http.StatusBadRequest, msg)
+	}
+
+	if err := serverModel.ServerRepos.Silence.Add(ctx, &silence); err != nil {
+		log.Error().Err(err).Msg("error add silence")
+		return sc.String(http.StatusInternalServerError, "internal server error")
+	}
+	silence.Active = silence.ActiveAt(time.Now())
+
+	log.Info().
+		Str("silence_id", silence.ID).
+		Str("created_by", silence.CreatedBy).
+		Str("reason", silence.Reason).
+		Msg("silence created")
+
+	return sc.JSON(http.StatusCreated, silence)
+}
+
+// Update changes the period, window or reason of a silence. Setting ends_at
+// to now expires it.
+func (s *service) Update(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+	id := sc.Param("id")
+
+	existing, err := serverModel.ServerRepos.Silence.Get(ctx, id)
+	if err != nil {
+		log.Error().Msg("error getting silence")
+		return sc.String(http.StatusNotFound, "silence not found")
+	}
+
+	silence := model.Silence{}
+	if err := sc.Bind(&silence); err != nil {
+		log.Error().Msg("error binding silence")
+		return sc.String(http.StatusBadRequest, "Invalid Request")
+	}
+
+	// The scope and the author of a silence are fixed
+	merged := existing
+	if !silence.StartsAt.IsZero() {
+		merged.StartsAt = silence.StartsAt
+	}
+	if silence.EndsAt != nil {
+		merged.EndsAt = silence.EndsAt
+	}
+	if silence.Window != nil {
+		normalizeWindow(silence.Window)
+		merged.Window = silence.Window
+	}
+	if reason := strings.TrimSpace(silence.Reason); reason != "" {
+		merged.Reason = reason
+	}
+	if err := merged.Validate(); err != nil {
+		return sc.JSON(http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid silence",
+			"message": err.Error(),
+		})
+	}
+
+	if err := serverModel.ServerRepos.Silence.Update(ctx, &merged); err != nil {
+		log.Error().Err(err).Msg("error updating silence")
+		return sc.String(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	updated, err := serverModel.ServerRepos.Silence.Get(ctx, id)
+	if err != nil {
+		log.Error().Err(err).Msg("error getting silence")
+		return sc.String(http.StatusInternalServerError, "Internal Server Error")
+	}
+	updated.Active = updated.ActiveAt(time.Now())
+
+	return sc.JSON(http.StatusOK, updated)
+}
+
+func (s *service) Delete(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+	id := sc.Param("id")
+
+	if len(id) == 0 {
+		log.Error().Err(errors.New("id is empty")).Msg("error deleting silence")
+		return sc.String(http.StatusBadRequest, "Invalid Request")
+	}
+
+	err := serverModel.ServerRepos.Silence.Delete(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sc.String(http.StatusNotFound, "silence not found")
+		}
+		log.Error().Err(err).Str("id", id).Msg("error deleting silence")
+		return sc.String(http.StatusInternalServerError, "Internal Server Error")
+	}
+
+	return sc.JSON(http.StatusOK, map[string]bool{"success": true})
+}
+
+// validateScope checks that the project, application, metric and metric type
+// a silence refers to exist, returning the error message otherwise
+func validateScope(ctx context.Context, silence model.Silence) string {
+	if silence.ProjectID != "" {
+		if _, err := serverModel.ServerRepos.Project.Get(ctx, silence.ProjectID); err != nil {
+			return "project not found"
+		}
+	}
+	if silence.ApplicationID != "" {
+		if _, err := serverModel.ServerRepos.Application.Get(ctx, silence.ApplicationID); err != nil {
+			return "application not found"
+		}
+	}
+	if silence.ApplicationMetricID != "" {
+		if _, err := serverModel.ServerRepos.ApplicationMetric.Get(ctx, silence.ApplicationMetricID); err != nil {
+			return "application metric not found"
+		}
+	}
+	if silence.MetricTypeID != "" {
+		if _, err := serverModel.ServerRepos.MetricType.Get(ctx, silence.MetricTypeID); err != nil {
+			return "metric type not found"
+		}
+	}
+	return ""
+}
+
+// normalizeWindow lowercases the weekdays of a maintenance window
+func normalizeWindow(window *model.Window) {
+	if window == nil {
+		return
+	}
+	for i, day := range window.Weekdays {
+		window.Weekdays[i] = strings.ToLower(strings.TrimSpace(day))
+	}
+}
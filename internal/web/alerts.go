@@ -0,0 +1,125 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	alertHistory "k8s-monitoring-app/internal/alert_history"
+	"k8s-monitoring-app/internal/alerts"
+	"k8s-monitoring-app/internal/core"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	alertHistoryModel "k8s-monitoring-app/pkg/alert_history/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RenderAlerts renders the alert history page
+func (h *Handler) RenderAlerts(sc *core.HTTPServerContext) error {
+	// Get user info from context (set by auth middleware)
+	userEmail := sc.Get("user_email")
+	userName := sc.Get("user_name")
+	userPicture := sc.Get("user_picture")
+
+	data := map[string]interface{}{
+		"Title":       "Histórico de Alertas",
+		"UserEmail":   userEmail,
+		"UserName":    userName,
+		"UserPicture": userPicture,
+		"Severities":  alerts.Severities,
+	}
+
+	sc.Response().Header().Set("Content-Type", "text/html")
+	sc.Response().WriteHeader(http.StatusOK)
+
+	if err := h.templates.ExecuteTemplate(sc.Response().Writer, "alertas.html", data); err != nil {
+		log.Error().Err(err).Msg("error executing alertas template")
+		return err
+	}
+
+	return nil
+}
+
+// GetAlertsList returns the filtered alert history for HTMX partial
+func (h *Handler) GetAlertsList(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	filter, err := alertHistory.ParseFilter(sc)
+	if err != nil {
+		return sc.String(http.StatusBadRequest, err.Error())
+	}
+	// The "Todos" project option lists every project
+	if filter.ProjectID == "all" {
+		filter.ProjectID = ""
+	}
+
+	history, err := serverModel.ServerRepos.AlertHistory.List(ctx, filter)
+	if err != nil {
+		log.Error().Err(err).Msg("error listing alerts")
+		return sc.String(http.StatusInternalServerError, "Error loading alerts")
+	}
+
+	sc.Response().Header().Set("Content-Type", "text/html")
+	sc.Response().WriteHeader(http.StatusOK)
+
+	if len(history) == 0 {
+		_, err := sc.Response().Writer.Write([]byte(`<p>Nenhum alerta encontrado.</p>`))
+		return err
+	}
+
+	type AlertDisplay struct {
+		alertHistoryModel.Alert
+		ProjectName      string
+		ApplicationName  string
+		StatusLabel      string
+		FiredAtText      string
+		ResolvedAtText   string
+		DurationText     string
+		AcknowledgedText string
+		EscalatedText    string
+	}
+
+	const layout = "02/01/2006 15:04:05 (UTC)"
+	projectNames := map[string]string{}
+	applicationNames := map[string]string{}
+	now := time.Now()
+	for _, alert := range history {
+		display := AlertDisplay{
+			Alert:        alert,
+			StatusLabel:  "Disparado",
+			FiredAtText:  alert.FiredAt.UTC().Format(layout),
+			DurationText: alert.Duration(now).String(),
+		}
+		if alert.ResolvedAt != nil {
+			display.StatusLabel = "Resolvido"
+			display.ResolvedAtText = alert.ResolvedAt.UTC().Format(layout)
+		}
+		if alert.AcknowledgedAt != nil {
+			display.AcknowledgedText = alert.AcknowledgedAt.UTC().Format(layout)
+		}
+		if alert.EscalatedAt != nil {
+			display.EscalatedText = alert.EscalatedAt.UTC().Format(layout)
+		}
+
+		if _, ok := projectNames[alert.ProjectID]; !ok {
+			projectNames[alert.ProjectID] = "N/A"
+			if project, err := serverModel.ServerRepos.Project.Get(ctx, alert.ProjectID); err == nil {
+				projectNames[alert.ProjectID] = project.Name
+			}
+		}
+		if _, ok := applicationNames[alert.ApplicationID]; !ok {
+			applicationNames[alert.ApplicationID] = "N/A"
+			if application, err := serverModel.ServerRepos.Application.Get(ctx, alert.ApplicationID); err == nil {
+				applicationNames[alert.ApplicationID] = application.Name
+			}
+		}
+		display.ProjectName = projectNames[alert.ProjectID]
+		display.ApplicationName = applicationNames[alert.ApplicationID]
+
+		if err := h.templates.ExecuteTemplate(sc.Response().Writer, "alert-list-item", display); err != nil {
+			log.Error().Msg("error executing template")
+			return err
+		}
+	}
+
+	return nil
+}
@@ -12,7 +12,7 @@ import (
 	"reflect"
 	"strings"
 
-	application_metric "k8s-monitoring-app/internal/application_metric"
+	"k8s-monitoring-app/internal/collector"
 	"k8s-monitoring-app/internal/core"
 	"k8s-monitoring-app/internal/security"
 	serverModel "k8s-monitoring-app/internal/server/model"
@@ -194,73 +194,58 @@ func (h *Handler) ImportYAML(sc *core.HTTPServerContext) error {
 		return ""
 	}
 
-	// Normalize configuration keys to match JSON expected by Configuration
-	normalizeConfig := func(metricTypeName string, cfg map[string]interface{}) map[string]interface{} {
-		if cfg == nil {
-			return map[string]interface{}{}
+	// Helper: get the optional retention override from metadata
+	getRetention := func(m map[string]interface{}) (*int, error) {
+		for _, key := range []string{"retention_days", "retentionDays"} {
+			v, ok := m[key]
+			if !ok || v == nil {
+				continue
+			}
+			days, ok := v.(int)
+			if !ok {
+				return nil, fmt.Errorf("%s deve ser um número inteiro de dias", key)
+			}
+			if err := applicationMetricValueModel.ValidateRetentionDays(days); err != nil {
+				return nil, err
+			}
+			return &days, nil
 		}
-		out := map[string]interface{}{}
+		return nil, nil
+	}
 
-		// Utility to copy if present
-		copyKey := func(dstKey string, candidates ...string) {
-			for _, c := range candidates {
-				if v, ok := cfg[c]; ok {
-					out[dstKey] = v
-					return
-				}
+	// Helper: get an optional consecutive-collection threshold from metadata
+	getThreshold := func(m map[string]interface{}, keys ...string) (*int, error) {
+		for _, key := range keys {
+			v, ok := m[key]
+			if !ok || v == nil {
+				continue
 			}
+			n, ok := v.(int)
+			if !ok || n < 0 || n > applicationMetricModel.MaxConsecutiveThreshold {
+				return nil, fmt.Errorf("%s deve ser um número inteiro entre 0 e %d", key, applicationMetricModel.MaxConsecutiveThreshold)
+			}
+			return &n, nil
 		}
+		return nil, nil
+	}
 
-		// Common mappings
-		copyKey("method", "method")
-		// URL / HealthCheck
-		copyKey("health_check_url", "health_check_url", "url", "healthCheckUrl")
-
-		// Distinguish timeout mapping
-		// For HealthCheck (timeout_seconds), for connection types (connection_timeout)
-		if metricTypeName == "HealthCheck" || metricTypeName == "IngressCertificate" || strings.HasPrefix(metricTypeName, "Pod") || metricTypeName == "PvcUsage" || metricTypeName == "KafkaConsumerLag" {
-			copyKey("timeout_seconds", "timeout_seconds", "timeout", "timeoutSeconds")
-		} else {
-			copyKey("connection_timeout", "connection_timeout", "timeout", "timeoutSeconds", "connectionTimeout")
+	// Normalize configuration keys to the schema declared by the metric type collector,
+	// accepting the aliases each field allows (e.g. "host" for "connection_host")
+	normalizeConfig := func(c collector.Collector, cfg map[string]interface{}) map[string]interface{} {
+		out := map[string]interface{}{}
+		if cfg == nil {
+			return out
 		}
 
-		// HealthCheck
-		copyKey("expected_status", "expected_status", "expectedStatus")
-
-		// Pods / PVC
-		copyKey("pod_label_selector", "pod_label_selector", "podLabelSelector")
-		copyKey("container_name", "container_name", "containerName")
-		copyKey("pvc_name", "pvc_name", "pvcName")
-		copyKey("pvc_mount_path", "pvc_mount_path", "pvcMountPath")
-
-		// Connections
-		copyKey("connection_host", "connection_host", "host", "connectionHost")
-		copyKey("connection_port", "connection_port", "port", "connectionPort")
-		copyKey("connection_username", "connection_username", "username", "connectionUsername")
-		copyKey("connection_password", "connection_password", "password", "connectionPassword")
-		copyKey("connection_database", "connection_database", "database", "connectionDatabase")
-		copyKey("connection_ssl", "connection_ssl", "ssl", "connectionSSL")
-		copyKey("connection_auth_source", "connection_auth_source", "authSource", "connectionAuthSource")
-		copyKey("connection_db", "connection_db", "db", "connectionDB")
-
-		// Kong
-		copyKey("kong_admin_url", "kong_admin_url", "adminUrl", "kongAdminUrl")
-
-		// Ingress
-		copyKey("ingress_name", "ingress_name", "ingressName")
-		copyKey("ingress_namespace", "ingress_namespace", "ingressNamespace")
-		copyKey("tls_secret_name", "tls_secret_name", "tlsSecretName")
-		copyKey("warning_days", "warning_days", "warningDays")
-
-		// Kafka
-		copyKey("kafka_bootstrap_servers", "kafka_bootstrap_servers", "bootstrapServers")
-		copyKey("kafka_consumer_group", "kafka_consumer_group", "consumerGroup")
-		copyKey("kafka_topic", "kafka_topic", "topic")
-		copyKey("kafka_security_protocol", "kafka_security_protocol", "securityProtocol")
-		copyKey("kafka_sasl_mechanism", "kafka_sasl_mechanism", "saslMechanism")
-		copyKey("kafka_sasl_username", "kafka_sasl_username", "saslUsername")
-		copyKey("kafka_sasl_password", "kafka_sasl_password", "saslPassword")
-		copyKey("kafka_lag_threshold", "kafka_lag_threshold", "lagThreshold")
+		for _, field := range c.ConfigFields() {
+			candidates := append([]string{field.Name}, field.Aliases...)
+			for _, key := range candidates {
+				if v, ok := cfg[key]; ok {
+					out[field.Name] = v
+					break
+				}
+			}
+		}
 
 		return out
 	}
@@ -295,12 +280,18 @@ func (h *Handler) ImportYAML(sc *core.HTTPServerContext) error {
 				results = append(results, `<div class="alert alert-error">Project: campo "name" é obrigatório</div>`)
 				continue
 			}
+			retentionDays, err := getRetention(d.Metadata)
+			if err != nil {
+				results = append(results, fmt.Sprintf(`<div class="alert alert-error">Retenção inválida para o projeto "%s": %s</div>`, template.HTMLEscapeString(name), template.HTMLEscapeString(err.Error())))
+				continue
+			}
 
 			// Try to get existing by name
 			proj, err := serverModel.ServerRepos.Project.Get(ctx, name, "name")
 			if err == nil && proj.ID != "" {
 				// Update existing project
 				proj.Description = desc
+				proj.RetentionDays = retentionDays
 				if err := serverModel.ServerRepos.Project.Update(ctx, &proj); err != nil {
 					results = append(results, fmt.Sprintf(`<div class="alert alert-error">Erro ao atualizar projeto "%s": %s</div>`, template.HTMLEscapeString(name), template.HTMLEscapeString(err.Error())))
 					continue
@@ -310,7 +301,7 @@ func (h *Handler) ImportYAML(sc *core.HTTPServerContext) error {
 			}
 
 			// Create new project
-			p := projectModel.Project{Name: name, Description: desc}
+			p := projectModel.Project{Name: name, Description: desc, RetentionDays: retentionDays}
 			if err := serverModel.ServerRepos.Project.Add(ctx, &p); err != nil {
 				results = append(results, fmt.Sprintf(`<div class="alert alert-error">Erro ao criar projeto "%s": %s</div>`, template.HTMLEscapeString(name), template.HTMLEscapeString(err.Error())))
 				continue
@@ -416,12 +407,17 @@ func (h *Handler) ImportYAML(sc *core.HTTPServerContext) error {
 				results = append(results, fmt.Sprintf(`<div class="alert alert-error">Tipo de métrica "%s" não encontrado</div>`, template.HTMLEscapeString(mtName)))
 				continue
 			}
+			mc, ok := collector.Get(mt.Name)
+			if !ok {
+				results = append(results, fmt.Sprintf(`<div class="alert alert-error">Tipo de métrica "%s" não suportado</div>`, template.HTMLEscapeString(mt.Name)))
+				continue
+			}
 
 			// Normalize configuration
 			var cfgMap map[string]interface{}
 			if rawCfg, ok := d.Metadata["configuration"]; ok && rawCfg != nil {
 				if m, ok2 := rawCfg.(map[string]interface{}); ok2 {
-					cfgMap = normalizeConfig(mt.Name, m)
+					cfgMap = normalizeConfig(mc, m)
 				}
 			}
 			if cfgMap == nil {
@@ -440,11 +436,48 @@ func (h *Handler) ImportYAML(sc *core.HTTPServerContext) error {
 				results = append(results, fmt.Sprintf(`<div class="alert alert-error">Configuração inválida para "%s": %s</div>`, template.HTMLEscapeString(mt.Name), template.HTMLEscapeString(err.Error())))
 				continue
 			}
-			if err := application_metric.ValidateConfigByType(mt.Name, cfg); err != nil {
+			if err := mc.Validate(cfg); err != nil {
 				results = append(results, fmt.Sprintf(`<div class="alert alert-error">Configuração inválida: %s</div>`, template.HTMLEscapeString(err.Error())))
 				continue
 			}
 
+			// Optional collection schedule
+			var schedule *applicationMetricModel.Schedule
+			if rawSchedule, ok := d.Metadata["schedule"]; ok && rawSchedule != nil {
+				m, ok2 := rawSchedule.(map[string]interface{})
+				if !ok2 {
+					results = append(results, `<div class="alert alert-error">ApplicationMetric: campo "schedule" deve ser um objeto</div>`)
+					continue
+				}
+				schedule, err = normalizeSchedule(m)
+				if err == nil {
+					err = schedule.Validate()
+				}
+				if err != nil {
+					results = append(results, fmt.Sprintf(`<div class="alert alert-error">Agendamento inválido para "%s": %s</div>`, template.HTMLEscapeString(mt.Name), template.HTMLEscapeString(err.Error())))
+					continue
+				}
+			}
+
+			// Optional retention override
+			retentionDays, err := getRetention(d.Metadata)
+			if err != nil {
+				results = append(results, fmt.Sprintf(`<div class="alert alert-error">Retenção inválida para "%s": %s</div>`, template.HTMLEscapeString(mt.Name), template.HTMLEscapeString(err.Error())))
+				continue
+			}
+
+			// Optional alert thresholds
+			failAfter, err := getThreshold(d.Metadata, "fail_after", "failAfter")
+			if err != nil {
+				results = append(results, fmt.Sprintf(`<div class="alert alert-error">Limiar inválido para "%s": %s</div>`, template.HTMLEscapeString(mt.Name), template.HTMLEscapeString(err.Error())))
+				continue
+			}
+			recoverAfter, err := getThreshold(d.Metadata, "recover_after", "recoverAfter")
+			if err != nil {
+				results = append(results, fmt.Sprintf(`<div class="alert alert-error">Limiar inválido para "%s": %s</div>`, template.HTMLEscapeString(mt.Name), template.HTMLEscapeString(err.Error())))
+				continue
+			}
+
 			// Check if metric type already exists for application
 			existingMetrics, err := serverModel.ServerRepos.ApplicationMetric.ListByApplication(ctx, app.ID)
 			updatedMetric := false
@@ -458,6 +491,10 @@ func (h *Handler) ImportYAML(sc *core.HTTPServerContext) error {
 							if err := json.Unmarshal(security.RedactSensitiveFieldsRaw(em.Configuration), &existingCfg); err == nil {
 								if existingCfg.PvcName != "" && cfg.PvcName != "" && strings.EqualFold(existingCfg.PvcName, cfg.PvcName) {
 									em.Configuration = json.RawMessage(cfgJSON)
+									em.Schedule = schedule
+									em.RetentionDays = retentionDays
+									em.FailAfter = failAfter
+									em.RecoverAfter = recoverAfter
 									if err := serverModel.ServerRepos.ApplicationMetric.Update(ctx, &em); err != nil {
 										results = append(results, fmt.Sprintf(`<div class="alert alert-error">Erro ao atualizar métrica "%s": %s</div>`, template.HTMLEscapeString(mt.Name), template.HTMLEscapeString(err.Error())))
 										updatedMetric = true
@@ -474,6 +511,10 @@ func (h *Handler) ImportYAML(sc *core.HTTPServerContext) error {
 
 						// Non-PvcUsage: single metric per type; update existing and skip creating new
 						em.Configuration = json.RawMessage(cfgJSON)
+						em.Schedule = schedule
+						em.RetentionDays = retentionDays
+						em.FailAfter = failAfter
+						em.RecoverAfter = recoverAfter
 						if err := serverModel.ServerRepos.ApplicationMetric.Update(ctx, &em); err != nil {
 							results = append(results, fmt.Sprintf(`<div class="alert alert-error">Erro ao atualizar métrica "%s": %s</div>`, template.HTMLEscapeString(mt.Name), template.HTMLEscapeString(err.Error())))
 							updatedMetric = true
@@ -495,6 +536,10 @@ func (h *Handler) ImportYAML(sc *core.HTTPServerContext) error {
 				ApplicationID: app.ID,
 				TypeID:        mt.ID,
 				Configuration: json.RawMessage(cfgJSON),
+				Schedule:      schedule,
+				RetentionDays: retentionDays,
+				FailAfter:     failAfter,
+				RecoverAfter:  recoverAfter,
 			}
 			if err := serverModel.ServerRepos.ApplicationMetric.Add(ctx, &am); err != nil {
 				results = append(results, fmt.Sprintf(`<div class="alert alert-error">Erro ao criar métrica "%s": %s</div>`, template.HTMLEscapeString(mt.Name), template.HTMLEscapeString(err.Error())))
@@ -799,359 +844,20 @@ func (h *Handler) GetMetricConfigurationFields(sc *core.HTTPServerContext) error
 	return nil
 }
 
-// generateConfigurationFields generates HTML form fields based on metric type
+// generateConfigurationFields generates HTML form fields from the schema declared by the metric type collector
 func (h *Handler) generateConfigurationFields(metricTypeName string) string {
-	var fieldsHTML string
-
-	switch metricTypeName {
-	case "HealthCheck":
-		fieldsHTML = `
-			<div class="form-group">
-				<label for="health_check_url">URL do Health Check:</label>
-				<input type="url" id="health_check_url" name="health_check_url" required 
-					   placeholder="http://app.namespace.svc.cluster.local:8080/health">
-			</div>
-			<div class="form-group">
-				<label for="method">Método HTTP:</label>
-				<select id="method" name="method" required>
-					<option value="GET">GET</option>
-					<option value="POST">POST</option>
-					<option value="HEAD">HEAD</option>
-				</select>
-			</div>
-			<div class="form-group">
-				<label for="expected_status">Status HTTP Esperado:</label>
-				<input type="number" id="expected_status" name="expected_status" value="200" required min="100" max="599">
-			</div>
-			<div class="form-group">
-				<label for="timeout_seconds">Timeout (segundos):</label>
-				<input type="number" id="timeout_seconds" name="timeout_seconds" value="10" required min="1" max="300">
-			</div>`
-
-	case "PodStatus":
-		fieldsHTML = `
-			<div class="form-group">
-				<label for="pod_label_selector">Seletor de Labels do Pod:</label>
-				<input type="text" id="pod_label_selector" name="pod_label_selector" required 
-					   placeholder="app=minha-aplicacao">
-			</div>
-			<div class="form-group">
-				<label for="container_name">Nome do Container:</label>
-				<input type="text" id="container_name" name="container_name" required 
-					   placeholder="web">
-			</div>`
-
-	case "PodMemoryUsage", "PodCpuUsage":
-		fieldsHTML = `
-			<div class="form-group">
-				<label for="pod_label_selector">Seletor de Labels do Pod:</label>
-				<input type="text" id="pod_label_selector" name="pod_label_selector" required 
-					   placeholder="app=minha-aplicacao">
-			</div>
-			<div class="form-group">
-				<label for="container_name">Nome do Container:</label>
-				<input type="text" id="container_name" name="container_name" required 
-					   placeholder="web">
-			</div>`
-
-	case "PvcUsage":
-		fieldsHTML = `
-			<div class="form-group">
-				<label for="pvc_name">Nome do PVC:</label>
-				<input type="text" id="pvc_name" name="pvc_name" required 
-					   placeholder="minha-aplicacao-data">
-			</div>
-			<div class="form-group">
-				<label for="pod_label_selector">Seletor de Labels do Pod:</label>
-				<input type="text" id="pod_label_selector" name="pod_label_selector" required 
-					   placeholder="app=minha-aplicacao">
-			</div>
-			<div class="form-group">
-				<label for="container_name">Nome do Container:</label>
-				<input type="text" id="container_name" name="container_name" required 
-					   placeholder="web">
-			</div>
-			<div class="form-group">
-				<label for="pvc_mount_path">Caminho de Montagem do PVC:</label>
-				<input type="text" id="pvc_mount_path" name="pvc_mount_path" required 
-					   placeholder="/data">
-			</div>`
-
-	case "PodActiveNodes":
-		fieldsHTML = `
-			<div class="form-group">
-				<label for="pod_label_selector">Seletor de Labels do Pod:</label>
-				<input type="text" id="pod_label_selector" name="pod_label_selector" required 
-					   placeholder="app=minha-aplicacao">
-			</div>`
-
-	case "RedisConnection":
-		fieldsHTML = `
-			<div class="form-group">
-				<label for="connection_host">Host do Redis:</label>
-				<input type="text" id="connection_host" name="connection_host" required 
-					   placeholder="redis.default.svc.cluster.local">
-			</div>
-			<div class="form-group">
-				<label for="connection_port">Porta:</label>
-				<input type="number" id="connection_port" name="connection_port" value="6379" required min="1" max="65535">
-			</div>
-			<div class="form-group">
-				<label for="connection_password">Senha (opcional):</label>
-				<input type="password" id="connection_password" name="connection_password" 
-					   placeholder="senha-do-redis">
-			</div>
-			<div class="form-group">
-				<label for="connection_db">Database:</label>
-				<input type="number" id="connection_db" name="connection_db" value="0" required min="0" max="15">
-			</div>
-			<div class="form-group">
-				<label for="connection_ssl">SSL:</label>
-				<select id="connection_ssl" name="connection_ssl" required>
-					<option value="false">Não</option>
-					<option value="true">Sim</option>
-				</select>
-			</div>
-			<div class="form-group">
-				<label for="connection_timeout">Timeout (segundos):</label>
-				<input type="number" id="connection_timeout" name="connection_timeout" value="5" required min="1" max="300">
-			</div>`
-
-	case "PostgreSQLConnection":
-		fieldsHTML = `
-			<div class="form-group">
-				<label for="connection_host">Host do PostgreSQL:</label>
-				<input type="text" id="connection_host" name="connection_host" required 
-					   placeholder="postgres.default.svc.cluster.local">
-			</div>
-			<div class="form-group">
-				<label for="connection_port">Porta:</label>
-				<input type="number" id="connection_port" name="connection_port" value="5432" required min="1" max="65535">
-			</div>
-			<div class="form-group">
-				<label for="connection_username">Usuário:</label>
-				<input type="text" id="connection_username" name="connection_username" required 
-					   placeholder="usuario">
-			</div>
-			<div class="form-group">
-				<label for="connection_password">Senha:</label>
-				<input type="password" id="connection_password" name="connection_password" required 
-					   placeholder="senha">
-			</div>
-			<div class="form-group">
-				<label for="connection_database">Database:</label>
-				<input type="text" id="connection_database" name="connection_database" required 
-					   placeholder="minha_base">
-			</div>
-			<div class="form-group">
-				<label for="connection_ssl">SSL:</label>
-				<select id="connection_ssl" name="connection_ssl" required>
-					<option value="false">Não</option>
-					<option value="true">Sim</option>
-				</select>
-			</div>
-			<div class="form-group">
-				<label for="connection_timeout">Timeout (segundos):</label>
-				<input type="number" id="connection_timeout" name="connection_timeout" value="10" required min="1" max="300">
-			</div>`
-
-	case "MongoDBConnection":
-		fieldsHTML = `
-			<div class="form-group">
-				<label for="connection_host">Host do MongoDB:</label>
-				<input type="text" id="connection_host" name="connection_host" required 
-					   placeholder="mongodb.default.svc.cluster.local">
-			</div>
-			<div class="form-group">
-				<label for="connection_port">Porta:</label>
-				<input type="number" id="connection_port" name="connection_port" value="27017" required min="1" max="65535">
-			</div>
-			<div class="form-group">
-				<label for="connection_username">Usuário:</label>
-				<input type="text" id="connection_username" name="connection_username" required 
-					   placeholder="admin">
-			</div>
-			<div class="form-group">
-				<label for="connection_password">Senha:</label>
-				<input type="password" id="connection_password" name="connection_password" required 
-					   placeholder="senha">
-			</div>
-			<div class="form-group">
-				<label for="connection_database">Database:</label>
-				<input type="text" id="connection_database" name="connection_database" required 
-					   placeholder="minha_base">
-			</div>
-			<div class="form-group">
-				<label for="connection_auth_source">Auth Source:</label>
-				<input type="text" id="connection_auth_source" name="connection_auth_source" value="admin" required 
-					   placeholder="admin">
-			</div>
-			<div class="form-group">
-				<label for="connection_ssl">SSL:</label>
-				<select id="connection_ssl" name="connection_ssl" required>
-					<option value="false">Não</option>
-					<option value="true">Sim</option>
-				</select>
-			</div>
-			<div class="form-group">
-				<label for="connection_timeout">Timeout (segundos):</label>
-				<input type="number" id="connection_timeout" name="connection_timeout" value="5" required min="1" max="300">
-			</div>`
-
-	case "MySQLConnection":
-		fieldsHTML = `
-			<div class="form-group">
-				<label for="connection_host">Host do MySQL:</label>
-				<input type="text" id="connection_host" name="connection_host" required 
-					   placeholder="mysql.default.svc.cluster.local">
-			</div>
-			<div class="form-group">
-				<label for="connection_port">Porta:</label>
-				<input type="number" id="connection_port" name="connection_port" value="3306" required min="1" max="65535">
-			</div>
-			<div class="form-group">
-				<label for="connection_username">Usuário:</label>
-				<input type="text" id="connection_username" name="connection_username" required 
-					   placeholder="root">
-			</div>
-			<div class="form-group">
-				<label for="connection_password">Senha:</label>
-				<input type="password" id="connection_password" name="connection_password" required 
-					   placeholder="senha">
-			</div>
-			<div class="form-group">
-				<label for="connection_database">Database:</label>
-				<input type="text" id="connection_database" name="connection_database" required 
-					   placeholder="minha_base">
-			</div>
-			<div class="form-group">
-				<label for="connection_ssl">SSL:</label>
-				<select id="connection_ssl" name="connection_ssl" required>
-					<option value="false">Não</option>
-					<option value="true">Sim</option>
-				</select>
-			</div>
-			<div class="form-group">
-				<label for="connection_timeout">Timeout (segundos):</label>
-				<input type="number" id="connection_timeout" name="connection_timeout" value="5" required min="1" max="300">
-			</div>`
-
-	case "KongConnection":
-		fieldsHTML = `
-			<div class="form-group">
-				<label for="connection_host">Host do Kong Admin:</label>
-				<input type="text" id="connection_host" name="connection_host" required 
-					   placeholder="kong-admin.default.svc.cluster.local">
-			</div>
-			<div class="form-group">
-				<label for="connection_port">Porta:</label>
-				<input type="number" id="connection_port" name="connection_port" value="8001" required min="1" max="65535">
-			</div>
-			<div class="form-group">
-				<label for="kong_admin_url">URL Admin do Kong:</label>
-				<input type="url" id="kong_admin_url" name="kong_admin_url" required 
-					   placeholder="http://kong-admin.default.svc.cluster.local:8001">
-			</div>
-			<div class="form-group">
-				<label for="connection_username">Usuário (opcional):</label>
-				<input type="text" id="connection_username" name="connection_username" 
-					   placeholder="admin">
-			</div>
-			<div class="form-group">
-				<label for="connection_password">Senha (opcional):</label>
-				<input type="password" id="connection_password" name="connection_password" 
-					   placeholder="senha">
-			</div>
-			<div class="form-group">
-				<label for="connection_ssl">SSL:</label>
-				<select id="connection_ssl" name="connection_ssl" required>
-					<option value="false">Não</option>
-					<option value="true">Sim</option>
-				</select>
-			</div>
-			<div class="form-group">
-				<label for="connection_timeout">Timeout (segundos):</label>
-				<input type="number" id="connection_timeout" name="connection_timeout" value="5" required min="1" max="300">
-			</div>`
-
-	case "IngressCertificate":
-		fieldsHTML = `
-			<div class="form-group">
-				<label for="ingress_name">Nome do Ingress:</label>
-				<input type="text" id="ingress_name" name="ingress_name" required 
-					   placeholder="minha-aplicacao-ingress">
-			</div>
-			<div class="form-group">
-				<label for="ingress_namespace">Namespace (opcional):</label>
-				<input type="text" id="ingress_namespace" name="ingress_namespace" 
-					   placeholder="default">
-			</div>
-			<div class="form-group">
-				<label for="tls_secret_name">Nome do Secret TLS (opcional):</label>
-				<input type="text" id="tls_secret_name" name="tls_secret_name" 
-					   placeholder="minha-aplicacao-tls">
-			</div>
-			<div class="form-group">
-				<label for="warning_days">Dias de Aviso:</label>
-				<input type="number" id="warning_days" name="warning_days" value="30" required min="1" max="365">
-			</div>`
-
-	case "KafkaConsumerLag":
-		fieldsHTML = `
-			<div class="form-group">
-				<label for="kafka_bootstrap_servers">Servidores Bootstrap do Kafka:</label>
-				<input type="text" id="kafka_bootstrap_servers" name="kafka_bootstrap_servers" required 
-					   placeholder="kafka:9092">
-			</div>
-			<div class="form-group">
-				<label for="kafka_consumer_group">Grupo de Consumidores (opcional):</label>
-				<input type="text" id="kafka_consumer_group" name="kafka_consumer_group"
-					   placeholder="meu-grupo-consumidor">
-			</div>
-			<div class="form-group">
-				<label for="kafka_topic">Tópico (opcional):</label>
-				<input type="text" id="kafka_topic" name="kafka_topic" 
-					   placeholder="meu-topico">
-			</div>
-			<div class="form-group">
-				<label for="kafka_lag_threshold">Limite de Lag:</label>
-				<input type="number" id="kafka_lag_threshold" name="kafka_lag_threshold" value="1000" required min="0">
-			</div>
-			<div class="form-group">
-				<label for="kafka_security_protocol">Protocolo de Segurança (opcional):</label>
-				<select id="kafka_security_protocol" name="kafka_security_protocol">
-					<option value="">Nenhum</option>
-					<option value="PLAINTEXT">PLAINTEXT</option>
-					<option value="SASL_PLAINTEXT">SASL_PLAINTEXT</option>
-					<option value="SASL_SSL">SASL_SSL</option>
-					<option value="SSL">SSL</option>
-				</select>
-			</div>
-			<div class="form-group">
-				<label for="kafka_sasl_mechanism">Mecanismo SASL (opcional):</label>
-				<select id="kafka_sasl_mechanism" name="kafka_sasl_mechanism">
-					<option value="">Nenhum</option>
-					<option value="PLAIN">PLAIN</option>
-					<option value="SCRAM-SHA-256">SCRAM-SHA-256</option>
-					<option value="SCRAM-SHA-512">SCRAM-SHA-512</option>
-				</select>
-			</div>
-			<div class="form-group">
-				<label for="kafka_sasl_username">Usuário SASL (opcional):</label>
-				<input type="text" id="kafka_sasl_username" name="kafka_sasl_username" 
-					   placeholder="usuario-kafka">
-			</div>
-			<div class="form-group">
-				<label for="kafka_sasl_password">Senha SASL (opcional):</label>
-				<input type="password" id="kafka_sasl_password" name="kafka_sasl_password" 
-					   placeholder="senha-kafka">
-			</div>`
+	c, ok := collector.Get(metricTypeName)
+	if !ok {
+		return `<p>Configuração não disponível para este tipo de métrica.</p>`
+	}
 
-	default:
-		fieldsHTML = `<p>Configuração não disponível para este tipo de métrica.</p>`
+	var b strings.Builder
+	if err := h.templates.ExecuteTemplate(&b, "metric-config-fields", c.ConfigFields()); err != nil {
+		log.Error().Err(err).Str("metric_type", metricTypeName).Msg("error executing metric-config-fields template")
+		return `<p class="text-error">Erro ao carregar campos de configuração.</p>`
 	}
 
-	return fieldsHTML
+	return b.String()
 }
 
 func (h *Handler) GetProjectsList(sc *core.HTTPServerContext) error {
@@ -1166,8 +872,17 @@ func (h *Handler) GetProjectsList(sc *core.HTTPServerContext) error {
 	sc.Response().Header().Set("Content-Type", "text/html")
 	sc.Response().WriteHeader(http.StatusOK)
 
+	type ProjectDisplay struct {
+		projectModel.Project
+		Retention string
+	}
+
 	for _, project := range projects {
-		if err := h.templates.ExecuteTemplate(sc.Response().Writer, "project-list-item", project); err != nil {
+		display := ProjectDisplay{
+			Project:   project,
+			Retention: describeRetention(project.RetentionDays, fmt.Sprintf("global (%d dias)", globalRetentionDays())),
+		}
+		if err := h.templates.ExecuteTemplate(sc.Response().Writer, "project-list-item", display); err != nil {
 			log.Error().Msg("error executing template")
 			return err
 		}
@@ -1322,6 +1037,8 @@ func (h *Handler) GetMetricsList(sc *core.HTTPServerContext) error {
 		ProjectName     string
 		MetricTypeName  string
 		Configuration   string
+		Schedule        string
+		Retention       string
 	}
 
 	for _, metric := range filteredMetrics {
@@ -1330,6 +1047,8 @@ func (h *Handler) GetMetricsList(sc *core.HTTPServerContext) error {
 			TypeID:        metric.TypeID,
 			ApplicationID: metric.ApplicationID,
 			Configuration: string(security.RedactSensitiveFieldsRaw(metric.Configuration)),
+			Schedule:      describeSchedule(metric.Schedule),
+			Retention:     describeRetention(metric.RetentionDays, fmt.Sprintf("global (%d dias)", globalRetentionDays())),
 		}
 
 		// Get metric type details
@@ -1357,6 +1076,9 @@ func (h *Handler) GetMetricsList(sc *core.HTTPServerContext) error {
 				display.ProjectName = "N/A"
 			} else {
 				display.ProjectName = project.Name
+				if project.RetentionDays != nil && *project.RetentionDays > 0 {
+					display.Retention = describeRetention(metric.RetentionDays, fmt.Sprintf("do projeto (%d dias)", *project.RetentionDays))
+				}
 			}
 		}
 
@@ -1482,6 +1204,18 @@ type MetricWithValue struct {
 	MetricTypeID  string
 	Configuration map[string]interface{}
 	LatestValue   *MetricValueParsed
+	Flapping      bool
+	FlapScore     float64
+}
+
+// loadFlapState marks a metric whose health keeps changing
+func (mv *MetricWithValue) loadFlapState(ctx context.Context) {
+	state, err := serverModel.ServerRepos.AlertState.Get(ctx, mv.MetricID)
+	if err != nil {
+		return
+	}
+	mv.Flapping = state.Flapping
+	mv.FlapScore = state.FlapScore
 }
 
 type MetricValueParsed struct {
@@ -1555,6 +1289,8 @@ func (h *Handler) GetApplicationMetrics(sc *core.HTTPServerContext) error {
 			}
 		}
 
+		metricWithValue.loadFlapState(ctx)
+
 		// Append to multi map
 		multiMetricsByType[metricType.Name] = append(multiMetricsByType[metricType.Name], metricWithValue)
 
@@ -1708,6 +1444,8 @@ func (h *Handler) GetDashboardResults(sc *core.HTTPServerContext) error {
 				}
 			}
 
+			mv.loadFlapState(ctx)
+
 			// Append to multi map
 			multiMetricsByType[metricType.Name] = append(multiMetricsByType[metricType.Name], mv)
 
@@ -1765,3 +1503,68 @@ func getMetricValueByType(ctx context.Context, applicationID, metricTypeName str
 
 	return nil
 }
+
+// normalizeSchedule builds a schedule from YAML metadata, accepting both
+// snake_case and camelCase keys
+func normalizeSchedule(m map[string]interface{}) (*applicationMetricModel.Schedule, error) {
+	aliases := map[string][]string{
+		"interval_seconds": {"interval_seconds", "intervalSeconds", "interval"},
+		"cron_expression":  {"cron_expression", "cronExpression", "cron"},
+		"jitter_seconds":   {"jitter_seconds", "jitterSeconds", "jitter"},
+	}
+
+	out := map[string]interface{}{}
+	for name, keys := range aliases {
+		for _, key := range keys {
+			if v, ok := m[key]; ok {
+				out[name] = v
+				break
+			}
+		}
+	}
+
+	raw, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+	var schedule applicationMetricModel.Schedule
+	if err := json.Unmarshal(raw, &schedule); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// describeSchedule renders a metric schedule for display
+func describeSchedule(schedule *applicationMetricModel.Schedule) string {
+	if schedule == nil {
+		return "intervalo global"
+	}
+
+	var desc string
+	switch {
+	case schedule.CronExpression != "":
+		desc = fmt.Sprintf("cron \"%s\"", schedule.CronExpression)
+	case schedule.IntervalSeconds > 0:
+		desc = fmt.Sprintf("a cada %ds", schedule.IntervalSeconds)
+	default:
+		desc = "intervalo global"
+	}
+	if schedule.JitterSeconds > 0 {
+		desc = fmt.Sprintf("%s (jitter até %ds)", desc, schedule.JitterSeconds)
+	}
+	return desc
+}
+
+// describeRetention renders a retention override for display, or the
+// fallback when there is none
+func describeRetention(days *int, fallback string) string {
+	if days == nil || *days <= 0 {
+		return fallback
+	}
+	return fmt.Sprintf("%d dias", *days)
+}
+
+// globalRetentionDays returns the METRICS_RETENTION_DAYS in effect
+func globalRetentionDays() int {
+	return int(applicationMetricValueModel.Retention(applicationMetricValueModel.ResolutionRaw).Hours() / 24)
+}
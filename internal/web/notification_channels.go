@@ -0,0 +1,252 @@
+package web
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"k8s-monitoring-app/internal/alerts"
+	"k8s-monitoring-app/internal/collector"
+	"k8s-monitoring-app/internal/core"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	notificationChannelModel "k8s-monitoring-app/pkg/notification_channel/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+// channelKindLabels names the notification channel kinds in the UI
+var channelKindLabels = map[string]string{
+	alerts.KindSlack:      "Slack",
+	alerts.KindWebhook:    "Webhook (JSON)",
+	alerts.KindTeams:      "Microsoft Teams",
+	alerts.KindGoogleChat: "Google Chat",
+	alerts.KindDiscord:    "Discord",
+	alerts.KindTelegram:   "Telegram",
+	alerts.KindEmail:      "E-mail (SMTP)",
+	alerts.KindPagerDuty:  "PagerDuty",
+	alerts.KindOpsgenie:   "Opsgenie",
+}
+
+// RenderCadastroChannels renders the notification channel registration page
+func (h *Handler) RenderCadastroChannels(sc *core.HTTPServerContext) error {
+	// Get user info from context (set by auth middleware)
+	userEmail := sc.Get("user_email")
+	userName := sc.Get("user_name")
+	userPicture := sc.Get("user_picture")
+
+	kinds := make([]collector.FieldOption, 0, len(alerts.Kinds))
+	for _, kind := range alerts.Kinds {
+		kinds = append(kinds, collector.FieldOption{Value: kind, Label: channelKindLabel(kind)})
+	}
+
+	data := map[string]interface{}{
+		"Title":       "Canais de Notificação",
+		"UserEmail":   userEmail,
+		"UserName":    userName,
+		"UserPicture": userPicture,
+		"Kinds":       kinds,
+	}
+
+	sc.Response().Header().Set("Content-Type", "text/html")
+	sc.Response().WriteHeader(http.StatusOK)
+
+	if err := h.templates.ExecuteTemplate(sc.Response().Writer, "cadastro-canais.html", data); err != nil {
+		log.Error().Err(err).Msg("error executing cadastro-canais template")
+		return err
+	}
+
+	return nil
+}
+
+// GetChannelSettingsFields returns the settings fields of a notification channel kind
+func (h *Handler) GetChannelSettingsFields(sc *core.HTTPServerContext) error {
+	fields := channelSettingsFields(sc.QueryParam("kind"))
+
+	sc.Response().Header().Set("Content-Type", "text/html")
+	sc.Response().WriteHeader(http.StatusOK)
+
+	if fields == nil {
+		_, err := sc.Response().Writer.Write([]byte(`<p>Selecione o tipo do canal.</p>`))
+		return err
+	}
+	if err := h.templates.ExecuteTemplate(sc.Response().Writer, "metric-config-fields", fields); err != nil {
+		log.Error().Err(err).Msg("error executing metric-config-fields template")
+		return err
+	}
+
+	return nil
+}
+
+// channelSettingsFields describes the settings form of a notification channel kind
+func channelSettingsFields(kind string) []collector.ConfigField {
+	switch kind {
+	case alerts.KindSlack, alerts.KindTeams, alerts.KindGoogleChat, alerts.KindDiscord:
+		return []collector.ConfigField{
+			{Name: "url", Label: "URL do Webhook:", Type: collector.FieldURL, Required: true, Placeholder: "https://..."},
+		}
+	case alerts.KindWebhook:
+		return []collector.ConfigField{
+			{Name: "url", Label: "URL do Webhook:", Type: collector.FieldURL, Required: true, Placeholder: "https://example.com/alerts"},
+			{Name: "secret", Label: "Segredo HMAC (opcional):", Type: collector.FieldPassword},
+		}
+	case alerts.KindTelegram:
+		return []collector.ConfigField{
+			{Name: "bot_token", Label: "Token do Bot:", Type: collector.FieldPassword, Required: true},
+			{Name: "chat_id", Label: "ID do Chat:", Type: collector.FieldText, Required: true, Placeholder: "-1001234567890"},
+		}
+	case alerts.KindEmail:
+		return []collector.ConfigField{
+			{Name: "host", Label: "Servidor SMTP:", Type: collector.FieldText, Required: true, Placeholder: "smtp.example.com"},
+			{Name: "port", Label: "Porta:", Type: collector.FieldNumber, Default: "587", Min: "1", Max: "65535"},
+			{Name: "username", Label: "Usuário (opcional):", Type: collector.FieldText},
+			{Name: "password", Label: "Senha (opcional):", Type: collector.FieldPassword},
+			{Name: "from", Label: "Remetente:", Type: collector.FieldText, Placeholder: "alertas@example.com"},
+			{Name: "to", Label: "Destinatários (separados por vírgula):", Type: collector.FieldText, Required: true, Placeholder: "oncall@example.com, time@example.com"},
+		}
+	case alerts.KindPagerDuty, alerts.KindOpsgenie:
+		placeholder := alerts.PagerDutyEventsURL
+		if kind == alerts.KindOpsgenie {
+			placeholder = alerts.OpsgenieAPIURL
+		}
+		return []collector.ConfigField{
+			{Name: "routing_key", Label: "Chave de roteamento padrão:", Type: collector.FieldPassword},
+			{Name: "routing_key_critical", Label: "Chave para severidade critical (opcional):", Type: collector.FieldPassword},
+			{Name: "routing_key_warning", Label: "Chave para severidade warning (opcional):", Type: collector.FieldPassword},
+			{Name: "routing_key_info", Label: "Chave para severidade info (opcional):", Type: collector.FieldPassword},
+			{Name: "url", Label: "URL da API (opcional):", Type: collector.FieldURL, Placeholder: placeholder},
+		}
+	default:
+		return nil
+	}
+}
+
+// GetChannelsList returns the notification channels for HTMX partial
+func (h *Handler) GetChannelsList(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	channels, err := serverModel.ServerRepos.NotificationChannel.List(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("error listing notification channels")
+		return sc.String(http.StatusInternalServerError, "Error loading notification channels")
+	}
+
+	sc.Response().Header().Set("Content-Type", "text/html")
+	sc.Response().WriteHeader(http.StatusOK)
+
+	if len(channels) == 0 {
+		_, err := sc.Response().Writer.Write([]byte(`<p>Nenhum canal cadastrado. Os alertas usam os canais configurados por variáveis de ambiente.</p>`))
+		return err
+	}
+
+	type ChannelDisplay struct {
+		notificationChannelModel.NotificationChannel
+		KindLabel   string
+		Scope       string
+		Destination string
+		Active      bool
+	}
+
+	projectNames := map[string]string{}
+	for _, channel := range channels {
+		display := ChannelDisplay{
+			NotificationChannel: channel,
+			KindLabel:           channelKindLabel(channel.Kind),
+			Destination:         channelDestination(channel),
+			Active:              channel.IsEnabled(),
+		}
+
+		projectName, ok := projectNames[channel.ProjectID]
+		if !ok {
+			projectName = "N/A"
+			if project, err := serverModel.ServerRepos.Project.Get(ctx, channel.ProjectID); err == nil {
+				projectName = project.Name
+			}
+			projectNames[channel.ProjectID] = projectName
+		}
+		display.Scope = "Projeto " + projectName
+		if channel.ApplicationID != "" {
+			applicationName := "N/A"
+			if application, err := serverModel.ServerRepos.Application.Get(ctx, channel.ApplicationID); err == nil {
+				applicationName = application.Name
+			}
+			display.Scope = "Aplicação " + applicationName + " (" + projectName + "), substitui os canais do projeto"
+		}
+
+		if err := h.templates.ExecuteTemplate(sc.Response().Writer, "channel-list-item", display); err != nil {
+			log.Error().Msg("error executing template")
+			return err
+		}
+	}
+
+	return nil
+}
+
+// channelDestination summarizes where a channel delivers without exposing
+// its credentials
+func channelDestination(channel notificationChannelModel.NotificationChannel) string {
+	settings := alerts.Settings{}
+	if err := json.Unmarshal(channel.Settings, &settings); err != nil {
+		return "-"
+	}
+
+	switch channel.Kind {
+	case alerts.KindTelegram:
+		return "chat " + settings.ChatID
+	case alerts.KindEmail:
+		return settings.To
+	case alerts.KindPagerDuty, alerts.KindOpsgenie:
+		keys := map[string]string{
+			alerts.SeverityCritical: settings.RoutingKeyCritical,
+			alerts.SeverityWarning:  settings.RoutingKeyWarning,
+			alerts.SeverityInfo:     settings.RoutingKeyInfo,
+		}
+		severities := []string{}
+		for i := len(alerts.Severities) - 1; i >= 0; i-- {
+			if keys[alerts.Severities[i]] != "" {
+				severities = append(severities, alerts.Severities[i])
+			}
+		}
+		if settings.RoutingKey != "" {
+			severities = append(severities, "padrão")
+		}
+		return "chaves: " + strings.Join(severities, ", ")
+	default:
+		if u, err := url.Parse(settings.URL); err == nil && u.Host != "" {
+			return u.Host
+		}
+		return "-"
+	}
+}
+
+// DeleteChannel deletes a notification channel and returns success response for HTMX
+func (h *Handler) DeleteChannel(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+	id := sc.Param("id")
+
+	if id == "" {
+		return sc.String(http.StatusBadRequest, "ID is required")
+	}
+
+	err := serverModel.ServerRepos.NotificationChannel.Delete(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sc.String(http.StatusNotFound, "Notification channel not found")
+		}
+		log.Error().Err(err).Str("id", id).Msg("error deleting notification channel")
+		return sc.String(http.StatusInternalServerError, "Error deleting notification channel")
+	}
+
+	log.Info().Str("id", id).Msg("notification channel deleted successfully")
+	return sc.String(http.StatusOK, "Notification channel deleted successfully")
+}
+
+// channelKindLabel names a channel kind, falling back to the kind itself
+func channelKindLabel(kind string) string {
+	if label, ok := channelKindLabels[kind]; ok {
+		return label
+	}
+	return strings.ToUpper(kind)
+}
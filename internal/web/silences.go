@@ -0,0 +1,200 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s-monitoring-app/internal/core"
+	serverModel "k8s-monitoring-app/internal/server/model"
+	silenceModel "k8s-monitoring-app/pkg/silence/model"
+
+	"github.com/rs/zerolog/log"
+)
+
+// weekdayLabels names the maintenance window weekdays in the UI
+var weekdayLabels = map[string]string{
+	"sunday":    "Dom",
+	"monday":    "Seg",
+	"tuesday":   "Ter",
+	"wednesday": "Qua",
+	"thursday":  "Qui",
+	"friday":    "Sex",
+	"saturday":  "Sáb",
+}
+
+// RenderCadastroSilences renders the silence and maintenance window registration page
+func (h *Handler) RenderCadastroSilences(sc *core.HTTPServerContext) error {
+	// Get user info from context (set by auth middleware)
+	userEmail := sc.Get("user_email")
+	userName := sc.Get("user_name")
+	userPicture := sc.Get("user_picture")
+
+	type WeekdayOption struct {
+		Value string
+		Label string
+	}
+	weekdays := make([]WeekdayOption, 0, len(silenceModel.Weekdays))
+	for _, day := range silenceModel.Weekdays {
+		weekdays = append(weekdays, WeekdayOption{Value: day, Label: weekdayLabels[day]})
+	}
+
+	data := map[string]interface{}{
+		"Title":       "Silêncios e Manutenções",
+		"UserEmail":   userEmail,
+		"UserName":    userName,
+		"UserPicture": userPicture,
+		"Weekdays":    weekdays,
+	}
+
+	sc.Response().Header().Set("Content-Type", "text/html")
+	sc.Response().WriteHeader(http.StatusOK)
+
+	if err := h.templates.ExecuteTemplate(sc.Response().Writer, "cadastro-silencios.html", data); err != nil {
+		log.Error().Err(err).Msg("error executing cadastro-silencios template")
+		return err
+	}
+
+	return nil
+}
+
+// GetSilencesList returns the silences for HTMX partial
+func (h *Handler) GetSilencesList(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+
+	silences, err := serverModel.ServerRepos.Silence.List(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("error listing silences")
+		return sc.String(http.StatusInternalServerError, "Error loading silences")
+	}
+
+	sc.Response().Header().Set("Content-Type", "text/html")
+	sc.Response().WriteHeader(http.StatusOK)
+
+	if len(silences) == 0 {
+		_, err := sc.Response().Writer.Write([]byte(`<p>Nenhum silêncio cadastrado.</p>`))
+		return err
+	}
+
+	type SilenceDisplay struct {
+		silenceModel.Silence
+		Scope   string
+		Period  string
+		Window  string
+		Status  string
+		Expired bool
+	}
+
+	now := time.Now()
+	for _, silence := range silences {
+		display := SilenceDisplay{
+			Silence: silence,
+			Scope:   silenceScope(ctx, silence),
+			Period:  silencePeriod(silence),
+			Window:  silenceWindow(silence.Window),
+			Expired: silence.Expired(now),
+		}
+		switch {
+		case display.Expired:
+			display.Status = "Encerrado"
+		case silence.ActiveAt(now):
+			display.Status = "Ativo agora"
+		case now.Before(silence.StartsAt):
+			display.Status = "Agendado"
+		default:
+			display.Status = "Fora da janela"
+		}
+
+		if err := h.templates.ExecuteTemplate(sc.Response().Writer, "silence-list-item", display); err != nil {
+			log.Error().Msg("error executing template")
+			return err
+		}
+	}
+
+	return nil
+}
+
+// silenceScope describes what a silence covers
+func silenceScope(ctx context.Context, silence silenceModel.Silence) string {
+	var parts []string
+	if silence.ProjectID != "" {
+		name := "N/A"
+		if project, err := serverModel.ServerRepos.Project.Get(ctx, silence.ProjectID); err == nil {
+			name = project.Name
+		}
+		parts = append(parts, "Projeto "+name)
+	}
+	if silence.ApplicationID != "" {
+		name := "N/A"
+		if application, err := serverModel.ServerRepos.Application.Get(ctx, silence.ApplicationID); err == nil {
+			name = application.Name
+		}
+		parts = append(parts, "Aplicação "+name)
+	}
+	if silence.ApplicationMetricID != "" {
+		parts = append(parts, "Métrica "+silence.ApplicationMetricID)
+	}
+	if silence.MetricTypeID != "" {
+		name := "N/A"
+		if metricType, err := serverModel.ServerRepos.MetricType.Get(ctx, silence.MetricTypeID); err == nil {
+			name = metricType.Name
+		}
+		parts = append(parts, "Tipo "+name)
+	}
+	return strings.Join(parts, " | ")
+}
+
+// silencePeriod renders when a silence starts and ends
+func silencePeriod(silence silenceModel.Silence) string {
+	const layout = "02/01/2006 15:04"
+	if silence.EndsAt == nil {
+		return fmt.Sprintf("A partir de %s (UTC)", silence.StartsAt.UTC().Format(layout))
+	}
+	return fmt.Sprintf("%s até %s (UTC)", silence.StartsAt.UTC().Format(layout), silence.EndsAt.UTC().Format(layout))
+}
+
+// silenceWindow renders a maintenance window, e.g. "Dom 02:00-04:00 (America/Sao_Paulo)"
+func silenceWindow(window *silenceModel.Window) string {
+	if window == nil {
+		return ""
+	}
+	days := "Todos os dias"
+	if len(window.Weekdays) > 0 {
+		labels := make([]string, 0, len(window.Weekdays))
+		for _, day := range window.Weekdays {
+			labels = append(labels, weekdayLabels[day])
+		}
+		days = strings.Join(labels, ", ")
+	}
+	timezone := window.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	return fmt.Sprintf("%s %s-%s (%s)", days, window.Start, window.End, timezone)
+}
+
+// DeleteSilence deletes a silence and returns success response for HTMX
+func (h *Handler) DeleteSilence(sc *core.HTTPServerContext) error {
+	ctx := sc.Request().Context()
+	id := sc.Param("id")
+
+	if id == "" {
+		return sc.String(http.StatusBadRequest, "ID is required")
+	}
+
+	err := serverModel.ServerRepos.Silence.Delete(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sc.String(http.StatusNotFound, "Silence not found")
+		}
+		log.Error().Err(err).Str("id", id).Msg("error deleting silence")
+		return sc.String(http.StatusInternalServerError, "Error deleting silence")
+	}
+
+	log.Info().Str("id", id).Msg("silence deleted successfully")
+	return sc.String(http.StatusOK, "Silence deleted successfully")
+}
@@ -0,0 +1,90 @@
+package alert_history
+
+import (
+	"time"
+
+	"k8s-monitoring-app/internal/core"
+)
+
+// Alert statuses in the history
+const (
+	StatusFiring   = "firing"
+	StatusResolved = "resolved"
+)
+
+// Alert is one problem of an application metric, from the moment its alert
+// fired until it resolved, with every delivery attempt made for it
+type Alert struct {
+	ID                  string     `json:"id"`
+	ApplicationMetricID string     `json:"application_metric_id"`
+	ApplicationID       string     `json:"application_id"`
+	ProjectID           string     `json:"project_id"`
+	MetricType          string     `json:"metric_type"` // Metric type name
+	Status              string     `json:"status"`      // firing or resolved
+	Severity            string     `json:"severity"`
+	Title               string     `json:"title"`
+	Reason              string     `json:"reason"`
+	StartedAt           time.Time  `json:"started_at"` // First failure of the problem
+	FiredAt             time.Time  `json:"fired_at"`
+	ResolvedAt          *time.Time `json:"resolved_at,omitempty"`
+	SilenceID           string     `json:"silence_id,omitempty"` // Last silence that held back the alert
+	AcknowledgedAt      *time.Time `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy      string     `json:"acknowledged_by,omitempty"` // e.g. an email or slack:@user
+	EscalationStep      int        `json:"escalation_step"`           // Last escalation step reached, 0 before the first one
+	EscalatedAt         *time.Time `json:"escalated_at,omitempty"`
+	Deliveries          []Delivery `json:"deliveries"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+	DurationSeconds     int64      `json:"duration_seconds"` // How long the problem lasted, or has lasted so far
+}
+
+// Acknowledged reports whether someone is on the alert. Acknowledged alerts
+// aren't sent again until they resolve.
+func (a Alert) Acknowledged() bool {
+	return a.AcknowledgedAt != nil
+}
+
+// Duration returns how long the problem lasted, up to now while still firing
+func (a Alert) Duration(now time.Time) time.Duration {
+	end := now
+	if a.ResolvedAt != nil {
+		end = *a.ResolvedAt
+	}
+	if end.Before(a.StartedAt) {
+		return 0
+	}
+	return end.Sub(a.StartedAt).Round(time.Second)
+}
+
+// Delivery is one attempt to send the firing or resolved notification of an
+// alert through a channel
+type Delivery struct {
+	ID             string    `json:"id"`
+	AlertID        string    `json:"alert_id"`
+	Status         string    `json:"status"`  // Notification sent: firing or resolved
+	Channel        string    `json:"channel"` // Channel kind, e.g. slack
+	Success        bool      `json:"success"`
+	Error          string    `json:"error,omitempty"`
+	EscalationStep int       `json:"escalation_step,omitempty"` // Escalation step that sent it, 0 for the regular notifications
+	SentAt         time.Time `json:"sent_at"`
+}
+
+// Filter narrows the alert history. Empty fields match every alert; From and
+// To bound when the alerts fired.
+type Filter struct {
+	ProjectID           string
+	ApplicationID       string
+	ApplicationMetricID string
+	Status              string
+	Severity            string
+	From                *time.Time
+	To                  *time.Time
+	Limit               int
+}
+
+type Service interface {
+	Get(sc *core.HTTPServerContext) error
+	List(sc *core.HTTPServerContext) error
+	Acknowledge(sc *core.HTTPServerContext) error
+	SlackInteraction(sc *core.HTTPServerContext) error
+}
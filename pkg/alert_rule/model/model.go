@@ -0,0 +1,133 @@
+package alert_rule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s-monitoring-app/internal/alerts"
+	"k8s-monitoring-app/internal/core"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+)
+
+// Comparators lists the comparisons a rule can make between a field and its threshold
+var Comparators = []string{">", ">=", "<", "<=", "==", "!="}
+
+// AlertRule raises an alert when a numeric field of the collected values
+// crosses a threshold, optionally for some time, e.g. pvc_percent > 85 or
+// memory_percent > 90 for 5 minutes
+type AlertRule struct {
+	ID                  string    `json:"id,omitempty"`
+	ApplicationMetricID string    `json:"application_metric_id" validate:"required"`
+	Field               string    `json:"field"`      // Numeric value field, e.g. pvc_percent
+	Comparator          string    `json:"comparator"` // >, >=, <, <=, == or !=
+	Threshold           *float64  `json:"threshold"`
+	ForSeconds          *int      `json:"for_seconds,omitempty"` // How long the condition must hold before the rule fires, 0 fires right away; left unchanged on update when omitted
+	Severity            string    `json:"severity,omitempty"`    // info, warning or critical; defaults to warning
+	Enabled             *bool     `json:"enabled,omitempty"`     // Defaults to true; left unchanged on update when omitted
+	CreatedAt           time.Time `json:"created_at,omitempty"`
+	UpdatedAt           time.Time `json:"updated_at,omitempty"`
+}
+
+// IsEnabled reports whether the rule is evaluated
+func (r AlertRule) IsEnabled() bool {
+	return r.Enabled == nil || *r.Enabled
+}
+
+// For returns how long the condition must hold before the rule fires
+func (r AlertRule) For() time.Duration {
+	if r.ForSeconds == nil {
+		return 0
+	}
+	return time.Duration(*r.ForSeconds) * time.Second
+}
+
+// Validate checks the field, comparator, threshold, duration and severity
+func (r AlertRule) Validate() error {
+	if !applicationMetricValueModel.IsSeriesField(r.Field) {
+		return fmt.Errorf("unknown field %q, use one of: %s", r.Field, strings.Join(applicationMetricValueModel.SeriesFields, ", "))
+	}
+	if !isComparator(r.Comparator) {
+		return fmt.Errorf("unknown comparator %q, use one of: %s", r.Comparator, strings.Join(Comparators, " "))
+	}
+	if r.Threshold == nil {
+		return fmt.Errorf("threshold is required")
+	}
+	if r.ForSeconds != nil && *r.ForSeconds < 0 {
+		return fmt.Errorf("for_seconds must not be negative")
+	}
+	if r.Severity != "" && alerts.SeverityRank(r.Severity) < 0 {
+		return fmt.Errorf("unknown severity %q, use one of: %s", r.Severity, strings.Join(alerts.Severities, ", "))
+	}
+	return nil
+}
+
+// ValidateField checks that the field is one the metric type of the rule fills.
+// Values lack the other fields, which would read as 0 and keep a rule like
+// ready_pods < 2 breached on a HealthCheck forever.
+func (r AlertRule) ValidateField(metricType string, fields []string) error {
+	for _, f := range fields {
+		if f == r.Field {
+			return nil
+		}
+	}
+	if len(fields) == 0 {
+		return fmt.Errorf("%s metrics have no numeric field to watch", metricType)
+	}
+	return fmt.Errorf("field %q is not collected by %s metrics, use one of: %s", r.Field, metricType, strings.Join(fields, ", "))
+}
+
+func isComparator(comparator string) bool {
+	for _, c := range Comparators {
+		if c == comparator {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches reports whether a value meets the condition of the rule
+func (r AlertRule) Matches(value float64) bool {
+	if r.Threshold == nil {
+		return false
+	}
+	threshold := *r.Threshold
+	switch r.Comparator {
+	case ">":
+		return value > threshold
+	case ">=":
+		return value >= threshold
+	case "<":
+		return value < threshold
+	case "<=":
+		return value <= threshold
+	case "==":
+		return value == threshold
+	case "!=":
+		return value != threshold
+	}
+	return false
+}
+
+// Describe explains a breach of the rule, e.g. "pvc_percent 91.2 > 85 for 5m0s"
+func (r AlertRule) Describe(value float64) string {
+	reason := fmt.Sprintf("%s %s %s %s", r.Field, formatNumber(value), r.Comparator, formatNumber(*r.Threshold))
+	if d := r.For(); d > 0 {
+		reason = fmt.Sprintf("%s for %s", reason, d)
+	}
+	return reason
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+type Service interface {
+	Get(sc *core.HTTPServerContext) error
+	Add(sc *core.HTTPServerContext) error
+	List(sc *core.HTTPServerContext) error
+	ListByApplicationMetric(sc *core.HTTPServerContext) error
+	Update(sc *core.HTTPServerContext) error
+	Delete(sc *core.HTTPServerContext) error
+}
@@ -0,0 +1,104 @@
+package alert_state
+
+import "time"
+
+// Alert states of an application metric
+const (
+	StateOK       = "ok"       // No problem
+	StatePending  = "pending"  // Failing, not long enough to fire yet
+	StateFiring   = "firing"   // Failing, the alert was raised
+	StateResolved = "resolved" // Recovered after firing
+)
+
+// AlertState tracks the alert lifecycle of an application metric:
+// ok -> pending -> firing -> resolved. A pending problem that recovers before
+// firing goes back to ok without any notification.
+type AlertState struct {
+	ApplicationMetricID  string     `json:"application_metric_id"`
+	State                string     `json:"state"`
+	Reason               string     `json:"reason,omitempty"`
+	Severity             string     `json:"severity,omitempty"` // Severity of the current or last problem
+	ConsecutiveFailures  int        `json:"consecutive_failures"`
+	ConsecutiveSuccesses int        `json:"consecutive_successes"` // Healthy values seen while firing
+	StartedAt            *time.Time `json:"started_at,omitempty"`  // First failure of the current or last problem
+	FiredAt              *time.Time `json:"fired_at,omitempty"`
+	ResolvedAt           *time.Time `json:"resolved_at,omitempty"`
+	NotifiedAt           *time.Time `json:"notified_at,omitempty"` // When the firing alert was delivered
+	SilenceID            string     `json:"silence_id,omitempty"`  // Silence holding back the firing alert
+	AlertID              string     `json:"alert_id,omitempty"`    // Alert history entry of the current or last problem
+	UpdatedAt            time.Time  `json:"updated_at"`
+	DurationSeconds      int64      `json:"duration_seconds"` // How long the problem lasted, or has lasted so far
+	FlapState
+}
+
+// FlapState tracks whether the health of a metric keeps changing. It outlives
+// the problems of the metric.
+type FlapState struct {
+	Flapping      bool       `json:"flapping"`
+	FlapScore     float64    `json:"flap_score"` // Weighted share of health changes over the last checks, in percent
+	FlappingSince *time.Time `json:"flapping_since,omitempty"`
+	FlapAlertID   string     `json:"flap_alert_id,omitempty"` // Alert history entry of the flapping alert
+}
+
+// Active reports whether the metric is failing
+func (s AlertState) Active() bool {
+	return s.State == StatePending || s.State == StateFiring
+}
+
+// Duration returns how long the problem lasted, up to now when still active
+func (s AlertState) Duration(now time.Time) time.Duration {
+	if s.StartedAt == nil {
+		return 0
+	}
+	end := now
+	if !s.Active() && s.ResolvedAt != nil {
+		end = *s.ResolvedAt
+	}
+	if end.Before(*s.StartedAt) {
+		return 0
+	}
+	return end.Sub(*s.StartedAt).Round(time.Second)
+}
+
+// Observe moves the state with the outcome of a collection. A problem goes
+// from pending to firing after failAfter consecutive failures; a firing alert
+// resolves after recoverAfter consecutive healthy values, and a pending one
+// clears on the first. It returns the previous state.
+func (s *AlertState) Observe(problem bool, reason, severity string, failAfter, recoverAfter int, now time.Time) string {
+	previous := s.State
+	if failAfter < 1 {
+		failAfter = 1
+	}
+	if recoverAfter < 1 {
+		recoverAfter = 1
+	}
+
+	if !problem {
+		switch s.State {
+		case StateFiring:
+			s.ConsecutiveSuccesses++
+			if s.ConsecutiveSuccesses >= recoverAfter {
+				s.State = StateResolved
+				s.ResolvedAt = &now
+				s.ConsecutiveFailures = 0
+				s.ConsecutiveSuccesses = 0
+			}
+		case StatePending:
+			*s = AlertState{ApplicationMetricID: s.ApplicationMetricID, State: StateOK, FlapState: s.FlapState}
+		}
+		return previous
+	}
+
+	if !s.Active() {
+		*s = AlertState{ApplicationMetricID: s.ApplicationMetricID, State: StatePending, StartedAt: &now, FlapState: s.FlapState}
+	}
+	s.ConsecutiveFailures++
+	s.ConsecutiveSuccesses = 0
+	s.Reason = reason
+	s.Severity = severity
+	if s.State == StatePending && s.ConsecutiveFailures >= failAfter {
+		s.State = StateFiring
+		s.FiredAt = &now
+	}
+	return previous
+}
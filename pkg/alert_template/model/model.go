@@ -0,0 +1,186 @@
+package alert_template
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"k8s-monitoring-app/internal/alerts"
+	"k8s-monitoring-app/internal/core"
+	applicationModel "k8s-monitoring-app/pkg/application/model"
+	applicationMetricValueModel "k8s-monitoring-app/pkg/application_metric_value/model"
+	projectModel "k8s-monitoring-app/pkg/project/model"
+)
+
+// AlertTemplate renders the message of the alerts of a metric type and/or
+// channel kind with Go text/template. Empty scopes match every metric type or
+// channel; the most specific template wins, the metric type before the channel.
+// Alerts without a template keep the default message.
+type AlertTemplate struct {
+	ID           string    `json:"id,omitempty"`
+	MetricTypeID string    `json:"metric_type_id,omitempty"` // Empty for every metric type
+	Channel      string    `json:"channel,omitempty"`        // Channel kind, e.g. slack; empty for every channel
+	Title        string    `json:"title,omitempty"`          // Title template; empty keeps the default title
+	Body         string    `json:"body"`                     // Message template, shown instead of the default fields
+	CreatedAt    time.Time `json:"created_at,omitempty"`
+	UpdatedAt    time.Time `json:"updated_at,omitempty"`
+}
+
+// Data is what alert templates are rendered against
+type Data struct {
+	Alert       alerts.Alert                             // Title, Status, Severity, Reason, StartedAt, Time...
+	Project     projectModel.Project                     // Project of the application
+	Application applicationModel.Application             // Application of the metric
+	Config      map[string]interface{}                   // Metric configuration, credentials redacted
+	Value       *applicationMetricValueModel.MetricValue // Value of this collection; nil when it failed
+	Previous    *applicationMetricValueModel.MetricValue // Previous stored value; nil for the first one
+}
+
+// Validate checks the scope and that the templates parse
+func (t AlertTemplate) Validate() error {
+	if t.Channel != "" && !isKind(t.Channel) {
+		return fmt.Errorf("unknown channel %q, use one of: %s", t.Channel, strings.Join(alerts.Kinds, ", "))
+	}
+	if strings.TrimSpace(t.Body) == "" {
+		return fmt.Errorf("body is required")
+	}
+	if _, err := parse("title", t.Title); err != nil {
+		return fmt.Errorf("invalid title: %w", err)
+	}
+	if _, err := parse("body", t.Body); err != nil {
+		return fmt.Errorf("invalid body: %w", err)
+	}
+	return nil
+}
+
+func isKind(kind string) bool {
+	for _, k := range alerts.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches reports whether the template applies to the alerts of a metric type
+// sent through a channel kind
+func (t AlertTemplate) Matches(metricTypeID, channel string) bool {
+	return (t.MetricTypeID == "" || t.MetricTypeID == metricTypeID) &&
+		(t.Channel == "" || t.Channel == channel)
+}
+
+// specificity ranks matching templates: metric type and channel, metric type,
+// channel, then the catch-all template
+func (t AlertTemplate) specificity() int {
+	rank := 0
+	if t.MetricTypeID != "" {
+		rank += 2
+	}
+	if t.Channel != "" {
+		rank++
+	}
+	return rank
+}
+
+// Select returns the most specific template for the alerts of a metric type
+// sent through a channel kind, or nil when none applies
+func Select(templates []AlertTemplate, metricTypeID, channel string) *AlertTemplate {
+	var selected *AlertTemplate
+	for i := range templates {
+		t := &templates[i]
+		if t.Matches(metricTypeID, channel) && (selected == nil || t.specificity() > selected.specificity()) {
+			selected = t
+		}
+	}
+	return selected
+}
+
+// Render returns the title and the message of an alert. The title is the
+// alert title when the template has none.
+func (t AlertTemplate) Render(data Data) (string, string, error) {
+	title := data.Alert.Title
+	if t.Title != "" {
+		rendered, err := execute("title", t.Title, data)
+		if err != nil {
+			return "", "", fmt.Errorf("title: %w", err)
+		}
+		title = strings.TrimSpace(rendered)
+	}
+
+	body, err := execute("body", t.Body, data)
+	if err != nil {
+		return "", "", fmt.Errorf("body: %w", err)
+	}
+	return title, strings.TrimSpace(body), nil
+}
+
+// funcs are the functions alert templates can call besides the text/template
+// builtins
+var funcs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	// date formats a time with a Go layout, e.g. {{ date "2006-01-02" .Value.CertificateExpiration }}
+	"date": func(layout string, t time.Time) string {
+		return t.UTC().Format(layout)
+	},
+	"topPartitions": TopPartitions,
+}
+
+func parse(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(funcs).Option("missingkey=zero").Parse(text)
+}
+
+func execute(name, text string, data Data) (string, error) {
+	tmpl, err := parse(name, text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// PartitionLag is the lag of one partition of a topic
+type PartitionLag struct {
+	Topic     string
+	Partition int32
+	Lag       int64
+}
+
+// TopPartitions returns the n partitions with the most lag of a Kafka consumer
+// lag value, e.g. {{ range topPartitions 3 .Value }}
+func TopPartitions(n int, value *applicationMetricValueModel.MetricValue) []PartitionLag {
+	partitions := []PartitionLag{}
+	if value == nil {
+		return partitions
+	}
+
+	topics := append([]applicationMetricValueModel.KafkaTopicLag{}, value.KafkaTopicLags...)
+	for _, group := range value.KafkaGroupLags {
+		topics = append(topics, group.TopicLags...)
+	}
+	for _, topic := range topics {
+		for _, p := range topic.PartitionLags {
+			partitions = append(partitions, PartitionLag{Topic: topic.Topic, Partition: p.Partition, Lag: p.Lag})
+		}
+	}
+
+	sort.SliceStable(partitions, func(i, j int) bool { return partitions[i].Lag > partitions[j].Lag })
+	if n >= 0 && len(partitions) > n {
+		partitions = partitions[:n]
+	}
+	return partitions
+}
+
+type Service interface {
+	Get(sc *core.HTTPServerContext) error
+	Add(sc *core.HTTPServerContext) error
+	List(sc *core.HTTPServerContext) error
+	Update(sc *core.HTTPServerContext) error
+	Delete(sc *core.HTTPServerContext) error
+}
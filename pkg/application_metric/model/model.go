@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"k8s-monitoring-app/internal/core"
+
+	"github.com/robfig/cron/v3"
 )
 
 // Configuration stores metric-specific configuration in JSONB format
@@ -207,15 +209,65 @@ func (c *Configuration) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MinScheduleIntervalSeconds is the shortest per-metric collection interval allowed
+const MinScheduleIntervalSeconds = 10
+
+// Schedule controls how often a metric is collected. A cron expression takes
+// precedence over the interval; when both are empty the global
+// METRICS_COLLECTION_INTERVAL is used.
+type Schedule struct {
+	IntervalSeconds int    `json:"interval_seconds,omitempty"` // Collect every N seconds
+	CronExpression  string `json:"cron_expression,omitempty"`  // Standard 5-field cron or descriptor (e.g. "@hourly")
+	JitterSeconds   int    `json:"jitter_seconds,omitempty"`   // Random delay of up to N seconds added to each run
+}
+
+// Validate checks the schedule values and that the cron expression parses
+func (s Schedule) Validate() error {
+	if s.IntervalSeconds < 0 {
+		return fmt.Errorf("interval_seconds must not be negative")
+	}
+	if s.IntervalSeconds > 0 && s.IntervalSeconds < MinScheduleIntervalSeconds {
+		return fmt.Errorf("interval_seconds must be at least %d", MinScheduleIntervalSeconds)
+	}
+	if s.JitterSeconds < 0 {
+		return fmt.Errorf("jitter_seconds must not be negative")
+	}
+	if s.CronExpression != "" {
+		if _, err := cron.ParseStandard(s.CronExpression); err != nil {
+			return fmt.Errorf("invalid cron_expression: %w", err)
+		}
+	}
+	return nil
+}
+
 type ApplicationMetric struct {
 	ID            string          `json:"id,omitempty"`
 	ApplicationID string          `json:"application_id" validate:"required"`
 	TypeID        string          `json:"metric_type_id" validate:"required"`
 	Configuration json.RawMessage `json:"configuration" validate:"required"`
+	Schedule      *Schedule       `json:"schedule,omitempty"`       // Left unchanged on update when omitted
+	RetentionDays *int            `json:"retention_days,omitempty"` // Days to keep the values, 0 uses the project's retention; left unchanged on update when omitted
+	FailAfter     *int            `json:"fail_after,omitempty"`     // Consecutive failures before the alert fires, 0 uses the default; left unchanged on update when omitted
+	RecoverAfter  *int            `json:"recover_after,omitempty"`  // Consecutive healthy values before a firing alert resolves, 0 uses 1; left unchanged on update when omitted
+	FailureAlerts *bool           `json:"failure_alerts,omitempty"` // Whether failed values, e.g. a health check down, fire an alert besides the rules; defaults to the metric type's default; left unchanged on update when omitted
 	CreatedAt     time.Time       `json:"created_at,omitempty"`
 	UpdatedAt     time.Time       `json:"updated_at,omitempty"`
 }
 
+// MaxConsecutiveThreshold bounds fail_after and recover_after
+const MaxConsecutiveThreshold = 100
+
+// ValidateThresholds checks fail_after and recover_after
+func (m ApplicationMetric) ValidateThresholds() error {
+	if m.FailAfter != nil && (*m.FailAfter < 0 || *m.FailAfter > MaxConsecutiveThreshold) {
+		return fmt.Errorf("fail_after must be between 0 and %d", MaxConsecutiveThreshold)
+	}
+	if m.RecoverAfter != nil && (*m.RecoverAfter < 0 || *m.RecoverAfter > MaxConsecutiveThreshold) {
+		return fmt.Errorf("recover_after must be between 0 and %d", MaxConsecutiveThreshold)
+	}
+	return nil
+}
+
 type Service interface {
 	Get(sc *core.HTTPServerContext) error
 	Add(sc *core.HTTPServerContext) error
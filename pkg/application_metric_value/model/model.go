@@ -2,9 +2,13 @@ package application_metric_value
 
 import (
 	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
 	"time"
 
 	"k8s-monitoring-app/internal/core"
+	"k8s-monitoring-app/internal/env"
 )
 
 // MetricValue stores the actual metric data in JSONB format
@@ -127,8 +131,302 @@ type ApplicationMetricValue struct {
 	UpdatedAt           time.Time       `json:"updated_at,omitempty"`
 }
 
+// Series aggregations
+const (
+	AggregationAvg = "avg"
+	AggregationMin = "min"
+	AggregationMax = "max"
+	AggregationP95 = "p95"
+)
+
+// Series resolutions: raw values, or the hourly and daily rollups
+const (
+	ResolutionRaw  = "raw"
+	ResolutionHour = "hour"
+	ResolutionDay  = "day"
+)
+
+// Retention returns how long data of a resolution is kept, following
+// METRICS_RETENTION_DAYS for raw values and the rollup retention settings
+func Retention(resolution string) time.Duration {
+	days := env.METRICS_RETENTION_DAYS
+	switch resolution {
+	case ResolutionHour:
+		days = env.METRICS_ROLLUP_HOURLY_RETENTION_DAYS
+	case ResolutionDay:
+		days = env.METRICS_ROLLUP_DAILY_RETENTION_DAYS
+	}
+	if days <= 0 {
+		days = 30
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// MaxRetentionDays is the longest retention override allowed on a metric or project
+const MaxRetentionDays = 3650
+
+// ValidateRetentionDays checks a retention override, where 0 means no override
+func ValidateRetentionDays(days int) error {
+	if days < 0 {
+		return fmt.Errorf("retention_days must not be negative")
+	}
+	if days > MaxRetentionDays {
+		return fmt.Errorf("retention_days must be at most %d", MaxRetentionDays)
+	}
+	return nil
+}
+
+// RawRetention returns how long the raw values of a metric are kept: its own
+// retention override, else the override of its project, else METRICS_RETENTION_DAYS
+func RawRetention(metricDays, projectDays int) time.Duration {
+	switch {
+	case metricDays > 0:
+		return time.Duration(metricDays) * 24 * time.Hour
+	case projectDays > 0:
+		return time.Duration(projectDays) * 24 * time.Hour
+	}
+	return Retention(ResolutionRaw)
+}
+
+// SeriesFieldUp is the series of values that didn't represent a failure:
+// 1 when the value was healthy and 0 when it would raise an alert, so its
+// average is the up ratio
+const SeriesFieldUp = "up"
+
+// SeriesFields lists the numeric MetricValue fields that can be queried as a series
+var SeriesFields = []string{
+	"response_time_ms", "status_code",
+	"restart_count", "total_pods", "ready_pods",
+	"memory_usage_bytes", "memory_limit_bytes", "memory_percent",
+	"cpu_usage_millicores", "cpu_limit_millicores", "cpu_percent",
+	"pvc_capacity_bytes", "pvc_used_bytes", "pvc_percent",
+	"active_nodes_count",
+	"connection_time_ms", "connection_ping_time_ms",
+	"certificate_days_to_expire",
+	"kafka_total_lag",
+}
+
+// IsSeriesField reports whether a field can be queried as a series
+func IsSeriesField(field string) bool {
+	for _, f := range SeriesFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Field returns a numeric field of the value by its JSON name. Like in the
+// series, a field missing from the value counts as zero.
+func (v MetricValue) Field(name string) float64 {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return 0
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return 0
+	}
+	f, _ := fields[name].(float64)
+	return f
+}
+
+// Aggregate reduces the values of a bucket with the given aggregation
+func Aggregate(aggregation string, values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	switch aggregation {
+	case AggregationMin:
+		lowest := values[0]
+		for _, v := range values[1:] {
+			if v < lowest {
+				lowest = v
+			}
+		}
+		return lowest
+	case AggregationMax:
+		highest := values[0]
+		for _, v := range values[1:] {
+			if v > highest {
+				highest = v
+			}
+		}
+		return highest
+	case AggregationP95:
+		// Nearest-rank percentile
+		sorted := append([]float64(nil), values...)
+		sort.Float64s(sorted)
+		rank := int(math.Ceil(0.95 * float64(len(sorted))))
+		return sorted[rank-1]
+	default:
+		sum := 0.0
+		for _, v := range values {
+			sum += v
+		}
+		return sum / float64(len(values))
+	}
+}
+
+// Sample is a single numeric value of a series
+type Sample struct {
+	Time  time.Time
+	Value float64
+}
+
+// BucketSamples aggregates time-ordered samples into buckets of step, aligned
+// to multiples of the step. Buckets without samples are omitted.
+func BucketSamples(samples []Sample, step time.Duration, aggregation string) []SeriesPoint {
+	points := []SeriesPoint{}
+
+	var bucketStart time.Time
+	var bucket []float64
+	flush := func() {
+		if len(bucket) == 0 {
+			return
+		}
+		points = append(points, SeriesPoint{
+			Timestamp: bucketStart,
+			Value:     Aggregate(aggregation, bucket),
+			Count:     len(bucket),
+		})
+		bucket = bucket[:0]
+	}
+
+	for _, sample := range samples {
+		start := sample.Time.UTC().Truncate(step)
+		if !start.Equal(bucketStart) {
+			flush()
+			bucketStart = start
+		}
+		bucket = append(bucket, sample.Value)
+	}
+	flush()
+
+	return points
+}
+
+// FieldStats summarizes the values of one field within a rollup bucket
+type FieldStats struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+	Sum float64 `json:"sum"`
+	P95 float64 `json:"p95"` // Daily rollups keep the highest hourly p95
+}
+
+// Rollup aggregates the values of an application metric over one hour or day.
+// Fields only holds the fields present in the bucket; like in the raw values,
+// a missing field means zero.
+type Rollup struct {
+	ApplicationMetricID string                `json:"application_metric_id"`
+	Resolution          string                `json:"resolution"`
+	BucketStart         time.Time             `json:"bucket_start"`
+	Samples             int                   `json:"samples"`    // Values in the bucket
+	UpSamples           int                   `json:"up_samples"` // Values that didn't represent a failure
+	Fields              map[string]FieldStats `json:"fields"`
+}
+
+// UpRatio is the share of values in the bucket that didn't represent a failure
+func (r Rollup) UpRatio() float64 {
+	if r.Samples == 0 {
+		return 0
+	}
+	return float64(r.UpSamples) / float64(r.Samples)
+}
+
+// RollupPoints aggregates time-ordered rollups into buckets of step. The step
+// should be a multiple of the rollup resolution. Averages are weighted by the
+// samples of each rollup, and p95 is approximated by the highest rollup p95.
+func RollupPoints(rollups []Rollup, field string, step time.Duration, aggregation string) []SeriesPoint {
+	points := []SeriesPoint{}
+
+	var point *SeriesPoint
+	var sum float64
+	flush := func() {
+		if point == nil {
+			return
+		}
+		if aggregation == AggregationAvg && point.Count > 0 {
+			point.Value = sum / float64(point.Count)
+		}
+		points = append(points, *point)
+		point = nil
+	}
+
+	for _, rollup := range rollups {
+		if rollup.Samples == 0 {
+			continue
+		}
+
+		start := rollup.BucketStart.UTC().Truncate(step)
+		if point != nil && !start.Equal(point.Timestamp) {
+			flush()
+		}
+
+		stats := rollup.Fields[field]
+		if field == SeriesFieldUp {
+			ratio := rollup.UpRatio()
+			stats = FieldStats{Min: ratio, Max: ratio, Sum: float64(rollup.UpSamples), P95: ratio}
+		}
+
+		var value float64
+		switch aggregation {
+		case AggregationMin:
+			value = stats.Min
+		case AggregationMax:
+			value = stats.Max
+		case AggregationP95:
+			value = stats.P95
+		}
+
+		if point == nil {
+			point = &SeriesPoint{Timestamp: start, Value: value}
+			sum = 0
+		} else if (aggregation == AggregationMin && value < point.Value) || (aggregation != AggregationMin && value > point.Value) {
+			point.Value = value
+		}
+		point.Count += rollup.Samples
+		sum += stats.Sum
+	}
+	flush()
+
+	return points
+}
+
+// SeriesQuery selects a numeric field of the values stored in a time range,
+// aggregated into buckets of Step
+type SeriesQuery struct {
+	ApplicationMetricID string
+	Field               string
+	From                time.Time
+	To                  time.Time
+	Step                time.Duration
+	Aggregation         string
+}
+
+// SeriesPoint is the aggregate of the values collected within one bucket
+type SeriesPoint struct {
+	Timestamp time.Time `json:"timestamp"` // Start of the bucket
+	Value     float64   `json:"value"`
+	Count     int       `json:"count"` // Values in the bucket
+}
+
+// Series is a bucketed time series of a metric field
+type Series struct {
+	ApplicationMetricID string        `json:"application_metric_id"`
+	Field               string        `json:"field"`
+	Aggregation         string        `json:"agg"`
+	From                time.Time     `json:"from"`
+	To                  time.Time     `json:"to"`
+	StepSeconds         int64         `json:"step_seconds"`
+	Resolution          string        `json:"resolution"` // raw, hour or day
+	Points              []SeriesPoint `json:"points"`     // Buckets without values are omitted
+}
+
 type Service interface {
 	Get(sc *core.HTTPServerContext) error
 	ListByApplicationMetric(sc *core.HTTPServerContext) error
 	GetLatestByApplication(sc *core.HTTPServerContext) error
+	Series(sc *core.HTTPServerContext) error
 }
@@ -0,0 +1,85 @@
+package escalation_policy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s-monitoring-app/internal/core"
+)
+
+// EscalationPolicy sends the firing alerts nobody acknowledges to more
+// channels, step after step, e.g. email after 30 minutes then PagerDuty after
+// an hour. It applies to the alerts of the projects it is attached to; the
+// channels of its steps only get the alerts that escalate.
+type EscalationPolicy struct {
+	ID          string    `json:"id,omitempty"`
+	Name        string    `json:"name" validate:"required"`
+	Description string    `json:"description,omitempty"`
+	Steps       []Step    `json:"steps" validate:"required"` // In order of their delay
+	CreatedAt   time.Time `json:"created_at,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+}
+
+// Step is one escalation of an alert: the channels notified once the alert has
+// fired for DelayMinutes without being acknowledged or resolved
+type Step struct {
+	DelayMinutes           int      `json:"delay_minutes"` // Minutes after the alert fired
+	NotificationChannelIDs []string `json:"notification_channel_ids"`
+}
+
+// Delay returns how long after the alert fired the step is reached
+func (s Step) Delay() time.Duration {
+	return time.Duration(s.DelayMinutes) * time.Minute
+}
+
+// Validate checks the name and that the steps come in order of their delay,
+// each with at least one channel
+func (p EscalationPolicy) Validate() error {
+	if strings.TrimSpace(p.Name) == "" {
+		return errors.New("name is required")
+	}
+	if len(p.Steps) == 0 {
+		return errors.New("at least one step is required")
+	}
+	previous := 0
+	for i, step := range p.Steps {
+		if step.DelayMinutes <= previous {
+			if i == 0 {
+				return errors.New("step 1: delay_minutes must be greater than 0")
+			}
+			return fmt.Errorf("step %d: delay_minutes must be greater than the %d minutes of step %d", i+1, previous, i)
+		}
+		if len(step.NotificationChannelIDs) == 0 {
+			return fmt.Errorf("step %d: at least one notification channel is required", i+1)
+		}
+		for _, id := range step.NotificationChannelIDs {
+			if strings.TrimSpace(id) == "" {
+				return fmt.Errorf("step %d: notification channel IDs can't be empty", i+1)
+			}
+		}
+		previous = step.DelayMinutes
+	}
+	return nil
+}
+
+// StepReached returns the last step, counting from 1, reached by an alert that
+// has fired for the given time, 0 when none is
+func (p EscalationPolicy) StepReached(firedFor time.Duration) int {
+	reached := 0
+	for i, step := range p.Steps {
+		if firedFor >= step.Delay() {
+			reached = i + 1
+		}
+	}
+	return reached
+}
+
+type Service interface {
+	Get(sc *core.HTTPServerContext) error
+	Add(sc *core.HTTPServerContext) error
+	List(sc *core.HTTPServerContext) error
+	Update(sc *core.HTTPServerContext) error
+	Delete(sc *core.HTTPServerContext) error
+}
@@ -0,0 +1,59 @@
+package monitoring
+
+import (
+	"time"
+
+	"k8s-monitoring-app/internal/core"
+)
+
+// RunStats summarizes the metrics dispatched together in one scheduler tick
+type RunStats struct {
+	StartedAt      time.Time `json:"started_at"`
+	FinishedAt     time.Time `json:"finished_at"`
+	DurationMs     int64     `json:"duration_ms"`
+	Total          int       `json:"total"`
+	Succeeded      int       `json:"succeeded"`
+	Failed         int       `json:"failed"`
+	Skipped        int       `json:"skipped"`
+	SkippedMetrics []string  `json:"skipped_metrics,omitempty"` // Application metric IDs that were not collected
+}
+
+// Status reports the current state of the collection scheduler
+type Status struct {
+	Collecting           bool      `json:"collecting"` // Whether this replica runs collection (it holds the Lease when leader election is enabled)
+	LeaderElection       bool      `json:"leader_election"`
+	Leader               string    `json:"leader,omitempty"` // Identity of the current leader, when leader election is enabled
+	Scheduled            int       `json:"scheduled"`        // Application metrics tracked by the scheduler
+	InFlight             int       `json:"in_flight"`        // Application metrics being collected right now
+	Workers              int       `json:"workers"`
+	MetricTimeoutSeconds int       `json:"metric_timeout_seconds"`
+	SkippedRuns          int64     `json:"skipped_runs"` // Scheduler ticks skipped because the previous tick was still dispatching
+	LastRun              *RunStats `json:"last_run,omitempty"`
+}
+
+// Check is the result of a single readiness check
+type Check struct {
+	Status  string `json:"status"` // "ok" or "fail"
+	Message string `json:"message,omitempty"`
+}
+
+// Readiness reports whether the monitoring engine is able to do its job
+type Readiness struct {
+	Status            string           `json:"status"` // "ready" or "not_ready"
+	Checks            map[string]Check `json:"checks"`
+	LastRun           *RunStats        `json:"last_run,omitempty"`
+	LastSuccessAt     *time.Time       `json:"last_success_at,omitempty"`  // Last time a metric was collected successfully
+	CollectionLagSecs int64            `json:"collection_lag_seconds"`     // How long the most overdue metric has been waiting
+	MaxLagSecs        int64            `json:"max_collection_lag_seconds"` // Lag above which readiness fails
+}
+
+type Service interface {
+	Start() error
+	Stop()
+	Status(sc *core.HTTPServerContext) error
+	Collect(sc *core.HTTPServerContext) error
+	Test(sc *core.HTTPServerContext) error
+	AlertState(sc *core.HTTPServerContext) error
+	Metrics(sc *core.HTTPServerContext) error
+	Ready(sc *core.HTTPServerContext) error
+}
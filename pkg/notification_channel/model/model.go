@@ -0,0 +1,54 @@
+package notification_channel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s-monitoring-app/internal/alerts"
+	"k8s-monitoring-app/internal/core"
+)
+
+// NotificationChannel is an alert destination of a project. A channel with an
+// application ID belongs to that application only; when an application has
+// enabled channels of its own, its alerts skip the project channels.
+type NotificationChannel struct {
+	ID            string          `json:"id,omitempty"`
+	ProjectID     string          `json:"project_id" validate:"required"`
+	ApplicationID string          `json:"application_id,omitempty"` // Empty for the project channels
+	Name          string          `json:"name" validate:"required"`
+	Kind          string          `json:"kind" validate:"required"` // slack, webhook, teams, google_chat, discord, telegram, email, pagerduty or opsgenie
+	Settings      json.RawMessage `json:"settings" validate:"required"`
+	Enabled       *bool           `json:"enabled,omitempty"` // Defaults to true; left unchanged on update when omitted
+	CreatedAt     time.Time       `json:"created_at,omitempty"`
+	UpdatedAt     time.Time       `json:"updated_at,omitempty"`
+}
+
+// IsEnabled reports whether alerts are sent to the channel
+func (c NotificationChannel) IsEnabled() bool {
+	return c.Enabled == nil || *c.Enabled
+}
+
+// Notifier returns the notifier delivering alerts to the channel, failing when
+// its settings don't fit its kind
+func (c NotificationChannel) Notifier() (alerts.Notifier, error) {
+	if len(c.Settings) == 0 {
+		return nil, errors.New("settings are required")
+	}
+	settings := alerts.Settings{}
+	if err := json.Unmarshal(c.Settings, &settings); err != nil {
+		return nil, fmt.Errorf("settings must be a JSON object: %w", err)
+	}
+	return alerts.New(c.Kind, settings)
+}
+
+type Service interface {
+	Get(sc *core.HTTPServerContext) error
+	Add(sc *core.HTTPServerContext) error
+	List(sc *core.HTTPServerContext) error
+	ListByProject(sc *core.HTTPServerContext) error
+	Update(sc *core.HTTPServerContext) error
+	Delete(sc *core.HTTPServerContext) error
+	Test(sc *core.HTTPServerContext) error
+}
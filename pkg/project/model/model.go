@@ -5,9 +5,12 @@ import (
 )
 
 type Project struct {
-	ID          string `json:"id,omitempty"`
-	Name        string `json:"name" validate:"required"`
-	Description string `json:"description" validate:"required"`
+	ID            string `json:"id,omitempty"`
+	Name          string `json:"name" validate:"required"`
+	Description   string `json:"description" validate:"required"`
+	RetentionDays *int   `json:"retention_days,omitempty"` // Days to keep the values of its metrics, 0 uses METRICS_RETENTION_DAYS; left unchanged on update when omitted
+	// Escalation policy of its alerts; left unchanged on update when omitted, "" detaches it
+	EscalationPolicyID *string `json:"escalation_policy_id,omitempty"`
 }
 
 type Service interface {
@@ -0,0 +1,176 @@
+package silence
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s-monitoring-app/internal/core"
+)
+
+// Weekdays lists the days a maintenance window can repeat on
+var Weekdays = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+// Silence holds back the alerts of a project, application, metric and/or
+// metric type, e.g. during a planned deploy. The alerts are still recorded in
+// the alert state but not delivered. A silence with a Window is a recurring
+// maintenance window, active only inside the window.
+type Silence struct {
+	ID                  string     `json:"id,omitempty"`
+	ProjectID           string     `json:"project_id,omitempty"`
+	ApplicationID       string     `json:"application_id,omitempty"`
+	ApplicationMetricID string     `json:"application_metric_id,omitempty"`
+	MetricTypeID        string     `json:"metric_type_id,omitempty"`
+	StartsAt            time.Time  `json:"starts_at"`         // Defaults to now
+	EndsAt              *time.Time `json:"ends_at,omitempty"` // Required unless the silence has a window
+	Window              *Window    `json:"window,omitempty"`  // Recurring maintenance window
+	CreatedBy           string     `json:"created_by,omitempty"`
+	Reason              string     `json:"reason"`
+	Active              bool       `json:"active"` // Whether the silence holds back alerts right now
+	CreatedAt           time.Time  `json:"created_at,omitempty"`
+	UpdatedAt           time.Time  `json:"updated_at,omitempty"`
+}
+
+// Window repeats a silence on some weekdays between two times of day, e.g.
+// every Sunday from 02:00 to 04:00. An end before the start crosses midnight.
+type Window struct {
+	Weekdays []string `json:"weekdays,omitempty"` // sunday ... saturday; every day when empty
+	Start    string   `json:"start"`              // HH:MM
+	End      string   `json:"end"`                // HH:MM
+	Timezone string   `json:"timezone,omitempty"` // IANA name, e.g. America/Sao_Paulo; defaults to UTC
+}
+
+// Validate checks that the silence has a scope, a reason and a valid period
+func (s Silence) Validate() error {
+	if s.ProjectID == "" && s.ApplicationID == "" && s.ApplicationMetricID == "" && s.MetricTypeID == "" {
+		return fmt.Errorf("at least one of project_id, application_id, application_metric_id or metric_type_id is required")
+	}
+	if strings.TrimSpace(s.Reason) == "" {
+		return fmt.Errorf("reason is required")
+	}
+	if s.Window == nil && s.EndsAt == nil {
+		return fmt.Errorf("ends_at is required for a silence without window")
+	}
+	if s.EndsAt != nil && !s.EndsAt.After(s.StartsAt) {
+		return fmt.Errorf("ends_at must be after starts_at")
+	}
+	if s.Window != nil {
+		if err := s.Window.Validate(); err != nil {
+			return fmt.Errorf("invalid window: %w", err)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether the silence covers an alert of the given metric.
+// Every scope the silence sets must match.
+func (s Silence) Matches(projectID, applicationID, applicationMetricID, metricTypeID string) bool {
+	return (s.ProjectID == "" || s.ProjectID == projectID) &&
+		(s.ApplicationID == "" || s.ApplicationID == applicationID) &&
+		(s.ApplicationMetricID == "" || s.ApplicationMetricID == applicationMetricID) &&
+		(s.MetricTypeID == "" || s.MetricTypeID == metricTypeID)
+}
+
+// ActiveAt reports whether the silence holds back alerts at the given time
+func (s Silence) ActiveAt(t time.Time) bool {
+	if t.Before(s.StartsAt) {
+		return false
+	}
+	if s.EndsAt != nil && !t.Before(*s.EndsAt) {
+		return false
+	}
+	return s.Window == nil || s.Window.Contains(t)
+}
+
+// Expired reports whether the silence will never be active again
+func (s Silence) Expired(now time.Time) bool {
+	return s.EndsAt != nil && !now.Before(*s.EndsAt)
+}
+
+// Validate checks the weekdays, the times of day and the timezone
+func (w Window) Validate() error {
+	for _, day := range w.Weekdays {
+		if weekday(day) < 0 {
+			return fmt.Errorf("unknown weekday %q, use one of: %s", day, strings.Join(Weekdays, ", "))
+		}
+	}
+	start, err := clockMinutes(w.Start)
+	if err != nil {
+		return fmt.Errorf("start: %w", err)
+	}
+	end, err := clockMinutes(w.End)
+	if err != nil {
+		return fmt.Errorf("end: %w", err)
+	}
+	if start == end {
+		return fmt.Errorf("start and end must differ")
+	}
+	if _, err := time.LoadLocation(w.Timezone); err != nil {
+		return fmt.Errorf("unknown timezone %q", w.Timezone)
+	}
+	return nil
+}
+
+// Contains reports whether a time falls inside the window. A window crossing
+// midnight belongs to the weekday it starts on.
+func (w Window) Contains(t time.Time) bool {
+	start, err := clockMinutes(w.Start)
+	if err != nil {
+		return false
+	}
+	end, err := clockMinutes(w.End)
+	if err != nil {
+		return false
+	}
+	location, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		return false
+	}
+
+	t = t.In(location)
+	minute := t.Hour()*60 + t.Minute()
+	if start < end {
+		return w.on(t.Weekday()) && minute >= start && minute < end
+	}
+	return (w.on(t.Weekday()) && minute >= start) || (w.on((t.Weekday()+6)%7) && minute < end)
+}
+
+// on reports whether the window repeats on a weekday
+func (w Window) on(day time.Weekday) bool {
+	if len(w.Weekdays) == 0 {
+		return true
+	}
+	for _, d := range w.Weekdays {
+		if weekday(d) == int(day) {
+			return true
+		}
+	}
+	return false
+}
+
+// weekday returns the number of a weekday name, or -1 when unknown
+func weekday(name string) int {
+	for i, day := range Weekdays {
+		if strings.EqualFold(day, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// clockMinutes parses a HH:MM time of day into minutes after midnight
+func clockMinutes(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a HH:MM time", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+type Service interface {
+	Get(sc *core.HTTPServerContext) error
+	Add(sc *core.HTTPServerContext) error
+	List(sc *core.HTTPServerContext) error
+	Update(sc *core.HTTPServerContext) error
+	Delete(sc *core.HTTPServerContext) error
+}